@@ -0,0 +1,220 @@
+package main
+
+import (
+	"ProjectManagementService/internal/backup"
+	"ProjectManagementService/internal/importer"
+	"ProjectManagementService/internal/models"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const usage = `usage: app [command] [flags]
+
+Without a command the service is started. Commands:
+  import   create users, projects or tasks from a CSV or JSON file, or
+           import a Jira, Trello or GitHub export
+  backup   write all data to a backup archive
+  restore  restore a backup archive into an empty database
+`
+
+// runCommand runs the command named by args[0] and returns the exit code of
+// the process.
+func runCommand(db *sql.DB, args []string) int {
+	switch args[0] {
+	case "import":
+		return runImport(db, args[1:])
+	case "backup":
+		return runBackup(db, args[1:])
+	case "restore":
+		return runRestore(db, args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %q\n%s", args[0], usage)
+	return 2
+}
+
+// runImport imports a file like POST /import/{kind}, or an export of
+// another tool like POST /import/{source}, and prints the report. It fails
+// when nothing was imported.
+func runImport(db *sql.DB, args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	kind := flags.String("kind", "", "users, projects or tasks")
+	sourceName := flags.String("source", "", "jira, trello or github, to import an export of that tool instead of -kind")
+	file := flags.String("file", "", "the file to import")
+	formatName := flags.String("format", "", "csv or json; defaults to the extension of the file")
+	mappingValue := flags.String("map", "", "column renames as source:field pairs, such as Summary:title")
+	usersValue := flags.String("users", "", "people of the -source export to users as username:email pairs")
+	defaultUser := flags.String("default-user", "", "email of the user of the unmapped people of the -source export")
+	dryRun := flags.Bool("dry-run", false, "validate without writing")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if (*kind == "") == (*sourceName == "") || *file == "" {
+		fmt.Fprintln(os.Stderr, "import: -file and one of -kind and -source are required")
+		flags.Usage()
+		return 2
+	}
+
+	in, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 1
+	}
+	defer in.Close()
+	imports := importer.New(models.NewImportModel(db), models.NewExternalModel(db))
+
+	if *sourceName != "" {
+		source, err := importer.ParseSource(*sourceName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 2
+		}
+		users, err := importer.ParseMapping(*usersValue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 2
+		}
+		export, err := importer.ReadExport(in, source)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %s: %v\n", *file, err)
+			return 1
+		}
+		report, err := imports.Sync(export, importer.SyncOptions{Users: users, DefaultUser: *defaultUser, DryRun: *dryRun})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		if !printJSON("import", report) || len(report.UnmappedUsers) > 0 || len(report.Errors) > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	if *formatName == "" {
+		*formatName = strings.TrimPrefix(filepath.Ext(*file), ".")
+	}
+	format, err := importer.ParseFormat(*formatName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 2
+	}
+	mapping, err := importer.ParseMapping(*mappingValue)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 2
+	}
+	records, ignored, err := importer.Read(in, format, importer.Kind(*kind), mapping)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %s: %v\n", *file, err)
+		return 1
+	}
+	report, err := imports.Import(importer.Kind(*kind), records, *dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 1
+	}
+	report.IgnoredColumns = ignored
+	if !printJSON("import", report) || report.Invalid > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runBackup writes a backup archive and prints its manifest. The archive is
+// written next to the file and renamed once complete, so that a failed
+// backup leaves no partial archive behind.
+func runBackup(db *sql.DB, args []string) int {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	file := flags.String("file", "", "the archive to write, such as backup.zip")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "backup: -file is required")
+		flags.Usage()
+		return 2
+	}
+	out, err := os.CreateTemp(filepath.Dir(*file), filepath.Base(*file)+".*.tmp")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+		return 1
+	}
+	defer os.Remove(out.Name())
+	manifest, err := backup.Create(db, out, time.Now())
+	if err == nil {
+		err = out.Close()
+	} else {
+		out.Close()
+	}
+	if err == nil {
+		err = os.Rename(out.Name(), *file)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+		return 1
+	}
+	if !printJSON("backup", manifest) {
+		return 1
+	}
+	return 0
+}
+
+// runRestore restores a backup archive into the empty database and prints
+// its manifest.
+func runRestore(db *sql.DB, args []string) int {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	file := flags.String("file", "", "the archive to restore")
+	verify := flags.Bool("verify", false, "only check the checksums of the archive")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "restore: -file is required")
+		flags.Usage()
+		return 2
+	}
+	in, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "restore: %v\n", err)
+		return 1
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "restore: %v\n", err)
+		return 1
+	}
+	archive, err := backup.OpenArchive(in, info.Size())
+	if err == nil && *verify {
+		err = archive.Verify()
+	} else if err == nil {
+		err = backup.Restore(db, archive)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "restore: %s: %v\n", *file, err)
+		return 1
+	}
+	if !printJSON("restore", archive.Manifest) {
+		return 1
+	}
+	return 0
+}
+
+// printJSON writes value to the standard output as JSON.
+func printJSON(command string, value any) bool {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", command, err)
+		return false
+	}
+	return true
+}
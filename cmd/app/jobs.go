@@ -0,0 +1,83 @@
+package main
+
+import (
+	"ProjectManagementService/internal/models"
+	"ProjectManagementService/internal/notifications"
+	"ProjectManagementService/internal/recurrence"
+	"ProjectManagementService/internal/scheduler"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// schedulerLockKey identifies the advisory lock held by the replica that runs jobs.
+const schedulerLockKey = 7_202_900
+
+func setupScheduler(db *sql.DB, jobRunModel models.JobRunModel, notificationModel models.NotificationModel, ruleModel models.RuleModel,
+	notifier *notifications.Notifier, emailNotifier *notifications.EmailNotifier, recurrences *recurrence.Service) *scheduler.Scheduler {
+	s := scheduler.New(scheduler.NewPostgresElector(db, schedulerLockKey), jobRunModel)
+
+	addJob(s, "due-date-reminders", "0 8 * * *", func(ctx context.Context, scheduledAt time.Time) error {
+		if err := notifier.SendDueDateReminders(scheduledAt); err != nil {
+			return err
+		}
+		if emailNotifier != nil {
+			return emailNotifier.SendDueDateReminders(scheduledAt)
+		}
+		return nil
+	})
+
+	addJob(s, "stale-tasks", "0 9 * * 1", func(ctx context.Context, scheduledAt time.Time) error {
+		return notifier.NotifyStaleTasks(scheduledAt.AddDate(0, 0, -envInt("STALE_TASK_DAYS", 14)))
+	})
+
+	// completing an occurrence creates the next one right away; this catches
+	// the occurrences whose date arrives first
+	addJob(s, "recurring-tasks", "15 * * * *", func(ctx context.Context, scheduledAt time.Time) error {
+		return recurrences.GenerateDue(scheduledAt)
+	})
+
+	// read notifications, job history and automation logs are the only data
+	// that piles up without ever being looked at again
+	addJob(s, "purge-trash", "30 3 * * *", func(ctx context.Context, scheduledAt time.Time) error {
+		before := scheduledAt.AddDate(0, 0, -envInt("RETENTION_DAYS", 30))
+		if _, err := notificationModel.PurgeRead(before); err != nil {
+			return err
+		}
+		if _, err := jobRunModel.PurgeRuns(before); err != nil {
+			return err
+		}
+		_, err := ruleModel.PurgeRuns(before)
+		return err
+	})
+
+	if emailNotifier != nil {
+		hour := envInt("DIGEST_HOUR", 7)
+		if hour < 0 || hour > 23 {
+			hour = 7
+		}
+		addJob(s, "daily-digest", fmt.Sprintf("0 %d * * *", hour), func(ctx context.Context, scheduledAt time.Time) error {
+			return emailNotifier.SendDailyDigests(scheduledAt)
+		})
+	}
+
+	return s
+}
+
+func addJob(s *scheduler.Scheduler, name, spec string, run scheduler.JobFunc) {
+	if err := s.Add(name, spec, run); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func envInt(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
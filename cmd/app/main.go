@@ -1,9 +1,16 @@
 package main
 
 import (
-	_ "ProjectManagementService/docs"
+	"ProjectManagementService/docs"
+	"ProjectManagementService/internal/automation"
+	"ProjectManagementService/internal/events"
 	"ProjectManagementService/internal/handlers"
+	"ProjectManagementService/internal/importer"
+	"ProjectManagementService/internal/mailer"
 	"ProjectManagementService/internal/models"
+	"ProjectManagementService/internal/notifications"
+	"ProjectManagementService/internal/recurrence"
+	"ProjectManagementService/internal/timeline"
 	"context"
 	"database/sql"
 	"github.com/gorilla/mux"
@@ -32,13 +39,77 @@ func main() {
 			log.Fatal("Could not close the database connection: ", err)
 		}
 	}(db)
-	userHandler := handlers.NewUserHandler(models.NewUserModel(db))
-	taskHandler := handlers.NewTaskHandler(models.NewTaskModel(db))
-	projectHandler := handlers.NewProjectHandler(models.NewProjectModel(db))
+	if len(os.Args) > 1 {
+		// run a command, see commands.go, instead of serving
+		code := runCommand(db, os.Args[1:])
+		if err := db.Close(); err != nil {
+			log.Printf("Could not close the database connection: %v\n", err)
+		}
+		os.Exit(code)
+	}
+	userModel := models.NewUserModel(db)
+	taskModel := models.NewTaskModel(db)
+	projectModel := models.NewProjectModel(db)
+	notificationModel := models.NewNotificationModel(db)
+	ruleModel := models.NewRuleModel(db)
+	recurrenceModel := models.NewRecurrenceModel(db)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := events.NewBus()
+	notifier := notifications.NewNotifier(notificationModel, userModel, taskModel)
+	notifier.Register(bus)
+	var emailNotifier *notifications.EmailNotifier
+	if smtpMailer := mailer.NewSMTPMailerFromEnv(); smtpMailer != nil {
+		emailNotifier = notifications.NewEmailNotifier(smtpMailer, notificationModel, userModel, taskModel)
+		emailNotifier.Register(bus)
+	} else {
+		log.Println("SMTP_HOST is not set, email notifications are disabled")
+	}
+	recurrences := recurrence.NewService(recurrenceModel, taskModel)
+	recurrences.Register(bus)
+	dependencyModel := models.NewDependencyModel(db)
+	timelines := timeline.NewService(dependencyModel, taskModel)
+	timelines.Register(bus)
+	// registered last so that notifications about a write go out before the
+	// ones about the automations it triggers
+	automation.NewEngine(ruleModel, taskModel, projectModel, notificationModel).Register(bus)
+
+	jobRunModel := models.NewJobRunModel(db)
+	go setupScheduler(db, jobRunModel, notificationModel, ruleModel, notifier, emailNotifier, recurrences).Run(ctx)
+
+	userHandler := handlers.NewUserHandler(userModel)
+	taskHandler := handlers.NewTaskHandler(taskModel, bus)
+	projectHandler := handlers.NewProjectHandler(projectModel, bus)
+	changesHandler := handlers.NewChangesHandler(models.NewChangeModel(db))
+	commentHandler := handlers.NewCommentHandler(models.NewCommentModel(db), taskModel, bus)
+	notificationHandler := handlers.NewNotificationHandler(notificationModel)
+	jobHandler := handlers.NewJobHandler(jobRunModel)
+	ruleHandler := handlers.NewRuleHandler(ruleModel)
+	recurrenceHandler := handlers.NewRecurrenceHandler(recurrenceModel, taskModel)
+	templateHandler := handlers.NewTemplateHandler(models.NewTemplateModel(db), projectModel, bus)
+	cloneHandler := handlers.NewCloneHandler(models.NewCloneModel(db), projectModel, taskModel, bus)
+	statusHistoryModel := models.NewStatusHistoryModel(db)
+	sprintHandler := handlers.NewSprintHandler(models.NewSprintModel(db), projectModel, taskModel, statusHistoryModel)
+	milestoneHandler := handlers.NewMilestoneHandler(models.NewMilestoneModel(db), projectModel, statusHistoryModel)
+	epicHandler := handlers.NewEpicHandler(models.NewEpicModel(db), projectModel, statusHistoryModel)
+	worklogHandler := handlers.NewWorklogHandler(models.NewWorklogModel(db), taskModel, projectModel, userModel)
+	timesheetHandler := handlers.NewTimesheetHandler(models.NewTimesheetModel(db), userModel, projectModel)
+	boardHandler := handlers.NewBoardHandler(models.NewBoardModel(db), projectModel, taskModel, bus)
+	timelineHandler := handlers.NewTimelineHandler(dependencyModel, taskModel, projectModel, timelines)
+	baselineHandler := handlers.NewBaselineHandler(models.NewBaselineModel(db), projectModel, taskModel)
+	reportHandler := handlers.NewReportHandler(models.NewReportModel(db))
+	workloadHandler := handlers.NewWorkloadHandler(models.NewWorkloadModel(db), userModel)
+	flowHandler := handlers.NewFlowHandler(projectModel, taskModel, statusHistoryModel)
+	exportHandler := handlers.NewExportHandler(models.NewExportModel(db))
+	importHandler := handlers.NewImportHandler(importer.New(models.NewImportModel(db), models.NewExternalModel(db)))
+	calendarHandler := handlers.NewCalendarHandler(models.NewCalendarModel(db), userModel, projectModel, calendarUIDDomain())
+	graphQLHandler := handlers.NewGraphQLHandler(models.NewGraphModel(db))
 
 	router := mux.NewRouter()
 
-	SetupRouter(router, userHandler, taskHandler, projectHandler)
+	SetupRouter(router, userHandler, taskHandler, projectHandler, changesHandler, commentHandler, notificationHandler, jobHandler, ruleHandler, recurrenceHandler, templateHandler, cloneHandler, sprintHandler, milestoneHandler, epicHandler, worklogHandler, timesheetHandler, boardHandler, timelineHandler, baselineHandler, reportHandler, workloadHandler, flowHandler, exportHandler, importHandler, calendarHandler, graphQLHandler)
 
 	port := "8080"
 	server := &http.Server{
@@ -52,11 +123,12 @@ func main() {
 		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 
 		<-signals
+		cancel()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
 
-		if err := server.Shutdown(ctx); err != nil {
+		if err := server.Shutdown(shutdownCtx); err != nil {
 			log.Fatalf("Graceful shutdown failed: %v\n", err)
 		}
 	}()
@@ -70,3 +142,13 @@ func main() {
 	log.Println("Server gracefully stopped")
 
 }
+
+// calendarUIDDomain is the domain of the UIDs of calendar entries, from
+// CALENDAR_UID_DOMAIN or else the public host of the API. It has to stay the
+// same for a deployment and to differ between deployments.
+func calendarUIDDomain() string {
+	if domain := os.Getenv("CALENDAR_UID_DOMAIN"); domain != "" {
+		return domain
+	}
+	return docs.SwaggerInfo.Host
+}
@@ -8,7 +8,7 @@ import (
 	"net/http"
 )
 
-func SetupRouter(router *mux.Router, userHandler *handlers.UserHandler, taskHandler *handlers.TaskHandler, projectHandler *handlers.ProjectHandler) {
+func SetupRouter(router *mux.Router, userHandler *handlers.UserHandler, taskHandler *handlers.TaskHandler, projectHandler *handlers.ProjectHandler, changesHandler *handlers.ChangesHandler, commentHandler *handlers.CommentHandler, notificationHandler *handlers.NotificationHandler, jobHandler *handlers.JobHandler, ruleHandler *handlers.RuleHandler, recurrenceHandler *handlers.RecurrenceHandler, templateHandler *handlers.TemplateHandler, cloneHandler *handlers.CloneHandler, sprintHandler *handlers.SprintHandler, milestoneHandler *handlers.MilestoneHandler, epicHandler *handlers.EpicHandler, worklogHandler *handlers.WorklogHandler, timesheetHandler *handlers.TimesheetHandler, boardHandler *handlers.BoardHandler, timelineHandler *handlers.TimelineHandler, baselineHandler *handlers.BaselineHandler, reportHandler *handlers.ReportHandler, workloadHandler *handlers.WorkloadHandler, flowHandler *handlers.FlowHandler, exportHandler *handlers.ExportHandler, importHandler *handlers.ImportHandler, calendarHandler *handlers.CalendarHandler, graphQLHandler *handlers.GraphQLHandler) {
 	router.HandleFunc("/health-check", handlers.HealthCheck).Methods(http.MethodGet)
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
@@ -21,6 +21,20 @@ func SetupRouter(router *mux.Router, userHandler *handlers.UserHandler, taskHand
 	usersRouter.HandleFunc("/{id:[0-9]+}", userHandler.DeleteUserHandler).Methods(http.MethodDelete)
 	usersRouter.HandleFunc("/{id:[0-9]+}/tasks", userHandler.GetUserTasksHandler).Methods(http.MethodGet)
 	usersRouter.HandleFunc("/search", userHandler.SearchUserHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/export", exportHandler.ExportUsersHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/notification-preferences", notificationHandler.GetPreferencesHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/notification-preferences", notificationHandler.UpdatePreferencesHandler).Methods(http.MethodPut)
+	usersRouter.HandleFunc("/{id:[0-9]+}/worklogs", worklogHandler.GetUserWorklogsHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/time", worklogHandler.GetUserTimeHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/timesheets", timesheetHandler.GetUserTimesheetsHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/timesheets", timesheetHandler.OpenTimesheetHandler).Methods(http.MethodPost)
+	usersRouter.HandleFunc("/{id:[0-9]+}/capacity", workloadHandler.GetCapacityHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/capacity", workloadHandler.SetCapacityHandler).Methods(http.MethodPut)
+	usersRouter.HandleFunc("/{id:[0-9]+}/time-off", workloadHandler.GetUserTimeOffHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/time-off", workloadHandler.CreateTimeOffHandler).Methods(http.MethodPost)
+	usersRouter.HandleFunc("/{id:[0-9]+}/calendar-feed", calendarHandler.GetUserFeedHandler).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/calendar-feed", calendarHandler.CreateUserFeedHandler).Methods(http.MethodPost)
+	usersRouter.HandleFunc("/{id:[0-9]+}/calendar-feed", calendarHandler.DeleteUserFeedHandler).Methods(http.MethodDelete)
 
 	tasksRouter := router.PathPrefix("/tasks").Subrouter()
 
@@ -30,6 +44,24 @@ func SetupRouter(router *mux.Router, userHandler *handlers.UserHandler, taskHand
 	tasksRouter.HandleFunc("/{id:[0-9]+}", taskHandler.UpdateTaskHandler).Methods(http.MethodPut)
 	tasksRouter.HandleFunc("/{id:[0-9]+}", taskHandler.DeleteTaskHandler).Methods(http.MethodDelete)
 	tasksRouter.HandleFunc("/search", taskHandler.SearchTasksHandler).Methods(http.MethodGet)
+	tasksRouter.HandleFunc("/export", exportHandler.ExportTasksHandler).Methods(http.MethodGet)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/comments", commentHandler.GetTaskCommentsHandler).Methods(http.MethodGet)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/comments", commentHandler.CreateTaskCommentHandler).Methods(http.MethodPost)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/watchers", notificationHandler.GetTaskWatchersHandler).Methods(http.MethodGet)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/watchers", notificationHandler.AddTaskWatcherHandler).Methods(http.MethodPost)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/watchers/{user_id:[0-9]+}", notificationHandler.RemoveTaskWatcherHandler).Methods(http.MethodDelete)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/recurrence", recurrenceHandler.GetRecurrenceHandler).Methods(http.MethodGet)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/recurrence", recurrenceHandler.CreateRecurrenceHandler).Methods(http.MethodPost)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/recurrence", recurrenceHandler.UpdateRecurrenceHandler).Methods(http.MethodPut)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/recurrence", recurrenceHandler.DeleteRecurrenceHandler).Methods(http.MethodDelete)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/clone", cloneHandler.CloneTaskHandler).Methods(http.MethodPost)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/worklogs", worklogHandler.GetTaskWorklogsHandler).Methods(http.MethodGet)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/worklogs", worklogHandler.CreateWorklogHandler).Methods(http.MethodPost)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/time", worklogHandler.GetTaskTimeHandler).Methods(http.MethodGet)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/move", boardHandler.MoveTaskHandler).Methods(http.MethodPost)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/dependencies", timelineHandler.GetTaskDependenciesHandler).Methods(http.MethodGet)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/dependencies", timelineHandler.AddDependencyHandler).Methods(http.MethodPost)
+	tasksRouter.HandleFunc("/{id:[0-9]+}/dependencies/{predecessor_id:[0-9]+}", timelineHandler.RemoveDependencyHandler).Methods(http.MethodDelete)
 
 	projectsRouter := router.PathPrefix("/projects").Subrouter()
 
@@ -40,4 +72,120 @@ func SetupRouter(router *mux.Router, userHandler *handlers.UserHandler, taskHand
 	projectsRouter.HandleFunc("/{id:[0-9]+}", projectHandler.DeleteProjectHandler).Methods(http.MethodDelete)
 	projectsRouter.HandleFunc("/{id:[0-9]+}/tasks", projectHandler.GetProjectTasksHandler).Methods(http.MethodGet)
 	projectsRouter.HandleFunc("/search", projectHandler.SearchProjectsHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/export", exportHandler.ExportProjectsHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/template", templateHandler.CreateTemplateFromProjectHandler).Methods(http.MethodPost)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/clone", cloneHandler.CloneProjectHandler).Methods(http.MethodPost)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/sprints", sprintHandler.GetProjectSprintsHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/sprints", sprintHandler.CreateSprintHandler).Methods(http.MethodPost)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/milestones", milestoneHandler.GetProjectMilestonesHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/milestones", milestoneHandler.CreateMilestoneHandler).Methods(http.MethodPost)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/epics", epicHandler.GetProjectEpicsHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/epics", epicHandler.CreateEpicHandler).Methods(http.MethodPost)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/time", worklogHandler.GetProjectTimeHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/timesheets/export", timesheetHandler.ExportApprovedHoursHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/forecast", flowHandler.GetForecastHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/board", boardHandler.GetBoardHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/board/limits", boardHandler.SetWIPLimitsHandler).Methods(http.MethodPut)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/timeline", timelineHandler.GetTimelineHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/timeline/schedule", timelineHandler.ScheduleProjectHandler).Methods(http.MethodPost)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/baselines", baselineHandler.GetProjectBaselinesHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/baselines", baselineHandler.CreateBaselineHandler).Methods(http.MethodPost)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/calendar-feed", calendarHandler.GetProjectFeedHandler).Methods(http.MethodGet)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/calendar-feed", calendarHandler.CreateProjectFeedHandler).Methods(http.MethodPost)
+	projectsRouter.HandleFunc("/{id:[0-9]+}/calendar-feed", calendarHandler.DeleteProjectFeedHandler).Methods(http.MethodDelete)
+
+	sprintsRouter := router.PathPrefix("/sprints").Subrouter()
+
+	sprintsRouter.HandleFunc("/{id:[0-9]+}", sprintHandler.GetSprintHandler).Methods(http.MethodGet)
+	sprintsRouter.HandleFunc("/{id:[0-9]+}", sprintHandler.UpdateSprintHandler).Methods(http.MethodPut)
+	sprintsRouter.HandleFunc("/{id:[0-9]+}", sprintHandler.DeleteSprintHandler).Methods(http.MethodDelete)
+	sprintsRouter.HandleFunc("/{id:[0-9]+}/tasks", sprintHandler.GetSprintTasksHandler).Methods(http.MethodGet)
+	sprintsRouter.HandleFunc("/{id:[0-9]+}/tasks", sprintHandler.AddSprintTasksHandler).Methods(http.MethodPost)
+	sprintsRouter.HandleFunc("/{id:[0-9]+}/tasks/{task_id:[0-9]+}", sprintHandler.RemoveSprintTaskHandler).Methods(http.MethodDelete)
+	sprintsRouter.HandleFunc("/{id:[0-9]+}/start", sprintHandler.StartSprintHandler).Methods(http.MethodPost)
+	sprintsRouter.HandleFunc("/{id:[0-9]+}/complete", sprintHandler.CompleteSprintHandler).Methods(http.MethodPost)
+	sprintsRouter.HandleFunc("/{id:[0-9]+}/burndown", sprintHandler.GetBurndownHandler).Methods(http.MethodGet)
+
+	milestonesRouter := router.PathPrefix("/milestones").Subrouter()
+
+	milestonesRouter.HandleFunc("/{id:[0-9]+}", milestoneHandler.GetMilestoneHandler).Methods(http.MethodGet)
+	milestonesRouter.HandleFunc("/{id:[0-9]+}", milestoneHandler.UpdateMilestoneHandler).Methods(http.MethodPut)
+	milestonesRouter.HandleFunc("/{id:[0-9]+}", milestoneHandler.DeleteMilestoneHandler).Methods(http.MethodDelete)
+	milestonesRouter.HandleFunc("/{id:[0-9]+}/tasks", milestoneHandler.GetMilestoneTasksHandler).Methods(http.MethodGet)
+	milestonesRouter.HandleFunc("/{id:[0-9]+}/progress", milestoneHandler.GetMilestoneProgressHandler).Methods(http.MethodGet)
+
+	epicsRouter := router.PathPrefix("/epics").Subrouter()
+
+	epicsRouter.HandleFunc("/{id:[0-9]+}", epicHandler.GetEpicHandler).Methods(http.MethodGet)
+	epicsRouter.HandleFunc("/{id:[0-9]+}", epicHandler.UpdateEpicHandler).Methods(http.MethodPut)
+	epicsRouter.HandleFunc("/{id:[0-9]+}", epicHandler.DeleteEpicHandler).Methods(http.MethodDelete)
+	epicsRouter.HandleFunc("/{id:[0-9]+}/tasks", epicHandler.GetEpicTasksHandler).Methods(http.MethodGet)
+	epicsRouter.HandleFunc("/{id:[0-9]+}/progress", epicHandler.GetEpicProgressHandler).Methods(http.MethodGet)
+
+	worklogsRouter := router.PathPrefix("/worklogs").Subrouter()
+
+	worklogsRouter.HandleFunc("/{id:[0-9]+}", worklogHandler.UpdateWorklogHandler).Methods(http.MethodPut)
+	worklogsRouter.HandleFunc("/{id:[0-9]+}", worklogHandler.DeleteWorklogHandler).Methods(http.MethodDelete)
+
+	reportsRouter := router.PathPrefix("/reports").Subrouter()
+
+	reportsRouter.HandleFunc("/projects", reportHandler.GetProjectReportsHandler).Methods(http.MethodGet)
+	reportsRouter.HandleFunc("/workload", workloadHandler.GetWorkloadHandler).Methods(http.MethodGet)
+	reportsRouter.HandleFunc("/flow", flowHandler.GetFlowHandler).Methods(http.MethodGet)
+
+	timeOffRouter := router.PathPrefix("/time-off").Subrouter()
+
+	timeOffRouter.HandleFunc("/{id:[0-9]+}", workloadHandler.DeleteTimeOffHandler).Methods(http.MethodDelete)
+
+	importRouter := router.PathPrefix("/import").Subrouter()
+
+	importRouter.HandleFunc("/{source:jira|trello|github}", importHandler.SyncHandler).Methods(http.MethodPost)
+	importRouter.HandleFunc("/{kind}", importHandler.ImportHandler).Methods(http.MethodPost)
+
+	router.HandleFunc("/calendar/{token:[0-9a-f]+}.ics", calendarHandler.GetCalendarHandler).Methods(http.MethodGet)
+
+	router.HandleFunc("/graphql", graphQLHandler.QueryHandler).Methods(http.MethodPost)
+	router.HandleFunc("/graphql", graphQLHandler.GetQueryHandler).Methods(http.MethodGet)
+	router.HandleFunc("/graphql/schema", graphQLHandler.GetSchemaHandler).Methods(http.MethodGet)
+
+	baselinesRouter := router.PathPrefix("/baselines").Subrouter()
+
+	baselinesRouter.HandleFunc("/{id:[0-9]+}", baselineHandler.GetBaselineHandler).Methods(http.MethodGet)
+	baselinesRouter.HandleFunc("/{id:[0-9]+}", baselineHandler.DeleteBaselineHandler).Methods(http.MethodDelete)
+	baselinesRouter.HandleFunc("/{id:[0-9]+}/variance", baselineHandler.GetVarianceHandler).Methods(http.MethodGet)
+
+	timesheetsRouter := router.PathPrefix("/timesheets").Subrouter()
+
+	timesheetsRouter.HandleFunc("/{id:[0-9]+}", timesheetHandler.GetTimesheetHandler).Methods(http.MethodGet)
+	timesheetsRouter.HandleFunc("/{id:[0-9]+}/submit", timesheetHandler.SubmitTimesheetHandler).Methods(http.MethodPost)
+	timesheetsRouter.HandleFunc("/{id:[0-9]+}/approve", timesheetHandler.ApproveTimesheetHandler).Methods(http.MethodPost)
+	timesheetsRouter.HandleFunc("/{id:[0-9]+}/reject", timesheetHandler.RejectTimesheetHandler).Methods(http.MethodPost)
+
+	templatesRouter := router.PathPrefix("/templates").Subrouter()
+
+	templatesRouter.HandleFunc("", templateHandler.GetAllTemplatesHandler).Methods(http.MethodGet)
+	templatesRouter.HandleFunc("", templateHandler.CreateTemplateHandler).Methods(http.MethodPost)
+	templatesRouter.HandleFunc("/{id:[0-9]+}", templateHandler.GetTemplateHandler).Methods(http.MethodGet)
+	templatesRouter.HandleFunc("/{id:[0-9]+}", templateHandler.DeleteTemplateHandler).Methods(http.MethodDelete)
+	templatesRouter.HandleFunc("/{id:[0-9]+}/instantiate", templateHandler.InstantiateTemplateHandler).Methods(http.MethodPost)
+
+	router.HandleFunc("/changes", changesHandler.GetChangesHandler).Methods(http.MethodGet)
+
+	notificationsRouter := router.PathPrefix("/notifications").Subrouter()
+
+	notificationsRouter.HandleFunc("", notificationHandler.GetNotificationsHandler).Methods(http.MethodGet)
+	notificationsRouter.HandleFunc("/unread-count", notificationHandler.GetUnreadCountHandler).Methods(http.MethodGet)
+	notificationsRouter.HandleFunc("/read-all", notificationHandler.MarkAllReadHandler).Methods(http.MethodPut)
+	notificationsRouter.HandleFunc("/{id:[0-9]+}/read", notificationHandler.MarkReadHandler).Methods(http.MethodPut)
+
+	router.HandleFunc("/jobs/runs", jobHandler.GetJobRunsHandler).Methods(http.MethodGet)
+
+	rulesRouter := router.PathPrefix("/automation/rules").Subrouter()
+
+	rulesRouter.HandleFunc("", ruleHandler.GetAllRulesHandler).Methods(http.MethodGet)
+	rulesRouter.HandleFunc("", ruleHandler.CreateRuleHandler).Methods(http.MethodPost)
+	rulesRouter.HandleFunc("/{id:[0-9]+}", ruleHandler.GetRuleHandler).Methods(http.MethodGet)
+	rulesRouter.HandleFunc("/{id:[0-9]+}", ruleHandler.UpdateRuleHandler).Methods(http.MethodPut)
+	rulesRouter.HandleFunc("/{id:[0-9]+}", ruleHandler.DeleteRuleHandler).Methods(http.MethodDelete)
+	rulesRouter.HandleFunc("/{id:[0-9]+}/runs", ruleHandler.GetRuleRunsHandler).Methods(http.MethodGet)
 }
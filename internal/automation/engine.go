@@ -0,0 +1,275 @@
+package automation
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxDepth is how many rules may fire one after another in a chain
+// started by a single client write.
+const DefaultMaxDepth = 5
+
+// Engine evaluates automation rules on task events and applies their actions.
+// Task changes made by a rule are published again so that other subscribers
+// (notifications, email, further rules) see them like any other update.
+type Engine struct {
+	Rules         models.RuleModel
+	Tasks         models.TaskModel
+	Projects      models.ProjectModel
+	Notifications models.NotificationModel
+	Events        *events.Bus
+	MaxDepth      int
+	// Now is the clock used for completion dates, replaceable in tests.
+	Now func() time.Time
+}
+
+func NewEngine(ruleModel models.RuleModel, taskModel models.TaskModel, projectModel models.ProjectModel, notificationModel models.NotificationModel) *Engine {
+	return &Engine{
+		Rules:         ruleModel,
+		Tasks:         taskModel,
+		Projects:      projectModel,
+		Notifications: notificationModel,
+		MaxDepth:      DefaultMaxDepth,
+		Now:           time.Now,
+	}
+}
+
+func (e *Engine) Register(bus *events.Bus) {
+	e.Events = bus
+	bus.Subscribe(e.Handle)
+}
+
+func (e *Engine) Handle(event events.Event) {
+	switch event.Type {
+	case events.TaskCreated:
+		e.evaluate(models.TriggerTaskCreated, event)
+		if event.Task.Status == models.Done {
+			e.checkProjectDone(event)
+		}
+	case events.TaskUpdated:
+		task, previous := event.Task, event.PreviousTask
+		e.evaluate(models.TriggerTaskUpdated, event)
+		if task.Status != previous.Status {
+			e.evaluate(models.TriggerTaskStatusChanged, event)
+		}
+		if task.Priority != previous.Priority {
+			e.evaluate(models.TriggerTaskPriorityChanged, event)
+		}
+		if task.ResponsibleUserID != previous.ResponsibleUserID {
+			e.evaluate(models.TriggerTaskAssigneeChanged, event)
+		}
+		if task.Status == models.Done && previous.Status != models.Done {
+			e.checkProjectDone(event)
+		}
+	}
+}
+
+// checkProjectDone fires project_tasks_done rules once the last open task of
+// the event's project is done.
+func (e *Engine) checkProjectDone(event events.Event) {
+	if event.Task.ProjectID == 0 {
+		return
+	}
+	tasks, err := e.Projects.GetProjectTasks(event.Task.ProjectID)
+	if err != nil {
+		log.Printf("automation: could not load tasks of project %d: %v\n", event.Task.ProjectID, err)
+		return
+	}
+	for _, task := range tasks {
+		if task.Status != models.Done {
+			return
+		}
+	}
+	e.evaluate(models.TriggerProjectTasksDone, event)
+}
+
+func (e *Engine) evaluate(trigger models.RuleTrigger, event events.Event) {
+	task := event.Task
+	rules, err := e.Rules.GetEnabledRules(trigger, task.ProjectID)
+	if err != nil {
+		log.Printf("automation: could not load %s rules: %v\n", trigger, err)
+		return
+	}
+	for _, rule := range rules {
+		if !Matches(rule.Conditions, task, event.PreviousTask) {
+			continue
+		}
+		if containsRule(event.Rules, rule.ID) {
+			e.logRun(rule, task, models.RuleRunSkipped, fmt.Sprintf("loop detected: rule already fired in chain %v", event.Rules))
+			continue
+		}
+		if len(event.Rules) >= e.MaxDepth {
+			e.logRun(rule, task, models.RuleRunSkipped, fmt.Sprintf("chain %v reached the maximum depth of %d", event.Rules, e.MaxDepth))
+			continue
+		}
+		if err := e.apply(rule, event); err != nil {
+			e.logRun(rule, task, models.RuleRunFailed, err.Error())
+			continue
+		}
+		e.logRun(rule, task, models.RuleRunSucceeded, "")
+	}
+}
+
+// apply runs the actions of rule against the current state of the event's
+// task. Task field changes are saved in one update.
+func (e *Engine) apply(rule *models.Rule, event events.Event) error {
+	task, err := e.Tasks.GetTaskById(event.Task.ID)
+	if err != nil {
+		return fmt.Errorf("could not load task %d: %w", event.Task.ID, err)
+	}
+	previous := *task
+	chain := append(append(make([]int, 0, len(event.Rules)+1), event.Rules...), rule.ID)
+	today := e.Now().Format(models.DateLayout)
+	changed := false
+	for _, action := range rule.Actions {
+		switch action.Type {
+		case models.ActionSetStatus:
+			task.Status = models.StatusEnum(action.Value)
+			changed = true
+		case models.ActionSetPriority:
+			task.Priority = models.PriorityEnum(action.Value)
+			changed = true
+		case models.ActionAssignUser:
+			task.ResponsibleUserID = action.UserID
+			changed = true
+		case models.ActionSetCompletionDate:
+			task.CompletionDate = today
+			changed = true
+		case models.ActionNotifyManager:
+			project, err := e.Projects.GetProjectByID(task.ProjectID)
+			if err != nil {
+				return fmt.Errorf("could not load project %d: %w", task.ProjectID, err)
+			}
+			if project.ManagerID != 0 {
+				if err := e.notify(project.ManagerID, rule, action, task); err != nil {
+					return err
+				}
+			}
+		case models.ActionNotifyAssignee:
+			if task.ResponsibleUserID != 0 {
+				if err := e.notify(task.ResponsibleUserID, rule, action, task); err != nil {
+					return err
+				}
+			}
+		case models.ActionNotifyUser:
+			if err := e.notify(action.UserID, rule, action, task); err != nil {
+				return err
+			}
+		case models.ActionCloseProject:
+			if err := e.closeProject(task.ProjectID, today, chain); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown action %q", action.Type)
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := e.Tasks.UpdateTask(task); err != nil {
+		return fmt.Errorf("could not update task %d: %w", task.ID, err)
+	}
+	e.Events.Publish(events.Event{Type: events.TaskUpdated, Task: task, PreviousTask: &previous, Rules: chain})
+	return nil
+}
+
+func (e *Engine) closeProject(projectID int, today string, chain []int) error {
+	if projectID == 0 {
+		return fmt.Errorf("task has no project to close")
+	}
+	project, err := e.Projects.GetProjectByID(projectID)
+	if err != nil {
+		return fmt.Errorf("could not load project %d: %w", projectID, err)
+	}
+	if project.CompletionDate != "" {
+		return nil
+	}
+	previous := *project
+	if err := e.Projects.CompleteProject(projectID, today); err != nil {
+		return fmt.Errorf("could not close project %d: %w", projectID, err)
+	}
+	project.CompletionDate = today
+	e.Events.Publish(events.Event{Type: events.ProjectUpdated, Project: project, PreviousProject: &previous, Rules: chain})
+	return nil
+}
+
+func (e *Engine) notify(userID int, rule *models.Rule, action models.RuleAction, task *models.Task) error {
+	message := action.Message
+	if message == "" {
+		message = fmt.Sprintf("Automation %q ran on task %q", rule.Name, task.Title)
+	}
+	err := e.Notifications.CreateNotification(userID, models.NotificationAutomation, message, task.ID, task.ProjectID)
+	if err != nil {
+		return fmt.Errorf("could not notify user %d: %w", userID, err)
+	}
+	return nil
+}
+
+func (e *Engine) logRun(rule *models.Rule, task *models.Task, status, message string) {
+	if err := e.Rules.LogRun(rule.ID, task.ID, task.ProjectID, status, message); err != nil {
+		log.Printf("automation: could not log run of rule %d: %v\n", rule.ID, err)
+	}
+}
+
+// Matches reports whether task satisfies every condition. previous is the
+// task before the update and nil for newly created tasks.
+func Matches(conditions []models.RuleCondition, task, previous *models.Task) bool {
+	for _, condition := range conditions {
+		value := fieldValue(condition.Field, task, previous)
+		switch condition.Operator {
+		case "eq":
+			if value != condition.Value {
+				return false
+			}
+		case "neq":
+			if value == condition.Value {
+				return false
+			}
+		case "contains":
+			if !strings.Contains(strings.ToLower(value), strings.ToLower(condition.Value)) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func fieldValue(field string, task, previous *models.Task) string {
+	switch field {
+	case "status":
+		return string(task.Status)
+	case "priority":
+		return string(task.Priority)
+	case "previous_status":
+		if previous != nil {
+			return string(previous.Status)
+		}
+	case "previous_priority":
+		if previous != nil {
+			return string(previous.Priority)
+		}
+	case "responsible_user_id":
+		return strconv.Itoa(task.ResponsibleUserID)
+	case "project_id":
+		return strconv.Itoa(task.ProjectID)
+	case "title":
+		return task.Title
+	}
+	return ""
+}
+
+func containsRule(chain []int, ruleID int) bool {
+	for _, id := range chain {
+		if id == ruleID {
+			return true
+		}
+	}
+	return false
+}
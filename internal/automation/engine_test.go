@@ -0,0 +1,189 @@
+package automation
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type run struct {
+	ruleID int
+	status string
+}
+
+type testEnv struct {
+	engine        *Engine
+	tasks         map[int]*models.Task
+	runs          []run
+	notified      []int
+	closedProject int
+}
+
+func newTestEnv(rules []*models.Rule, tasks ...*models.Task) *testEnv {
+	env := &testEnv{tasks: make(map[int]*models.Task)}
+	for _, task := range tasks {
+		env.tasks[task.ID] = task
+	}
+	ruleModel := &models.MockRuleModel{
+		MockGetEnabledRules: func(trigger models.RuleTrigger, projectID int) ([]*models.Rule, error) {
+			matching := make([]*models.Rule, 0)
+			for _, rule := range rules {
+				if rule.Trigger == trigger {
+					matching = append(matching, rule)
+				}
+			}
+			return matching, nil
+		},
+		MockLogRun: func(ruleID, taskID, projectID int, status, message string) error {
+			env.runs = append(env.runs, run{ruleID, status})
+			return nil
+		},
+	}
+	taskModel := &models.MockTaskModel{
+		MockGetTaskById: func(id int) (*models.Task, error) {
+			task := *env.tasks[id]
+			return &task, nil
+		},
+		MockUpdateTask: func(task *models.Task) error {
+			stored := *task
+			env.tasks[task.ID] = &stored
+			return nil
+		},
+	}
+	projectModel := &models.MockProjectModel{
+		MockGetProjectByID: func(id int) (*models.Project, error) {
+			return &models.Project{ID: id, Title: "Launch", ManagerID: 7}, nil
+		},
+		MockGetProjectTasks: func(id int) ([]models.Task, error) {
+			projectTasks := make([]models.Task, 0)
+			for _, task := range env.tasks {
+				if task.ProjectID == id {
+					projectTasks = append(projectTasks, *task)
+				}
+			}
+			return projectTasks, nil
+		},
+		MockCompleteProject: func(id int, completionDate string) error {
+			env.closedProject = id
+			return nil
+		},
+	}
+	notificationModel := &models.MockNotificationModel{
+		MockCreateNotification: func(userID int, notificationType models.NotificationType, message string, taskID, projectID int) error {
+			env.notified = append(env.notified, userID)
+			return nil
+		},
+	}
+	env.engine = NewEngine(ruleModel, taskModel, projectModel, notificationModel)
+	env.engine.Now = func() time.Time { return time.Date(2021, 9, 15, 10, 0, 0, 0, time.UTC) }
+	env.engine.Register(events.NewBus())
+	return env
+}
+
+// update simulates a client changing a task through the handlers.
+func (env *testEnv) update(id int, change func(task *models.Task)) {
+	previous := *env.tasks[id]
+	task := previous
+	change(&task)
+	env.tasks[id] = &task
+	current := task
+	env.engine.Events.Publish(events.Event{Type: events.TaskUpdated, Task: &current, PreviousTask: &previous})
+}
+
+func TestEngineCompletesTaskAndNotifiesManager(t *testing.T) {
+	env := newTestEnv([]*models.Rule{{
+		ID:         1,
+		Trigger:    models.TriggerTaskStatusChanged,
+		Conditions: []models.RuleCondition{{Field: "status", Operator: "eq", Value: "done"}},
+		Actions:    []models.RuleAction{{Type: models.ActionSetCompletionDate}, {Type: models.ActionNotifyManager}},
+	}}, &models.Task{ID: 1, Title: "Ship", Status: models.InProgress, ProjectID: 3})
+
+	env.update(1, func(task *models.Task) { task.Status = models.Done })
+
+	if got := env.tasks[1].CompletionDate; got != "2021-09-15" {
+		t.Errorf("unexpected completion date: %q", got)
+	}
+	if !reflect.DeepEqual(env.notified, []int{7}) {
+		t.Errorf("expected the manager to be notified, got %v", env.notified)
+	}
+	if !reflect.DeepEqual(env.runs, []run{{1, models.RuleRunSucceeded}}) {
+		t.Errorf("unexpected runs: %v", env.runs)
+	}
+}
+
+func TestEngineConditionsNotMet(t *testing.T) {
+	env := newTestEnv([]*models.Rule{{
+		ID:         1,
+		Trigger:    models.TriggerTaskPriorityChanged,
+		Conditions: []models.RuleCondition{{Field: "priority", Operator: "eq", Value: "high"}},
+		Actions:    []models.RuleAction{{Type: models.ActionAssignUser, UserID: 5}},
+	}}, &models.Task{ID: 1, Priority: models.Low})
+
+	env.update(1, func(task *models.Task) { task.Priority = models.Medium })
+
+	if env.tasks[1].ResponsibleUserID != 0 || len(env.runs) != 0 {
+		t.Errorf("rule should not have fired: task %+v, runs %v", env.tasks[1], env.runs)
+	}
+}
+
+// pingPongRules move a task back and forth between new and in_progress forever
+// unless the engine stops them.
+func pingPongRules() []*models.Rule {
+	return []*models.Rule{{
+		ID:         1,
+		Trigger:    models.TriggerTaskStatusChanged,
+		Conditions: []models.RuleCondition{{Field: "status", Operator: "eq", Value: "in_progress"}},
+		Actions:    []models.RuleAction{{Type: models.ActionSetStatus, Value: "new"}},
+	}, {
+		ID:         2,
+		Trigger:    models.TriggerTaskStatusChanged,
+		Conditions: []models.RuleCondition{{Field: "status", Operator: "eq", Value: "new"}},
+		Actions:    []models.RuleAction{{Type: models.ActionSetStatus, Value: "in_progress"}},
+	}}
+}
+
+func TestEngineLoopProtection(t *testing.T) {
+	env := newTestEnv(pingPongRules(), &models.Task{ID: 1, Status: models.New})
+
+	env.update(1, func(task *models.Task) { task.Status = models.InProgress })
+
+	expected := []run{{1, models.RuleRunSkipped}, {2, models.RuleRunSucceeded}, {1, models.RuleRunSucceeded}}
+	if !reflect.DeepEqual(env.runs, expected) {
+		t.Errorf("unexpected runs: got %v want %v", env.runs, expected)
+	}
+	if env.tasks[1].Status != models.InProgress {
+		t.Errorf("unexpected final status %s", env.tasks[1].Status)
+	}
+}
+
+func TestEngineMaxDepth(t *testing.T) {
+	env := newTestEnv(pingPongRules(), &models.Task{ID: 1, Status: models.New})
+	env.engine.MaxDepth = 1
+
+	env.update(1, func(task *models.Task) { task.Status = models.InProgress })
+
+	expected := []run{{2, models.RuleRunSkipped}, {1, models.RuleRunSucceeded}}
+	if !reflect.DeepEqual(env.runs, expected) {
+		t.Errorf("unexpected runs: got %v want %v", env.runs, expected)
+	}
+}
+
+func TestEngineClosesProjectWhenAllTasksDone(t *testing.T) {
+	env := newTestEnv([]*models.Rule{{
+		ID:      1,
+		Trigger: models.TriggerProjectTasksDone,
+		Actions: []models.RuleAction{{Type: models.ActionCloseProject}},
+	}}, &models.Task{ID: 1, Status: models.InProgress, ProjectID: 3}, &models.Task{ID: 2, Status: models.InProgress, ProjectID: 3})
+
+	env.update(1, func(task *models.Task) { task.Status = models.Done })
+	if env.closedProject != 0 {
+		t.Fatalf("project closed while task 2 is still open")
+	}
+
+	env.update(2, func(task *models.Task) { task.Status = models.Done })
+	if env.closedProject != 3 {
+		t.Errorf("expected project 3 to be closed, got %d", env.closedProject)
+	}
+}
@@ -0,0 +1,424 @@
+// Package backup writes the whole dataset to a portable archive and
+// restores it into an empty database, independently of pg_dump.
+//
+// An archive is a zip file with a JSON-lines file per table, one row as a
+// JSON object per line, and a manifest.json listing the files with their
+// row counts and SHA-256 checksums. Restoring gives the rows new ids and
+// rewrites the references between them.
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// Format names the archives of this package.
+	Format = "project-management-backup"
+	// Version is the version of the layout of the archives, changed when
+	// older versions cannot read them.
+	Version = 1
+
+	manifestFile = "manifest.json"
+)
+
+type Manifest struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	// SchemaVersion is the latest migration of the database backed up; an
+	// archive restores into a database with that migration or later ones.
+	SchemaVersion uint         `json:"schema_version"`
+	CreatedAt     time.Time    `json:"created_at"`
+	Tables        []*TableFile `json:"tables"`
+}
+
+type TableFile struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Rows   int    `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+// errSkipRow is returned by remap functions for rows that are not restored.
+var errSkipRow = errors.New("skip row")
+
+// archiveWriter writes the files of an archive and the manifest listing
+// them.
+type archiveWriter struct {
+	zip      *zip.Writer
+	manifest *Manifest
+}
+
+func newArchiveWriter(out io.Writer, schemaVersion uint, now time.Time) *archiveWriter {
+	return &archiveWriter{
+		zip:      zip.NewWriter(out),
+		manifest: &Manifest{Format: Format, Version: Version, SchemaVersion: schemaVersion, CreatedAt: now.UTC(), Tables: []*TableFile{}},
+	}
+}
+
+// table writes the file of a table, whose rows are the lines that each
+// passes to write.
+func (w *archiveWriter) table(name string, each func(write func(line []byte) error) error) error {
+	file := &TableFile{Name: name, File: "tables/" + name + ".jsonl"}
+	out, err := w.zip.Create(file.File)
+	if err != nil {
+		return err
+	}
+	checksum := sha256.New()
+	buffered := bufio.NewWriter(io.MultiWriter(out, checksum))
+	err = each(func(line []byte) error {
+		if bytes.ContainsAny(line, "\r\n") {
+			return fmt.Errorf("%s: a row spans lines", name)
+		}
+		file.Rows++
+		if _, err := buffered.Write(line); err != nil {
+			return err
+		}
+		return buffered.WriteByte('\n')
+	})
+	if err != nil {
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	file.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	w.manifest.Tables = append(w.manifest.Tables, file)
+	return nil
+}
+
+func (w *archiveWriter) close() (*Manifest, error) {
+	out, err := w.zip.Create(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(w.manifest); err != nil {
+		return nil, err
+	}
+	return w.manifest, w.zip.Close()
+}
+
+// Create writes all tables to out as an archive, from one snapshot of the
+// database. The feed of changes and the runs of background jobs describe
+// the running service rather than the data and are left out.
+func Create(db *sql.DB, out io.Writer, now time.Time) (*Manifest, error) {
+	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	schemaVersion, err := getSchemaVersion(tx)
+	if err != nil {
+		return nil, err
+	}
+	writer := newArchiveWriter(out, schemaVersion, now)
+	for _, t := range tables {
+		err := writer.table(t.name, func(write func(line []byte) error) error {
+			rows, err := tx.Query(fmt.Sprintf("SELECT row_to_json(t)::text FROM %s t ORDER BY %s", pq.QuoteIdentifier(t.name), t.orderBy))
+			if err != nil {
+				return err
+			}
+			defer func(rows *sql.Rows) {
+				err := rows.Close()
+				if err != nil {
+					return
+				}
+			}(rows)
+			for rows.Next() {
+				var line []byte
+				if err := rows.Scan(&line); err != nil {
+					return err
+				}
+				if err := write(line); err != nil {
+					return err
+				}
+			}
+			return rows.Err()
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.name, err)
+		}
+	}
+	return writer.close()
+}
+
+// Archive is an archive opened for reading.
+type Archive struct {
+	Manifest *Manifest
+	files    map[string]*zip.File
+}
+
+// OpenArchive reads the manifest of an archive and checks that this version
+// can restore it.
+func OpenArchive(in io.ReaderAt, size int64) (*Archive, error) {
+	reader, err := zip.NewReader(in, size)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	archive := &Archive{files: make(map[string]*zip.File)}
+	for _, file := range reader.File {
+		archive.files[file.Name] = file
+	}
+	file, ok := archive.files[manifestFile]
+	if !ok {
+		return nil, fmt.Errorf("not a backup archive: no %s", manifestFile)
+	}
+	content, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	if err := json.NewDecoder(content).Decode(&archive.Manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", manifestFile, err)
+	}
+	manifest := archive.Manifest
+	if manifest.Format != Format {
+		return nil, fmt.Errorf("not a backup archive: format %q", manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > Version {
+		return nil, fmt.Errorf("archives of version %d cannot be read, the latest known version is %d", manifest.Version, Version)
+	}
+	for _, table := range manifest.Tables {
+		if findTable(table.Name) == nil {
+			return nil, fmt.Errorf("the archive has the unknown table %s", table.Name)
+		}
+		if archive.files[table.File] == nil {
+			return nil, fmt.Errorf("the archive lacks %s", table.File)
+		}
+	}
+	return archive, nil
+}
+
+// Verify checks the row counts and the checksums of all files.
+func (a *Archive) Verify() error {
+	for _, table := range a.Manifest.Tables {
+		rows := 0
+		checksum := sha256.New()
+		err := a.each(table, checksum, func(line []byte) error {
+			rows++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if sum := hex.EncodeToString(checksum.Sum(nil)); sum != table.SHA256 {
+			return fmt.Errorf("%s is corrupt: its checksum is %s, the manifest says %s", table.File, sum, table.SHA256)
+		}
+		if rows != table.Rows {
+			return fmt.Errorf("%s is corrupt: it has %d rows, the manifest says %d", table.File, rows, table.Rows)
+		}
+	}
+	return nil
+}
+
+// each calls fn with each line of the file of table, writing the content of
+// the file to checksum as well when it is set.
+func (a *Archive) each(table *TableFile, checksum hash.Hash, fn func(line []byte) error) error {
+	content, err := a.files[table.File].Open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	var in io.Reader = content
+	if checksum != nil {
+		in = io.TeeReader(content, checksum)
+	}
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %w", table.File, err)
+	}
+	return nil
+}
+
+// Restore verifies archive and writes its rows to db in one transaction.
+// The tables of the archive must be empty; the rows get new ids.
+func Restore(db *sql.DB, archive *Archive) error {
+	if err := archive.Verify(); err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	schemaVersion, err := getSchemaVersion(tx)
+	if err != nil {
+		return err
+	}
+	if archive.Manifest.SchemaVersion > schemaVersion {
+		return fmt.Errorf("the archive is of schema version %d, the database of %d: update the service first", archive.Manifest.SchemaVersion, schemaVersion)
+	}
+	files := make(map[string]*TableFile, len(archive.Manifest.Tables))
+	for _, file := range archive.Manifest.Tables {
+		files[file.Name] = file
+	}
+	for _, t := range tables {
+		var exists bool
+		if err := tx.QueryRow(fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", pq.QuoteIdentifier(t.name))).Scan(&exists); err != nil {
+			return err
+		}
+		if exists && files[t.name] != nil {
+			return fmt.Errorf("the database is not empty: %s has rows", t.name)
+		}
+	}
+
+	remapper := newRemapper()
+	for _, t := range tables {
+		file := files[t.name]
+		if file == nil {
+			// the archive is older than the table
+			continue
+		}
+		if t.name == "task_status_history" {
+			// restoring tasks has recorded their current status as history
+			if _, err := tx.Exec("DELETE FROM task_status_history"); err != nil {
+				return err
+			}
+		}
+		if err := restoreTable(tx, archive, file, t, remapper); err != nil {
+			return fmt.Errorf("%s: %w", t.name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func restoreTable(tx *sql.Tx, archive *Archive, file *TableFile, t *table, remapper *remapper) error {
+	columns, err := getColumns(tx, t.name)
+	if err != nil {
+		return err
+	}
+	type pending struct {
+		id       int64
+		deferred map[string]any
+	}
+	var later []pending
+	line := 0
+	err = archive.each(file, nil, func(data []byte) error {
+		line++
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var row map[string]any
+		if err := decoder.Decode(&row); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		var oldID int64
+		if t.serial {
+			oldID, err = toInt(row["id"])
+			if err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			delete(row, "id")
+		}
+		deferred, err := remapper.row(t, row)
+		if errors.Is(err, errSkipRow) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		names := make([]string, 0, len(row))
+		for name := range row {
+			if !columns[name] {
+				return fmt.Errorf("line %d: the database has no column %s, update the service first", line, name)
+			}
+			names = append(names, pq.QuoteIdentifier(name))
+		}
+		sort.Strings(names)
+		values, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		list := strings.Join(names, ", ")
+		query := fmt.Sprintf("INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM json_populate_record(null::%[1]s, $1::json)", pq.QuoteIdentifier(t.name), list)
+		if !t.serial {
+			if _, err := tx.Exec(query, values); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			return nil
+		}
+		var newID int64
+		if err := tx.QueryRow(query+" RETURNING id", values).Scan(&newID); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		remapper.add(t.name, oldID, newID)
+		if len(deferred) > 0 {
+			later = append(later, pending{id: newID, deferred: deferred})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, row := range later {
+		for column, oldRef := range row.deferred {
+			ref, err := remapper.id(t.refs[column], oldRef)
+			if err != nil {
+				return fmt.Errorf("%s: %w", column, err)
+			}
+			if _, err := tx.Exec(fmt.Sprintf("UPDATE %s SET %s = $1 WHERE id = $2", pq.QuoteIdentifier(t.name), pq.QuoteIdentifier(column)), ref, row.id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func getColumns(tx *sql.Tx, table string) (map[string]bool, error) {
+	rows, err := tx.Query("SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1", table)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns[column] = true
+	}
+	return columns, rows.Err()
+}
+
+// getSchemaVersion returns the latest migration applied to the database.
+func getSchemaVersion(tx *sql.Tx) (uint, error) {
+	var version uint
+	var dirty bool
+	err := tx.QueryRow("SELECT version, dirty FROM schema_migrations").Scan(&version, &dirty)
+	if err != nil {
+		return 0, fmt.Errorf("reading the schema version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("migration %d failed, fix the database first", version)
+	}
+	return version, nil
+}
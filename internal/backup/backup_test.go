@@ -0,0 +1,159 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeArchive(t *testing.T, tamper func(manifest *Manifest)) *bytes.Reader {
+	t.Helper()
+	var out bytes.Buffer
+	writer := newArchiveWriter(&out, 17, time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC))
+	for name, lines := range map[string][]string{
+		"users":    {`{"id":1,"name":"Ann","email":"ann@example.com"}`, `{"id":4,"name":"Bob","email":"bob@example.com"}`},
+		"projects": {`{"id":2,"title":"Website","manager_id":4}`},
+	} {
+		err := writer.table(name, func(write func(line []byte) error) error {
+			for _, line := range lines {
+				if err := write([]byte(line)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tamper != nil {
+		tamper(writer.manifest)
+	}
+	if _, err := writer.close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(out.Bytes())
+}
+
+func TestArchive(t *testing.T) {
+	in := writeArchive(t, nil)
+	archive, err := OpenArchive(in, in.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archive.Manifest.SchemaVersion != 17 || len(archive.Manifest.Tables) != 2 {
+		t.Fatalf("unexpected manifest %+v", archive.Manifest)
+	}
+	if err := archive.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range archive.Manifest.Tables {
+		var lines []string
+		err := archive.each(table, nil, func(line []byte) error {
+			lines = append(lines, string(line))
+			return nil
+		})
+		if err != nil || len(lines) != table.Rows {
+			t.Errorf("%s: read %d lines, want %d: %v", table.Name, len(lines), table.Rows, err)
+		}
+	}
+
+	in = writeArchive(t, func(manifest *Manifest) {
+		manifest.Tables[0].SHA256 = strings.Repeat("0", 64)
+	})
+	archive, err = OpenArchive(in, in.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Verify(); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Errorf("a wrong checksum was not noticed: %v", err)
+	}
+
+	in = writeArchive(t, func(manifest *Manifest) {
+		manifest.Version = Version + 1
+	})
+	if _, err := OpenArchive(in, in.Size()); err == nil {
+		t.Error("an archive of a later version was opened")
+	}
+
+	in = writeArchive(t, func(manifest *Manifest) {
+		manifest.Tables[0].Name = "invoices"
+	})
+	if _, err := OpenArchive(in, in.Size()); err == nil {
+		t.Error("an archive with an unknown table was opened")
+	}
+}
+
+func decode(t *testing.T, line string) map[string]any {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	var row map[string]any
+	if err := decoder.Decode(&row); err != nil {
+		t.Fatal(err)
+	}
+	return row
+}
+
+func TestRemap(t *testing.T) {
+	r := newRemapper()
+	r.add("users", 4, 1)
+	r.add("projects", 2, 1)
+	r.add("tasks", 10, 1)
+	r.add("tasks", 11, 2)
+	r.add("milestones", 3, 1)
+
+	row := decode(t, `{"title":"Login","responsible_user_id":4,"project_id":2,"parent_id":10,"milestone_id":3,"sprint_id":null,"epic_id":null,"recurrence_id":null}`)
+	deferred, err := r.row(findTable("tasks"), row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row["responsible_user_id"] != int64(1) || row["project_id"] != int64(1) || row["milestone_id"] != int64(1) || row["parent_id"] != nil || row["sprint_id"] != nil {
+		t.Errorf("unexpected row %v", row)
+	}
+	if !reflect.DeepEqual(deferred, map[string]any{"parent_id": json.Number("10")}) {
+		t.Errorf("deferred %v", deferred)
+	}
+
+	if _, err := r.row(findTable("task_comments"), decode(t, `{"task_id":99,"user_id":4}`)); err == nil {
+		t.Error("a comment of an unknown task was remapped")
+	}
+
+	row = decode(t, `{"project_id":2,"conditions":[{"field":"responsible_user_id","operator":"eq","value":"4"},{"field":"status","operator":"eq","value":"done"}],"actions":[{"type":"assign_user","user_id":4},{"type":"notify_manager"}]}`)
+	if _, err := r.row(findTable("automation_rules"), row); err != nil {
+		t.Fatal(err)
+	}
+	rule, _ := json.Marshal(row)
+	if want := `{"actions":[{"type":"assign_user","user_id":1},{"type":"notify_manager"}],"conditions":[{"field":"responsible_user_id","operator":"eq","value":"1"},{"field":"status","operator":"eq","value":"done"}],"project_id":1}`; string(rule) != want {
+		t.Errorf("rule %s, want %s", rule, want)
+	}
+
+	r.add("baselines", 5, 1)
+	row = decode(t, `{"baseline_id":5,"task_id":12,"title":"Deleted since"}`)
+	if _, err := r.row(findTable("baseline_tasks"), row); err != nil || row["task_id"] != int64(-12) {
+		t.Errorf("deleted baseline task remapped to %v, %v", row["task_id"], err)
+	}
+
+	row = decode(t, `{"source":"jira","kind":"task","external_id":"10001","entity_id":11}`)
+	if _, err := r.row(findTable("external_ids"), row); err != nil || row["entity_id"] != int64(2) {
+		t.Errorf("external id remapped to %v, %v", row["entity_id"], err)
+	}
+	if _, err := r.row(findTable("external_ids"), decode(t, `{"source":"jira","kind":"task","external_id":"10002","entity_id":12}`)); err != errSkipRow {
+		t.Errorf("the external id of a deleted task was not skipped: %v", err)
+	}
+}
+
+func TestTablesOrder(t *testing.T) {
+	restored := make(map[string]bool)
+	for _, table := range tables {
+		for column, ref := range table.refs {
+			if !restored[ref] && ref != table.name {
+				t.Errorf("%s.%s refers to %s, which is restored later", table.name, column, ref)
+			}
+		}
+		restored[table.name] = true
+	}
+}
@@ -0,0 +1,222 @@
+package backup
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// table is a table of the backup. Tables are listed in the order they are
+// restored, so that each comes after the tables it refers to.
+type table struct {
+	name string
+	// serial is set for tables with a generated id column; restoring them
+	// gives their rows new ids.
+	serial bool
+	// refs maps the columns holding ids to the tables of the ids.
+	refs map[string]string
+	// deferred are the columns referring to the table itself, which are set
+	// once all of its rows are restored.
+	deferred []string
+	// orderBy keeps the lines of a table in a stable order.
+	orderBy string
+	// remap rewrites the ids that refs cannot describe.
+	remap func(r *remapper, row map[string]any) error
+}
+
+var tables = []*table{
+	{name: "users", serial: true, orderBy: "id"},
+	{name: "projects", serial: true, orderBy: "id", refs: map[string]string{"manager_id": "users"}},
+	{name: "task_recurrences", serial: true, orderBy: "id"},
+	{name: "sprints", serial: true, orderBy: "id", refs: map[string]string{"project_id": "projects"}},
+	{name: "milestones", serial: true, orderBy: "id", refs: map[string]string{"project_id": "projects"}},
+	{name: "epics", serial: true, orderBy: "id", refs: map[string]string{"project_id": "projects"}},
+	{name: "tasks", serial: true, orderBy: "id", deferred: []string{"parent_id"}, refs: map[string]string{
+		"responsible_user_id": "users", "project_id": "projects", "recurrence_id": "task_recurrences", "parent_id": "tasks",
+		"sprint_id": "sprints", "milestone_id": "milestones", "epic_id": "epics",
+	}},
+	{name: "task_status_history", serial: true, orderBy: "id", refs: map[string]string{"task_id": "tasks"}},
+	{name: "task_comments", serial: true, orderBy: "id", refs: map[string]string{"task_id": "tasks", "user_id": "users"}},
+	{name: "task_watchers", orderBy: "task_id, user_id", refs: map[string]string{"task_id": "tasks", "user_id": "users"}},
+	{name: "notifications", serial: true, orderBy: "id", refs: map[string]string{"user_id": "users", "task_id": "tasks", "project_id": "projects"}},
+	{name: "notification_preferences", orderBy: "user_id", refs: map[string]string{"user_id": "users"}},
+	{name: "automation_rules", serial: true, orderBy: "id", refs: map[string]string{"project_id": "projects"}, remap: remapRule},
+	{name: "automation_rule_runs", serial: true, orderBy: "id", refs: map[string]string{"rule_id": "automation_rules", "task_id": "tasks", "project_id": "projects"}},
+	{name: "project_templates", serial: true, orderBy: "id"},
+	{name: "template_tasks", serial: true, orderBy: "id", deferred: []string{"parent_id"}, refs: map[string]string{"template_id": "project_templates", "parent_id": "template_tasks"}},
+	{name: "sprint_carryover", orderBy: "sprint_id, task_id", refs: map[string]string{"sprint_id": "sprints", "task_id": "tasks"}},
+	// worklogs come before timesheets, which lock the weeks they cover
+	{name: "worklogs", serial: true, orderBy: "id", refs: map[string]string{"task_id": "tasks", "user_id": "users"}},
+	{name: "timesheets", serial: true, orderBy: "id", refs: map[string]string{"user_id": "users"}},
+	{name: "timesheet_transitions", serial: true, orderBy: "id", refs: map[string]string{"timesheet_id": "timesheets", "user_id": "users"}},
+	{name: "board_wip_limits", orderBy: "project_id, status", refs: map[string]string{"project_id": "projects"}},
+	{name: "task_dependencies", orderBy: "predecessor_id, successor_id", refs: map[string]string{"predecessor_id": "tasks", "successor_id": "tasks"}},
+	{name: "baselines", serial: true, orderBy: "id", refs: map[string]string{"project_id": "projects"}},
+	{name: "baseline_tasks", orderBy: "baseline_id, task_id", refs: map[string]string{"baseline_id": "baselines"}, remap: remapBaselineTask},
+	{name: "user_capacity", orderBy: "user_id", refs: map[string]string{"user_id": "users"}},
+	{name: "time_off", serial: true, orderBy: "id", refs: map[string]string{"user_id": "users"}},
+	{name: "external_ids", orderBy: "source, kind, external_id", remap: remapExternalID},
+	{name: "calendar_feeds", serial: true, orderBy: "id", refs: map[string]string{"user_id": "users", "project_id": "projects"}},
+}
+
+func findTable(name string) *table {
+	for _, t := range tables {
+		if t.name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// remapper maps the ids of the rows of a backup to the ids they are
+// restored with, by table.
+type remapper struct {
+	ids map[string]map[int64]int64
+}
+
+func newRemapper() *remapper {
+	return &remapper{ids: make(map[string]map[int64]int64)}
+}
+
+func (r *remapper) add(table string, oldID, newID int64) {
+	if r.ids[table] == nil {
+		r.ids[table] = make(map[int64]int64)
+	}
+	r.ids[table][oldID] = newID
+}
+
+// id returns the new id of the row of table with the old id value. Null
+// stays null.
+func (r *remapper) id(table string, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	oldID, err := toInt(value)
+	if err != nil {
+		return nil, err
+	}
+	newID, ok := r.ids[table][oldID]
+	if !ok {
+		return nil, fmt.Errorf("no row of %s has the id %d", table, oldID)
+	}
+	return newID, nil
+}
+
+// row rewrites the ids of a row of t. The old ids of the deferred columns
+// are returned instead and the columns are cleared.
+func (r *remapper) row(t *table, row map[string]any) (map[string]any, error) {
+	deferred := make(map[string]any)
+	for _, column := range t.deferred {
+		if row[column] != nil {
+			deferred[column] = row[column]
+		}
+		row[column] = nil
+	}
+	for column, refTable := range t.refs {
+		if _, ok := deferred[column]; ok {
+			continue
+		}
+		id, err := r.id(refTable, row[column])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", column, err)
+		}
+		row[column] = id
+	}
+	if t.remap != nil {
+		if err := t.remap(r, row); err != nil {
+			return nil, err
+		}
+	}
+	return deferred, nil
+}
+
+// remapRule rewrites the users and projects that the conditions and actions
+// of an automation rule name.
+func remapRule(r *remapper, row map[string]any) error {
+	conditions, _ := row["conditions"].([]any)
+	for _, item := range conditions {
+		condition, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		refTable := map[string]string{"responsible_user_id": "users", "project_id": "projects"}[fmt.Sprint(condition["field"])]
+		value, _ := condition["value"].(string)
+		if refTable == "" || value == "" {
+			continue
+		}
+		id, err := r.id(refTable, value)
+		if err != nil {
+			return fmt.Errorf("conditions: %w", err)
+		}
+		condition["value"] = fmt.Sprint(id)
+	}
+	actions, _ := row["actions"].([]any)
+	for _, item := range actions {
+		action, ok := item.(map[string]any)
+		if !ok || action["user_id"] == nil {
+			continue
+		}
+		id, err := r.id("users", action["user_id"])
+		if err != nil {
+			return fmt.Errorf("actions: %w", err)
+		}
+		action["user_id"] = id
+	}
+	return nil
+}
+
+// remapBaselineTask rewrites the task of a baseline. The task may have been
+// deleted since the baseline was taken; it gets the negated old id then,
+// which no task has, so that it keeps showing as removed.
+func remapBaselineTask(r *remapper, row map[string]any) error {
+	oldID, err := toInt(row["task_id"])
+	if err != nil {
+		return fmt.Errorf("task_id: %w", err)
+	}
+	if newID, ok := r.ids["tasks"][oldID]; ok {
+		row["task_id"] = newID
+	} else {
+		row["task_id"] = -oldID
+	}
+	return nil
+}
+
+// externalKinds maps the kinds of external ids to their tables.
+var externalKinds = map[string]string{
+	models.ExternalUserKind: "users", models.ExternalProjectKind: "projects", models.ExternalTaskKind: "tasks", models.ExternalCommentKind: "task_comments",
+}
+
+// remapExternalID rewrites the entity of an external id, whose table
+// depends on its kind. The external ids of entities deleted since the
+// import are not restored.
+func remapExternalID(r *remapper, row map[string]any) error {
+	refTable, ok := externalKinds[fmt.Sprint(row["kind"])]
+	if !ok {
+		return fmt.Errorf("unknown kind %v", row["kind"])
+	}
+	oldID, err := toInt(row["entity_id"])
+	if err != nil {
+		return fmt.Errorf("entity_id: %w", err)
+	}
+	newID, ok := r.ids[refTable][oldID]
+	if !ok {
+		return errSkipRow
+	}
+	row["entity_id"] = newID
+	return nil
+}
+
+func toInt(value any) (int64, error) {
+	switch value := value.(type) {
+	case json.Number:
+		return value.Int64()
+	case string:
+		return strconv.ParseInt(value, 10, 64)
+	case int64:
+		return value, nil
+	case float64:
+		return int64(value), nil
+	}
+	return 0, fmt.Errorf("invalid id %v", value)
+}
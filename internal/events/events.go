@@ -0,0 +1,62 @@
+package events
+
+import (
+	"ProjectManagementService/internal/models"
+	"sync"
+)
+
+type Type string
+
+const (
+	TaskCreated    Type = "task_created"
+	TaskUpdated    Type = "task_updated"
+	ProjectCreated Type = "project_created"
+	ProjectUpdated Type = "project_updated"
+	CommentCreated Type = "comment_created"
+)
+
+// Event describes a write that went through the handlers. Previous* fields
+// hold the state before an update and are nil for creations.
+type Event struct {
+	Type            Type
+	Task            *models.Task
+	PreviousTask    *models.Task
+	Project         *models.Project
+	PreviousProject *models.Project
+	Comment         *models.Comment
+	// Rules lists the automation rules whose actions led to this event, in
+	// firing order. It is empty for writes made by clients.
+	Rules []int
+}
+
+type Handler func(event Event)
+
+// Bus delivers events synchronously to every subscriber in subscription order.
+// A nil *Bus is valid and drops everything, so handlers can be built without one.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	handlers := make([]Handler, len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,125 @@
+// Package export writes tables as CSV or XLSX one row at a time, so that
+// exports never hold more than a row in memory.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+var contentTypes = map[Format]string{
+	CSV:  "text/csv",
+	XLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+func ParseFormat(value string) (Format, error) {
+	format := Format(strings.ToLower(value))
+	if _, ok := contentTypes[format]; !ok {
+		return "", fmt.Errorf("unknown format %q, expected csv or xlsx", value)
+	}
+	return format, nil
+}
+
+// Negotiate picks the format asked for in an Accept header. CSV is the
+// default when the header is empty or accepts anything.
+func Negotiate(accept string) (Format, error) {
+	if strings.TrimSpace(accept) == "" {
+		return CSV, nil
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case contentTypes[XLSX]:
+			return XLSX, nil
+		case contentTypes[CSV], "text/*", "*/*":
+			return CSV, nil
+		}
+	}
+	return "", fmt.Errorf("cannot export as %s, accepted types are %s and %s", accept, contentTypes[CSV], contentTypes[XLSX])
+}
+
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// Column is a column of an export. Numeric columns are written as numbers
+// in XLSX; their empty values are left blank.
+type Column struct {
+	Name    string
+	Numeric bool
+}
+
+// Writer writes the rows of a table after its header. Close must be called
+// once all rows are written.
+type Writer interface {
+	Write(values []string) error
+	Close() error
+}
+
+// NewWriter writes the header of columns to out and returns the writer for
+// the rows.
+func NewWriter(out io.Writer, format Format, sheet string, columns []Column) (Writer, error) {
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+	numeric := make([]bool, 0, len(columns))
+	for _, column := range columns {
+		numeric = append(numeric, column.Numeric)
+	}
+	var writer Writer
+	switch format {
+	case CSV:
+		writer = &csvWriter{out: csv.NewWriter(out), numeric: numeric}
+	case XLSX:
+		xlsx, err := newXLSXWriter(out, sheet, numeric)
+		if err != nil {
+			return nil, err
+		}
+		writer = xlsx
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+	if err := writer.Write(names); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+type csvWriter struct {
+	out     *csv.Writer
+	numeric []bool
+	row     []string
+}
+
+// Write quotes text that a spreadsheet would take for a formula with a
+// leading apostrophe, so that titles and names from users cannot run as
+// formulas when the file is opened. XLSX cells are always written as text.
+func (w *csvWriter) Write(values []string) error {
+	w.row = w.row[:0]
+	for i, value := range values {
+		if (i >= len(w.numeric) || !w.numeric[i]) && value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+			value = "'" + value
+		}
+		w.row = append(w.row, value)
+	}
+	return w.out.Write(w.row)
+}
+
+func (w *csvWriter) Close() error {
+	w.out.Flush()
+	return w.out.Error()
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+var columns = []Column{{Name: "id", Numeric: true}, {Name: "title"}, {Name: "story_points", Numeric: true}}
+
+func TestCSVWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := NewWriter(&out, CSV, "Tasks", columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = writer.Write([]string{"1", "Write \"docs\", then ship", ""})
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "id,title,story_points\n1,\"Write \"\"docs\"\", then ship\",\n"; out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestCSVWriterFormulas(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := NewWriter(&out, CSV, "Tasks", columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]string{
+		{"1", "=HYPERLINK(\"http://example.com\")", "-1.5"},
+		{"2", "+1", ""},
+		{"3", "-cmd", ""},
+		{"4", "@SUM(A1)", ""},
+		{"5", "\tTab", ""},
+		{"6", "\rReturn", ""},
+		{"7", "Fix a+b=c", ""},
+	} {
+		_ = writer.Write(row)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,title,story_points\n" +
+		"1,\"'=HYPERLINK(\"\"http://example.com\"\")\",-1.5\n" +
+		"2,'+1,\n" +
+		"3,'-cmd,\n" +
+		"4,'@SUM(A1),\n" +
+		"5,'\tTab,\n" +
+		"6,\"'\rReturn\",\n" +
+		"7,Fix a+b=c,\n"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := NewWriter(&out, XLSX, "Tasks: all", columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = writer.Write([]string{"1", "Fix <login> & logout", "2.5"})
+	_ = writer.Write([]string{"2", " padded ", ""})
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(reader)
+		parts[file.Name] = string(content)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Tasks all"`) {
+		t.Errorf("unexpected workbook %s", parts["xl/workbook.xml"])
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, cell := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`,
+		`<c r="A2"><v>1</v></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">Fix &lt;login&gt; &amp; logout</t></is></c>`,
+		`<c r="C2"><v>2.5</v></c>`,
+		`<t xml:space="preserve"> padded </t>`,
+	} {
+		if !strings.Contains(sheet, cell) {
+			t.Errorf("sheet is missing %s:\n%s", cell, sheet)
+		}
+	}
+	if strings.Contains(sheet, `r="C3"`) {
+		t.Error("empty value written as a cell")
+	}
+}
+
+func TestCellName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := cellName(index); got != want {
+			t.Errorf("cellName(%d) = %s, want %s", index, got, want)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		format Format
+		ok     bool
+	}{
+		{"", CSV, true},
+		{"*/*", CSV, true},
+		{"text/csv; charset=utf-8", CSV, true},
+		{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", XLSX, true},
+		{"application/json, text/*;q=0.5", CSV, true},
+		{"application/json", "", false},
+	}
+	for _, test := range tests {
+		format, err := Negotiate(test.accept)
+		if format != test.format || (err == nil) != test.ok {
+			t.Errorf("Negotiate(%q) = %q, %v", test.accept, format, err)
+		}
+	}
+}
@@ -0,0 +1,488 @@
+package graphql
+
+import (
+	"ProjectManagementService/internal/models"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize and MaxPageSize bound the pages of connections.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Loaders batch the loads of the entities of a request. The resolvers of
+// the API find them in the context, see WithLoaders.
+type Loaders struct {
+	model    models.GraphModel
+	users    *Loader
+	projects *Loader
+	tasks    *Loader
+	// userTasks and projectTasks have a loader for each filter and page of
+	// the tasks of users and projects the query asks for.
+	userTasks    map[string]*Loader
+	projectTasks map[string]*Loader
+}
+
+func NewLoaders(model models.GraphModel) *Loaders {
+	return &Loaders{
+		model: model,
+		users: NewLoader(func(ids []int) (map[int]any, error) {
+			users, err := model.GetUsersByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[int]any, len(users))
+			for _, user := range users {
+				values[user.ID] = user
+			}
+			return values, nil
+		}),
+		projects: NewLoader(func(ids []int) (map[int]any, error) {
+			projects, err := model.GetProjectsByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[int]any, len(projects))
+			for _, project := range projects {
+				values[project.ID] = project
+			}
+			return values, nil
+		}),
+		tasks: NewLoader(func(ids []int) (map[int]any, error) {
+			tasks, err := model.GetTasksByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[int]any, len(tasks))
+			for _, task := range tasks {
+				values[task.ID] = task
+			}
+			return values, nil
+		}),
+		userTasks:    make(map[string]*Loader),
+		projectTasks: make(map[string]*Loader),
+	}
+}
+
+// taskPages returns the loader of a page of the tasks of users or projects,
+// which fetch loads.
+func taskPages(loaders map[string]*Loader, fetch func(ids []int, filter models.TaskFilter, page models.Page) (map[int]*models.TaskPage, error), filter models.TaskFilter, page models.Page) *Loader {
+	key := fmt.Sprintf("%+v %+v", filter, page)
+	if loader := loaders[key]; loader != nil {
+		return loader
+	}
+	loader := NewLoader(func(ids []int) (map[int]any, error) {
+		pages, err := fetch(ids, filter, page)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[int]any, len(ids))
+		for _, id := range ids {
+			values[id] = taskConnection(pages[id])
+		}
+		return values, nil
+	})
+	loaders[key] = loader
+	return loader
+}
+
+type loadersKey struct{}
+
+// WithLoaders returns a context for the resolvers of the API.
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+func loadersOf(p graphql.ResolveParams) *Loaders {
+	return p.Context.Value(loadersKey{}).(*Loaders)
+}
+
+// connection is a page of nodes in id order, the source of the connection
+// types.
+type connection struct {
+	nodes       []any
+	ids         []int
+	totalCount  int
+	hasNextPage bool
+}
+
+func userConnection(page *models.UserPage) *connection {
+	c := &connection{nodes: []any{}, totalCount: page.TotalCount, hasNextPage: page.HasNextPage}
+	for _, user := range page.Users {
+		c.nodes, c.ids = append(c.nodes, user), append(c.ids, user.ID)
+	}
+	return c
+}
+
+func projectConnection(page *models.ProjectPage) *connection {
+	c := &connection{nodes: []any{}, totalCount: page.TotalCount, hasNextPage: page.HasNextPage}
+	for _, project := range page.Projects {
+		c.nodes, c.ids = append(c.nodes, project), append(c.ids, project.ID)
+	}
+	return c
+}
+
+func taskConnection(page *models.TaskPage) *connection {
+	c := &connection{nodes: []any{}}
+	if page == nil {
+		return c
+	}
+	c.totalCount, c.hasNextPage = page.TotalCount, page.HasNextPage
+	for _, task := range page.Tasks {
+		c.nodes, c.ids = append(c.nodes, task), append(c.ids, task.ID)
+	}
+	return c
+}
+
+// Cursors are opaque to clients; they hold the id of a node.
+func encodeCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("cursor:" + strconv.Itoa(id)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	text, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(text), "cursor:") {
+		if id, err := strconv.Atoi(strings.TrimPrefix(string(text), "cursor:")); err == nil {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid cursor %q", cursor)
+}
+
+// connectionType returns the type of pages of node.
+func connectionType(node, pageInfo *graphql.Object, nodes string) *graphql.Object {
+	edge := graphql.NewObject(graphql.ObjectConfig{
+		Name: node.Name() + "Edge",
+		Fields: graphql.Fields{
+			"cursor": {Description: "Pass as after to get the " + nodes + " that follow.", Type: graphql.NewNonNull(graphql.String)},
+			"node":   {Type: graphql.NewNonNull(node)},
+		},
+	})
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        node.Name() + "Connection",
+		Description: "A page of " + nodes + " in id order.",
+		Fields: graphql.Fields{
+			"totalCount": {Description: "The count of the " + nodes + " on all pages.", Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*connection).totalCount, nil
+			}},
+			"pageInfo": {Type: graphql.NewNonNull(pageInfo), Resolve: func(p graphql.ResolveParams) (any, error) {
+				c := p.Source.(*connection)
+				info := map[string]any{"hasNextPage": c.hasNextPage, "endCursor": nil}
+				if len(c.ids) > 0 {
+					info["endCursor"] = encodeCursor(c.ids[len(c.ids)-1])
+				}
+				return info, nil
+			}},
+			"edges": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge))), Resolve: func(p graphql.ResolveParams) (any, error) {
+				c := p.Source.(*connection)
+				edges := make([]any, len(c.nodes))
+				for i, node := range c.nodes {
+					edges[i] = map[string]any{"cursor": encodeCursor(c.ids[i]), "node": node}
+				}
+				return edges, nil
+			}},
+			"nodes": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(node))), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*connection).nodes, nil
+			}},
+		},
+	})
+}
+
+func nonNull(t graphql.Type) graphql.Type {
+	return graphql.NewNonNull(t)
+}
+
+// pageArgs are the arguments of connections. The complexity of queries
+// takes the fields with a first argument for connections, see checkLimits.
+func pageArgs(nodes string) graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"first": {Description: fmt.Sprintf("How many %s to return, at most %d.", nodes, MaxPageSize), Type: graphql.Int, DefaultValue: DefaultPageSize},
+		"after": {Description: "The cursor of the edge the page starts after.", Type: graphql.String},
+	}
+}
+
+func pageOf(args map[string]any) (models.Page, error) {
+	page := models.Page{First: DefaultPageSize}
+	if first, ok := args["first"].(int); ok {
+		if first < 0 || first > MaxPageSize {
+			return page, fmt.Errorf("first must be between 0 and %d", MaxPageSize)
+		}
+		page.First = first
+	}
+	if after, ok := args["after"].(string); ok {
+		id, err := decodeCursor(after)
+		if err != nil {
+			return page, err
+		}
+		page.After = id
+	}
+	return page, nil
+}
+
+// idOf returns the id argument named name, or 0 when it is not given.
+func idOf(args map[string]any, name string) (int, error) {
+	text, ok := args[name].(string)
+	if !ok {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(text)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, text)
+	}
+	return id, nil
+}
+
+// taskArgs are the filters of the tasks of connections, but the one the
+// connection sets, and the page.
+func taskArgs(status, priority *graphql.Enum, except string) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"title":      {Description: "Only the tasks with this title.", Type: graphql.String},
+		"status":     {Type: status},
+		"priority":   {Type: priority},
+		"assigneeId": {Description: "Only the tasks this user is responsible for.", Type: graphql.ID},
+		"projectId":  {Description: "Only the tasks of this project.", Type: graphql.ID},
+	}
+	delete(args, except)
+	for name, arg := range pageArgs("tasks") {
+		args[name] = arg
+	}
+	return args
+}
+
+func taskFilterOf(args map[string]any) (models.TaskFilter, error) {
+	filter := models.TaskFilter{}
+	filter.Title, _ = args["title"].(string)
+	filter.Status, _ = args["status"].(models.StatusEnum)
+	filter.Priority, _ = args["priority"].(models.PriorityEnum)
+	var err error
+	if filter.ResponsibleUserID, err = idOf(args, "assigneeId"); err != nil {
+		return filter, err
+	}
+	filter.ProjectID, err = idOf(args, "projectId")
+	return filter, err
+}
+
+// optional maps the empty strings the models use for missing values to null.
+func optional(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// load resolves to the entity of a loader, or to null for the zero id.
+func load(loader *Loader, id int) (any, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return loader.Load(id), nil
+}
+
+// NewAPI returns the schema of the API. Its resolvers need the loaders of
+// the request in their context, see WithLoaders.
+func NewAPI() *Schema {
+	taskStatus := graphql.NewEnum(graphql.EnumConfig{Name: "TaskStatus", Values: graphql.EnumValueConfigMap{
+		"NEW":         {Value: models.New},
+		"IN_PROGRESS": {Value: models.InProgress},
+		"DONE":        {Value: models.Done},
+	}})
+	taskPriority := graphql.NewEnum(graphql.EnumConfig{Name: "TaskPriority", Values: graphql.EnumValueConfigMap{
+		"LOW":    {Value: models.Low},
+		"MEDIUM": {Value: models.Medium},
+		"HIGH":   {Value: models.High},
+	}})
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{Name: "PageInfo", Fields: graphql.Fields{
+		"hasNextPage": {Type: nonNull(graphql.Boolean)},
+		"endCursor":   {Description: "The cursor of the last edge of the page, null when it is empty.", Type: graphql.String},
+	}})
+	// the fields of the entities refer to each other, so they are defined
+	// once all types exist
+	var user, project, task, tasks *graphql.Object
+	user = graphql.NewObject(graphql.ObjectConfig{Name: "User", Fields: graphql.FieldsThunk(func() graphql.Fields {
+		return graphql.Fields{
+			"id":    {Type: nonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.User).ID, nil }},
+			"name":  {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.User).Name, nil }},
+			"email": {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.User).Email, nil }},
+			"role":  {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.User).Role, nil }},
+			"registrationDate": {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.User).RegistrationDate, nil
+			}},
+			"tasks": {Description: "The tasks the user is responsible for.", Type: nonNull(tasks), Args: taskArgs(taskStatus, taskPriority, "assigneeId"),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					filter, err := taskFilterOf(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					page, err := pageOf(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					loaders := loadersOf(p)
+					return taskPages(loaders.userTasks, loaders.model.GetUsersTaskPages, filter, page).Load(p.Source.(*models.User).ID), nil
+				}},
+		}
+	})})
+	project = graphql.NewObject(graphql.ObjectConfig{Name: "Project", Fields: graphql.FieldsThunk(func() graphql.Fields {
+		return graphql.Fields{
+			"id":    {Type: nonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.Project).ID, nil }},
+			"title": {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.Project).Title, nil }},
+			"description": {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.Project).Description, nil
+			}},
+			"creationDate": {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.Project).CreationDate, nil
+			}},
+			"completionDate": {Description: "Null until the project is completed.", Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return optional(p.Source.(*models.Project).CompletionDate), nil
+			}},
+			"manager": {Type: user, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return load(loadersOf(p).users, p.Source.(*models.Project).ManagerID)
+			}},
+			"tasks": {Type: nonNull(tasks), Args: taskArgs(taskStatus, taskPriority, "projectId"), Resolve: func(p graphql.ResolveParams) (any, error) {
+				filter, err := taskFilterOf(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				page, err := pageOf(p.Args)
+				if err != nil {
+					return nil, err
+				}
+				loaders := loadersOf(p)
+				return taskPages(loaders.projectTasks, loaders.model.GetProjectsTaskPages, filter, page).Load(p.Source.(*models.Project).ID), nil
+			}},
+		}
+	})})
+	task = graphql.NewObject(graphql.ObjectConfig{Name: "Task", Fields: graphql.FieldsThunk(func() graphql.Fields {
+		return graphql.Fields{
+			"id":    {Type: nonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.Task).ID, nil }},
+			"title": {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.Task).Title, nil }},
+			"description": {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.Task).Description, nil
+			}},
+			"status": {Type: nonNull(taskStatus), Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.Task).Status, nil }},
+			"priority": {Type: nonNull(taskPriority), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.Task).Priority, nil
+			}},
+			"labels": {Type: nonNull(graphql.NewList(nonNull(graphql.String))), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.Task).Labels, nil
+			}},
+			"creationDate": {Type: nonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.Task).CreationDate, nil
+			}},
+			"startDate": {Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) { return optional(p.Source.(*models.Task).StartDate), nil }},
+			"dueDate":   {Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) { return optional(p.Source.(*models.Task).DueDate), nil }},
+			"completionDate": {Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return optional(p.Source.(*models.Task).CompletionDate), nil
+			}},
+			"storyPoints": {Type: graphql.Float, Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.Task).StoryPoints, nil }},
+			"originalEstimateMinutes": {Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.Task).OriginalEstimateMinutes, nil
+			}},
+			"remainingEstimateMinutes": {Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*models.Task).RemainingEstimateMinutes, nil
+			}},
+			"assignee": {Description: "The user responsible for the task.", Type: user, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return load(loadersOf(p).users, p.Source.(*models.Task).ResponsibleUserID)
+			}},
+			"project": {Type: project, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return load(loadersOf(p).projects, p.Source.(*models.Task).ProjectID)
+			}},
+			"parent": {Description: "The task this task is a subtask of.", Type: task, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return load(loadersOf(p).tasks, p.Source.(*models.Task).ParentID)
+			}},
+		}
+	})})
+	users := connectionType(user, pageInfo, "users")
+	projects := connectionType(project, pageInfo, "projects")
+	tasks = connectionType(task, pageInfo, "tasks")
+
+	userArgs := pageArgs("users")
+	userArgs["email"] = &graphql.ArgumentConfig{Description: "Only the user with this email.", Type: graphql.String}
+	userArgs["name"] = &graphql.ArgumentConfig{Description: "Only the users with this name.", Type: graphql.String}
+	projectArgs := pageArgs("projects")
+	projectArgs["title"] = &graphql.ArgumentConfig{Description: "Only the projects with this title.", Type: graphql.String}
+	projectArgs["managerId"] = &graphql.ArgumentConfig{Description: "Only the projects this user manages.", Type: graphql.ID}
+
+	query := graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: graphql.Fields{
+		"user": {Type: user, Args: graphql.FieldConfigArgument{"id": {Type: nonNull(graphql.ID)}}, Resolve: func(p graphql.ResolveParams) (any, error) {
+			id, err := idOf(p.Args, "id")
+			if err != nil {
+				return nil, err
+			}
+			return load(loadersOf(p).users, id)
+		}},
+		"users": {Type: nonNull(users), Args: userArgs, Resolve: func(p graphql.ResolveParams) (any, error) {
+			filter := models.UserFilter{}
+			filter.Email, _ = p.Args["email"].(string)
+			filter.Name, _ = p.Args["name"].(string)
+			page, err := pageOf(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			result, err := loadersOf(p).model.GetUserPage(filter, page)
+			if err != nil {
+				return nil, err
+			}
+			return userConnection(result), nil
+		}},
+		"project": {Type: project, Args: graphql.FieldConfigArgument{"id": {Type: nonNull(graphql.ID)}}, Resolve: func(p graphql.ResolveParams) (any, error) {
+			id, err := idOf(p.Args, "id")
+			if err != nil {
+				return nil, err
+			}
+			return load(loadersOf(p).projects, id)
+		}},
+		"projects": {Type: nonNull(projects), Args: projectArgs, Resolve: func(p graphql.ResolveParams) (any, error) {
+			filter := models.ProjectFilter{}
+			filter.Title, _ = p.Args["title"].(string)
+			managerID, err := idOf(p.Args, "managerId")
+			if err != nil {
+				return nil, err
+			}
+			filter.ManagerID = managerID
+			page, err := pageOf(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			result, err := loadersOf(p).model.GetProjectPage(filter, page)
+			if err != nil {
+				return nil, err
+			}
+			return projectConnection(result), nil
+		}},
+		"task": {Type: task, Args: graphql.FieldConfigArgument{"id": {Type: nonNull(graphql.ID)}}, Resolve: func(p graphql.ResolveParams) (any, error) {
+			id, err := idOf(p.Args, "id")
+			if err != nil {
+				return nil, err
+			}
+			return load(loadersOf(p).tasks, id)
+		}},
+		"tasks": {Type: nonNull(tasks), Args: taskArgs(taskStatus, taskPriority, ""), Resolve: func(p graphql.ResolveParams) (any, error) {
+			filter, err := taskFilterOf(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			page, err := pageOf(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			result, err := loadersOf(p).model.GetTaskPage(filter, page)
+			if err != nil {
+				return nil, err
+			}
+			return taskConnection(result), nil
+		}},
+	}})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		panic(err)
+	}
+	return &Schema{schema: schema, sdl: printSchema(&schema)}
+}
@@ -0,0 +1,151 @@
+// Package graphql defines the GraphQL API of the service: users, projects
+// and tasks with their relationships. Queries are parsed, validated and
+// executed by github.com/graphql-go/graphql; this package adds the schema
+// and its resolvers, the batching of loads, see Loader, and the limits on
+// the work a query may ask for.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"strings"
+)
+
+// Schema is the schema of the API together with its description in the
+// schema definition language.
+type Schema struct {
+	schema graphql.Schema
+	sdl    string
+}
+
+// SDL describes the types of the schema in the schema definition language.
+func (s *Schema) SDL() string {
+	return s.sdl
+}
+
+// Request is a GraphQL request as clients post it.
+type Request struct {
+	Query         string         `json:"query" example:"{ project(id: 1) { title tasks { nodes { title assignee { name } } } } }"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response holds the data of an executed query, null where fields failed,
+// and the errors. Requests that fail validation have errors only.
+type Response struct {
+	Errors   []*Error `json:"errors,omitempty"`
+	Data     any      `json:"data"`
+	executed bool
+}
+
+// Executed tells whether the query was valid and executed, whatever errors
+// its fields had.
+func (r *Response) Executed() bool {
+	return r.executed
+}
+
+func (r *Response) MarshalJSON() ([]byte, error) {
+	if !r.executed {
+		return json.Marshal(struct {
+			Errors []*Error `json:"errors"`
+		}{r.Errors})
+	}
+	return json.Marshal(struct {
+		Errors []*Error `json:"errors,omitempty"`
+		Data   any      `json:"data"`
+	}{r.Errors, r.Data})
+}
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is an error of a request, or of a field with the path to the field
+// in the data.
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorsOf(formatted []gqlerrors.FormattedError) []*Error {
+	errs := make([]*Error, 0, len(formatted))
+	for _, f := range formatted {
+		err := &Error{Message: f.Message, Path: f.Path}
+		for _, loc := range f.Locations {
+			err.Locations = append(err.Locations, Location{Line: loc.Line, Column: loc.Column})
+		}
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Execute validates and runs a query of request against schema. Resolvers
+// get ctx.
+func Execute(ctx context.Context, schema *Schema, request *Request) *Response {
+	if strings.TrimSpace(request.Query) == "" {
+		return &Response{Errors: []*Error{{Message: "Must provide query string."}}}
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return &Response{Errors: errorsOf(gqlerrors.FormatErrors(err))}
+	}
+	// the rule on overlapping fields recurses into fragments without end when
+	// they spread in a cycle, so cycles are ruled out first
+	for _, rules := range [][]graphql.ValidationRuleFn{{graphql.NoFragmentCyclesRule}, graphql.SpecifiedRules} {
+		if result := graphql.ValidateDocument(&schema.schema, doc, rules); !result.IsValid {
+			return &Response{Errors: errorsOf(result.Errors)}
+		}
+	}
+	if op := operation(doc, request.OperationName); op != nil && op.Operation == ast.OperationTypeQuery {
+		if err := checkLimits(&schema.schema, doc, op, request.Variables); err != nil {
+			return &Response{Errors: []*Error{err}}
+		}
+	}
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema.schema,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       ctx,
+	})
+	response := &Response{Data: result.Data, Errors: errorsOf(result.Errors), executed: result.Data != nil}
+	// a failed non-null field at the root nulls the data, unlike an
+	// operation or variables that cannot be executed, which fail without a
+	// path
+	for _, err := range response.Errors {
+		response.executed = response.executed || len(err.Path) > 0
+	}
+	return response
+}
+
+// operation returns the operation of doc to execute: the one named name, or
+// the only one. It is nil when there is none, which Execute reports.
+func operation(doc *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		op, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil
+			}
+			found = op
+		} else if op.Name != nil && op.Name.Value == name {
+			return op
+		}
+	}
+	return found
+}
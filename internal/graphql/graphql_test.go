@@ -0,0 +1,243 @@
+package graphql
+
+import (
+	"ProjectManagementService/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"strings"
+	"testing"
+)
+
+// graphModel serves two projects of two tasks each and counts the loads.
+func graphModel(calls map[string]int) *models.MockGraphModel {
+	users := map[int]*models.User{1: {ID: 1, Name: "Ann"}, 2: {ID: 2, Name: "Bob"}}
+	projects := []*models.Project{{ID: 10, Title: "Website", ManagerID: 1}, {ID: 11, Title: "App", ManagerID: 2}}
+	tasks := []*models.Task{
+		{ID: 100, Title: "Login", Status: models.InProgress, Priority: models.High, ProjectID: 10, ResponsibleUserID: 2, Labels: []string{"ui"}},
+		{ID: 101, Title: "Logout", Status: models.New, Priority: models.Low, ProjectID: 10, ResponsibleUserID: 1, Labels: []string{}},
+		{ID: 102, Title: "Store", Status: models.Done, Priority: models.Medium, ProjectID: 11, ResponsibleUserID: 2, Labels: []string{}},
+		{ID: 103, Title: "Push", Status: models.New, Priority: models.Medium, ProjectID: 11, ResponsibleUserID: 2, Labels: []string{}},
+	}
+	return &models.MockGraphModel{
+		MockGetUsersByIDs: func(ids []int) ([]*models.User, error) {
+			calls["users"]++
+			var result []*models.User
+			for _, id := range ids {
+				if user := users[id]; user != nil {
+					result = append(result, user)
+				}
+			}
+			return result, nil
+		},
+		MockGetProjectPage: func(filter models.ProjectFilter, page models.Page) (*models.ProjectPage, error) {
+			calls["projects"]++
+			return &models.ProjectPage{Projects: projects, TotalCount: 2}, nil
+		},
+		MockGetProjectsTaskPages: func(projectIDs []int, filter models.TaskFilter, page models.Page) (map[int]*models.TaskPage, error) {
+			calls["project tasks"]++
+			pages := make(map[int]*models.TaskPage)
+			for _, id := range projectIDs {
+				pages[id] = &models.TaskPage{Tasks: []*models.Task{}}
+			}
+			for _, task := range tasks {
+				taskPage := pages[task.ProjectID]
+				if taskPage == nil || task.ID <= page.After || filter.Status != "" && task.Status != filter.Status {
+					continue
+				}
+				taskPage.TotalCount++
+				if len(taskPage.Tasks) == page.First {
+					taskPage.HasNextPage = true
+				} else {
+					taskPage.Tasks = append(taskPage.Tasks, task)
+				}
+			}
+			return pages, nil
+		},
+	}
+}
+
+func execute(t *testing.T, model models.GraphModel, query string, variables map[string]any) string {
+	t.Helper()
+	ctx := WithLoaders(context.Background(), NewLoaders(model))
+	body, err := json.Marshal(Execute(ctx, NewAPI(), &Request{Query: query, Variables: variables}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestExecuteBatchesLoads(t *testing.T) {
+	calls := make(map[string]int)
+	body := execute(t, graphModel(calls), `{
+		projects {
+			totalCount
+			nodes {
+				title
+				manager { name }
+				tasks(first: 1) {
+					totalCount
+					pageInfo { hasNextPage }
+					nodes { id status assignee { name } }
+				}
+			}
+		}
+	}`, nil)
+
+	want := `{"data":{"projects":{"nodes":[` +
+		`{"manager":{"name":"Ann"},"tasks":{"nodes":[{"assignee":{"name":"Bob"},"id":"100","status":"IN_PROGRESS"}],"pageInfo":{"hasNextPage":true},"totalCount":2},"title":"Website"},` +
+		`{"manager":{"name":"Bob"},"tasks":{"nodes":[{"assignee":{"name":"Bob"},"id":"102","status":"DONE"}],"pageInfo":{"hasNextPage":true},"totalCount":2},"title":"App"}],"totalCount":2}}}`
+	if body != want {
+		t.Errorf("got  %s\nwant %s", body, want)
+	}
+	// the assignees were loaded with the managers, which are a level higher
+	if calls["projects"] != 1 || calls["project tasks"] != 1 || calls["users"] != 1 {
+		t.Errorf("unexpected loads %v", calls)
+	}
+}
+
+func TestExecuteVariablesAndFragments(t *testing.T) {
+	calls := make(map[string]int)
+	body := execute(t, graphModel(calls), `
+		query Board($status: TaskStatus, $after: String, $withManager: Boolean = false) {
+			projects {
+				nodes {
+					...project
+					manager @include(if: $withManager) { name }
+				}
+			}
+		}
+		fragment project on Project {
+			__typename
+			name: title
+			open: tasks(status: $status, after: $after) { edges { cursor node { title } } }
+		}`, map[string]any{"status": "NEW", "after": encodeCursor(101)})
+
+	want := `{"data":{"projects":{"nodes":[` +
+		`{"__typename":"Project","name":"Website","open":{"edges":[]}},` +
+		`{"__typename":"Project","name":"App","open":{"edges":[{"cursor":"` + encodeCursor(103) + `","node":{"title":"Push"}}]}}]}}}`
+	if body != want {
+		t.Errorf("got  %s\nwant %s", body, want)
+	}
+	if calls["users"] != 0 {
+		t.Errorf("skipped managers were loaded %d times", calls["users"])
+	}
+}
+
+func TestExecuteFieldErrors(t *testing.T) {
+	model := &models.MockGraphModel{
+		MockGetTaskPage: func(filter models.TaskFilter, page models.Page) (*models.TaskPage, error) {
+			return nil, errors.New("connection refused")
+		},
+		MockGetProjectsByIDs: func(ids []int) ([]*models.Project, error) {
+			return []*models.Project{{ID: 10, Title: "Website"}}, nil
+		},
+	}
+	tests := []struct {
+		query string
+		want  string
+	}{
+		// a non-null field fails, so its parent is null
+		{`{ project(id: 10) { title } tasks { totalCount } }`,
+			`{"errors":[{"message":"connection refused","locations":[{"line":1,"column":29}],"path":["tasks"]}],"data":null}`},
+		{`{ a: project(id: 10) { title } b: project(id: 12) { title } }`,
+			`{"data":{"a":{"title":"Website"},"b":null}}`},
+		{`{ project(id: 10) { title tasks(first: 500) { totalCount } } }`,
+			`{"errors":[{"message":"first must be between 0 and 100","locations":[{"line":1,"column":27}],"path":["project","tasks"]}],"data":{"project":null}}`},
+	}
+	for _, test := range tests {
+		if body := execute(t, model, test.query, nil); body != test.want {
+			t.Errorf("%s:\ngot  %s\nwant %s", test.query, body, test.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		query string
+		error string
+	}{
+		{`{ user(id: 1) { name }`, `Syntax Error GraphQL request (1:23) Expected Name, found EOF`},
+		{`{ user(id: 1) { nickname } }`, `Cannot query field "nickname" on type "User". Did you mean "name"?`},
+		{`{ user { name } }`, `Field "user" argument "id" of type "ID!" is required but not provided.`},
+		{`{ user(id: 1) }`, `Field "user" of type "User" must have a sub selection.`},
+		{`{ tasks(status: OPEN) { totalCount } }`, "Argument \"status\" has invalid value OPEN.\nExpected type \"TaskStatus\", found OPEN."},
+		{`{ tasks(first: "ten") { totalCount } }`, "Argument \"first\" has invalid value \"ten\".\nExpected type \"Int\", found \"ten\"."},
+		{`query($id: Int!) { task(id: $id) { title } }`, `Variable "$id" of type "Int!" used in position expecting type "ID!".`},
+		{`{ task(id: $id) { title } }`, `Variable "$id" is not defined.`},
+		{`{ ...task } fragment task on Task { title }`, `Fragment "task" cannot be spread here as objects of type "Query" can never be of type "Task".`},
+		{`{ user(id: 1) { ...a } } fragment a on User { ...a }`, `Cannot spread fragment "a" within itself.`},
+		{`{ user(id: 1) { name: email name } }`, `Fields "name" conflict because email and name are different fields. Use different aliases on the fields to fetch both if this was intentional.`},
+		{`mutation { user(id: 1) { name } }`, `Schema is not configured for mutations`},
+		{`{ task(id: 1) { parent { parent { parent { parent { parent { parent { parent { parent { parent { title } } } } } } } } } } }`,
+			`Query is nested deeper than 10 levels.`},
+		{`{ projects(first: 100) { nodes { tasks(first: 100) { nodes { title } } } } }`,
+			`Query may return more than 10000 objects.`},
+	}
+	for _, test := range tests {
+		response := Execute(context.Background(), NewAPI(), &Request{Query: test.query})
+		// syntax errors go on with an excerpt of the query
+		if response.Executed() || len(response.Errors) == 0 || !strings.HasPrefix(response.Errors[0].Message, test.error) {
+			t.Errorf("%s: got %+v, want %q", test.query, response.Errors, test.error)
+		}
+	}
+}
+
+func TestComplexity(t *testing.T) {
+	query := `query($n: Int) { projects(first: $n) { nodes { tasks(first: $n) { nodes { title } } } } }`
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{100, MaxComplexity + 1},
+		{50, 1 + 50 + 50 + 50*50},
+	}
+	api := NewAPI()
+	doc := parse(t, query)
+	for _, test := range tests {
+		got := complexity(api, doc, map[string]any{"n": test.n})
+		if got != test.want {
+			t.Errorf("first: %d: got complexity %d, want %d", test.n, got, test.want)
+		}
+	}
+
+	// pages whose size is invalid count as full
+	doc = parse(t, `{ projects(first: "ten") { nodes { tasks(first: 100) { nodes { title } } } } }`)
+	if got := complexity(api, doc, nil); got != MaxComplexity+1 {
+		t.Errorf("first: \"ten\": got complexity %d, want %d", got, MaxComplexity+1)
+	}
+}
+
+func parse(t *testing.T, query string) *ast.Document {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// complexity counts the objects the first operation of doc may return, up
+// to MaxComplexity+1.
+func complexity(api *Schema, doc *ast.Document, variables map[string]any) int {
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	l := &limits{doc: doc, op: op, variables: variables}
+	return l.count(api.schema.QueryType(), op.SelectionSet, 1, 0)
+}
+
+func TestSDL(t *testing.T) {
+	sdl := NewAPI().SDL()
+	for _, part := range []string{
+		"type Query {\n  project(id: ID!): Project\n",
+		"  tasks(after: String, assigneeId: ID, first: Int = 20, priority: TaskPriority, projectId: ID, status: TaskStatus, title: String): TaskConnection!\n",
+		"enum TaskStatus {\n  DONE\n  IN_PROGRESS\n  NEW\n}\n",
+		"type TaskConnection {\n",
+		"schema {\n  query: Query\n}\n",
+	} {
+		if !strings.Contains(sdl, part) {
+			t.Errorf("schema lacks %q:\n%s", part, sdl)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package graphql
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"strconv"
+)
+
+// MaxDepth is how deeply fields may be nested in a query.
+const MaxDepth = 10
+
+// MaxComplexity bounds the work a query asks for: the count of the objects
+// it may return at most, with every page of a connection taken as full.
+const MaxComplexity = 10000
+
+// limits walks the fields an operation selects, as the executor will with
+// the variables of the request.
+type limits struct {
+	doc       *ast.Document
+	op        *ast.OperationDefinition
+	variables map[string]any
+	err       *Error
+}
+
+// checkLimits reports a query that is nested deeper than MaxDepth or may
+// return more than MaxComplexity objects. A field of an object type counts
+// once for each object it is selected on, and the page size of a connection,
+// a field with a first argument, multiplies the count of the objects below
+// it. Pages whose size is invalid count as full; the executor reports them.
+func checkLimits(schema *graphql.Schema, doc *ast.Document, op *ast.OperationDefinition, variables map[string]any) *Error {
+	l := &limits{doc: doc, op: op, variables: variables}
+	if l.count(schema.QueryType(), op.SelectionSet, 1, 0) > MaxComplexity && l.err == nil {
+		l.err = errorAt(op.Loc, "Query may return more than %d objects.", MaxComplexity)
+	}
+	return l.err
+}
+
+func errorAt(loc *ast.Location, format string, args ...any) *Error {
+	err := &Error{Message: fmt.Sprintf(format, args...)}
+	if loc != nil {
+		at := location.GetLocation(loc.Source, loc.Start)
+		err.Locations = []Location{{Line: at.Line, Column: at.Column}}
+	}
+	return err
+}
+
+// count returns the count of the objects the selections of an object of
+// type t return, for objects of them at depth. Counting stops once it is
+// over MaxComplexity or a field is too deep.
+func (l *limits) count(t *graphql.Object, selectionSet *ast.SelectionSet, objects, depth int) int {
+	total := 0
+	for _, group := range l.collect(selectionSet) {
+		f := group[0]
+		if depth >= MaxDepth {
+			l.err = errorAt(f.Loc, "Query is nested deeper than %d levels.", MaxDepth)
+			return MaxComplexity + 1
+		}
+		def := t.Fields()[f.Name.Value]
+		if def == nil {
+			continue
+		}
+		child, ok := graphql.GetNamed(def.Type).(*graphql.Object)
+		if !ok {
+			continue
+		}
+		total += objects
+		below := objects
+		for _, arg := range def.Args {
+			if arg.Name() == "first" {
+				below *= l.pageSize(arg, f)
+			}
+		}
+		if total > MaxComplexity || below > MaxComplexity {
+			return MaxComplexity + 1
+		}
+		subselections := &ast.SelectionSet{}
+		for _, f := range group {
+			if f.SelectionSet != nil {
+				subselections.Selections = append(subselections.Selections, f.SelectionSet.Selections...)
+			}
+		}
+		total += l.count(child, subselections, below, depth+1)
+		if total > MaxComplexity {
+			return MaxComplexity + 1
+		}
+	}
+	return total
+}
+
+// pageSize is the most nodes the connection f returns for its first
+// argument, defined as first.
+func (l *limits) pageSize(first *graphql.Argument, f *ast.Field) int {
+	size, _ := first.DefaultValue.(int)
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		switch value := l.value(arg.Value).(type) {
+		case nil:
+		case int:
+			size = value
+		case float64:
+			size = int(value)
+		default:
+			return MaxPageSize
+		}
+	}
+	switch {
+	case size > MaxPageSize:
+		return MaxPageSize
+	case size < 0:
+		return 0
+	}
+	return size
+}
+
+// collect groups the fields of selectionSet by the key they have in the
+// response, in order, with the ones of its fragments and without the ones
+// its directives skip.
+func (l *limits) collect(selectionSet *ast.SelectionSet) [][]*ast.Field {
+	var groups [][]*ast.Field
+	index := make(map[string]int)
+	var walk func(selectionSet *ast.SelectionSet)
+	walk = func(selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch s := selection.(type) {
+			case *ast.Field:
+				if !l.included(s.Directives) {
+					continue
+				}
+				key := s.Name.Value
+				if s.Alias != nil {
+					key = s.Alias.Value
+				}
+				if i, ok := index[key]; ok {
+					groups[i] = append(groups[i], s)
+				} else {
+					index[key] = len(groups)
+					groups = append(groups, []*ast.Field{s})
+				}
+			case *ast.InlineFragment:
+				if l.included(s.Directives) {
+					walk(s.SelectionSet)
+				}
+			case *ast.FragmentSpread:
+				if l.included(s.Directives) {
+					walk(l.fragment(s.Name.Value))
+				}
+			}
+		}
+	}
+	walk(selectionSet)
+	return groups
+}
+
+func (l *limits) fragment(name string) *ast.SelectionSet {
+	for _, definition := range l.doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name.Value == name {
+			return fragment.SelectionSet
+		}
+	}
+	return nil
+}
+
+// included tells whether the @skip and @include directives keep a selection.
+func (l *limits) included(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		for _, arg := range directive.Arguments {
+			if arg.Name.Value != "if" {
+				continue
+			}
+			condition, _ := l.value(arg.Value).(bool)
+			if directive.Name.Value == "skip" && condition || directive.Name.Value == "include" && !condition {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// value returns a literal, or the value of a variable or else its default;
+// nil for a variable without either.
+func (l *limits) value(v ast.Value) any {
+	switch v := v.(type) {
+	case *ast.Variable:
+		if value, ok := l.variables[v.Name.Value]; ok {
+			return value
+		}
+		for _, definition := range l.op.VariableDefinitions {
+			if definition.Variable.Name.Value == v.Name.Value && definition.DefaultValue != nil {
+				return l.value(definition.DefaultValue)
+			}
+		}
+		return nil
+	case *ast.IntValue:
+		n, err := strconv.Atoi(v.Value)
+		if err != nil {
+			return v.Value
+		}
+		return n
+	case *ast.BooleanValue:
+		return v.Value
+	}
+	return v.GetValue()
+}
@@ -0,0 +1,63 @@
+package graphql
+
+// Thunk is what a resolver returns to put off its work until the other
+// fields at the same depth of the query are resolved: the executor calls
+// the thunks of a level once all of them are returned. Resolvers return the
+// thunks of a Loader, so that the values of all of those fields are fetched
+// at once. It is an alias as the executor only takes this exact type.
+type Thunk = func() (any, error)
+
+// Loader batches the loads of values by key, such as the users by id of
+// the tasks of a query: Load queues a key and returns a thunk, and the first
+// of the thunks to be called fetches the values of all queued keys with one
+// call. Values are kept, so each key is fetched once. A Loader serves one
+// request and is not safe for concurrent use.
+type Loader struct {
+	fetch  func(keys []int) (map[int]any, error)
+	queue  []int
+	queued map[int]bool
+	loaded map[int]bool
+	values map[int]any
+	errs   map[int]error
+}
+
+// NewLoader returns a loader that fetches with fetch, which returns the
+// values of the keys by key. The values of keys it leaves out are nil.
+func NewLoader(fetch func(keys []int) (map[int]any, error)) *Loader {
+	return &Loader{
+		fetch:  fetch,
+		queued: make(map[int]bool),
+		loaded: make(map[int]bool),
+		values: make(map[int]any),
+		errs:   make(map[int]error),
+	}
+}
+
+func (l *Loader) Load(key int) Thunk {
+	if !l.loaded[key] && !l.queued[key] {
+		l.queue = append(l.queue, key)
+		l.queued[key] = true
+	}
+	return func() (any, error) {
+		if !l.loaded[key] {
+			l.dispatch()
+		}
+		return l.values[key], l.errs[key]
+	}
+}
+
+// dispatch fetches the queued keys.
+func (l *Loader) dispatch() {
+	keys := l.queue
+	l.queue = nil
+	values, err := l.fetch(keys)
+	for _, key := range keys {
+		delete(l.queued, key)
+		l.loaded[key] = true
+		if err != nil {
+			l.errs[key] = err
+		} else if value, ok := values[key]; ok {
+			l.values[key] = value
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package graphql
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// printSchema describes the object and enum types of schema in the schema
+// definition language. Types, fields, arguments and enum values are in name
+// order, as the schema does not keep the order they were defined in.
+func printSchema(schema *graphql.Schema) string {
+	var names []string
+	for name, t := range schema.TypeMap() {
+		switch t.(type) {
+		case *graphql.Object, *graphql.Enum:
+			if !strings.HasPrefix(name, "__") {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var sdl strings.Builder
+	for _, name := range names {
+		switch t := schema.Type(name).(type) {
+		case *graphql.Enum:
+			writeDescription(&sdl, "", t.Description())
+			fmt.Fprintf(&sdl, "enum %s {\n", t.Name())
+			values := t.Values()
+			sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+			for _, v := range values {
+				writeDescription(&sdl, "  ", v.Description)
+				fmt.Fprintf(&sdl, "  %s\n", v.Name)
+			}
+			sdl.WriteString("}\n\n")
+		case *graphql.Object:
+			writeDescription(&sdl, "", t.Description())
+			fmt.Fprintf(&sdl, "type %s {\n", t.Name())
+			fields := t.Fields()
+			fieldNames := make([]string, 0, len(fields))
+			for fieldName := range fields {
+				fieldNames = append(fieldNames, fieldName)
+			}
+			sort.Strings(fieldNames)
+			for _, fieldName := range fieldNames {
+				f := fields[fieldName]
+				writeDescription(&sdl, "  ", f.Description)
+				sdl.WriteString("  " + f.Name)
+				if len(f.Args) > 0 {
+					args := make([]string, len(f.Args))
+					for i, arg := range f.Args {
+						args[i] = arg.Name() + ": " + arg.Type.String()
+						if arg.DefaultValue != nil {
+							args[i] += " = " + literal(arg.Type, arg.DefaultValue)
+						}
+					}
+					sort.Strings(args)
+					sdl.WriteString("(" + strings.Join(args, ", ") + ")")
+				}
+				sdl.WriteString(": " + f.Type.String() + "\n")
+			}
+			sdl.WriteString("}\n\n")
+		}
+	}
+	sdl.WriteString("schema {\n  query: " + schema.QueryType().Name() + "\n}\n")
+	return sdl.String()
+}
+
+func writeDescription(sdl *strings.Builder, indent, description string) {
+	if description != "" {
+		sdl.WriteString(indent + strconv.Quote(description) + "\n")
+	}
+}
+
+// literal writes a default value as a GraphQL literal.
+func literal(t graphql.Type, value any) string {
+	if enum, ok := graphql.GetNamed(t).(*graphql.Enum); ok {
+		if name, ok := enum.Serialize(value).(string); ok {
+			return name
+		}
+	}
+	if text, ok := value.(string); ok {
+		return strconv.Quote(text)
+	}
+	return fmt.Sprint(value)
+}
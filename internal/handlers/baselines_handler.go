@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/metrics"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type BaselineInput struct {
+	Name string `json:"name" example:"Kick-off plan"`
+}
+
+type BaselineHandler struct {
+	BaselineModel models.BaselineModel
+	ProjectModel  models.ProjectModel
+	TaskModel     models.TaskModel
+}
+
+func NewBaselineHandler(baselineModel models.BaselineModel, projectModel models.ProjectModel, taskModel models.TaskModel) *BaselineHandler {
+	return &BaselineHandler{
+		BaselineModel: baselineModel,
+		ProjectModel:  projectModel,
+		TaskModel:     taskModel,
+	}
+}
+
+// @Summary Get the baselines of a project
+// @Description Most recent first, without their tasks.
+// @Tags baselines
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {array} models.Baseline
+// @Router /projects/{id}/baselines [get]
+// @Failure 404 {string} string "No baselines found"
+// @Failure 500 {string} string "Internal server error"
+func (bh *BaselineHandler) GetProjectBaselinesHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	baselines, err := bh.BaselineModel.GetProjectBaselines(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(baselines) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(baselines)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Take a baseline of a project
+// @Description Records the dates, estimates and status of every task of the project under a name unique in the project.
+// @Tags baselines
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param baseline body BaselineInput true "Baseline"
+// @Success 201 {object} models.Baseline
+// @Router /projects/{id}/baselines [post]
+// @Failure 400 {string} string "Invalid baseline"
+// @Failure 404 {string} string "Project not found"
+// @Failure 409 {string} string "A baseline with this name exists"
+// @Failure 500 {string} string "Internal server error"
+func (bh *BaselineHandler) CreateBaselineHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input BaselineInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	baseline := &models.Baseline{ProjectID: id, Name: input.Name}
+	if err := baseline.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := bh.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	existing, err := bh.BaselineModel.GetProjectBaselines(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, other := range existing {
+		if other.Name == baseline.Name {
+			http.Error(writer, "a baseline named "+baseline.Name+" already exists", http.StatusConflict)
+			return
+		}
+	}
+	baselineID, err := bh.BaselineModel.CreateBaseline(baseline)
+	if err != nil {
+		http.Error(writer, "could not create baseline: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, err := bh.BaselineModel.GetBaselineById(baselineID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(created)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get baseline by ID
+// @Description Includes the tasks as they were recorded.
+// @Tags baselines
+// @Produce json
+// @Param id path int true "Baseline ID"
+// @Success 200 {object} models.Baseline
+// @Router /baselines/{id} [get]
+// @Failure 404 {string} string "Baseline not found"
+// @Failure 500 {string} string "Internal server error"
+func (bh *BaselineHandler) GetBaselineHandler(writer http.ResponseWriter, request *http.Request) {
+	baseline, ok := bh.baselineFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	var err error
+	baseline.Tasks, err = bh.BaselineModel.GetBaselineTasks(baseline.ID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(baseline)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Delete a baseline
+// @Tags baselines
+// @Param id path int true "Baseline ID"
+// @Success 200 {string} string "Baseline deleted"
+// @Router /baselines/{id} [delete]
+// @Failure 404 {string} string "Baseline not found"
+// @Failure 500 {string} string "Internal server error"
+func (bh *BaselineHandler) DeleteBaselineHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := bh.BaselineModel.DeleteBaseline(id)
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Compare a project with a baseline
+// @Description Tasks added and removed since the baseline, and tasks whose dates, original estimate, story points or
+// @Description status changed. Slips are in days, positive when a date moved later.
+// @Tags baselines
+// @Produce json
+// @Param id path int true "Baseline ID"
+// @Success 200 {object} metrics.Variance
+// @Router /baselines/{id}/variance [get]
+// @Failure 404 {string} string "Baseline not found"
+// @Failure 500 {string} string "Internal server error"
+func (bh *BaselineHandler) GetVarianceHandler(writer http.ResponseWriter, request *http.Request) {
+	baseline, ok := bh.baselineFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	planned, err := bh.BaselineModel.GetBaselineTasks(baseline.ID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := bh.TaskModel.SearchTaskByProjectID(baseline.ProjectID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(metrics.NewVariance(baseline, planned, tasks))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// baselineFromRequest loads the baseline named by the id path variable and
+// writes the error response when it cannot.
+func (bh *BaselineHandler) baselineFromRequest(writer http.ResponseWriter, request *http.Request) (*models.Baseline, bool) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	baseline, err := bh.BaselineModel.GetBaselineById(id)
+	if baseline == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return baseline, true
+}
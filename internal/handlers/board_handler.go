@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type MoveTaskInput struct {
+	Status string `json:"status" example:"in_progress"`
+	// Position is the index in the target column, counted without the moved
+	// task; it is clamped to the column.
+	Position int `json:"position" example:"0"`
+}
+
+type WIPLimitsInput struct {
+	// Limits maps a status to the most tasks its column takes; 0 or a missing
+	// status means unlimited.
+	Limits map[string]int `json:"limits"`
+}
+
+type BoardHandler struct {
+	BoardModel   models.BoardModel
+	ProjectModel models.ProjectModel
+	TaskModel    models.TaskModel
+	Events       *events.Bus
+}
+
+func NewBoardHandler(boardModel models.BoardModel, projectModel models.ProjectModel, taskModel models.TaskModel, bus *events.Bus) *BoardHandler {
+	return &BoardHandler{
+		BoardModel:   boardModel,
+		ProjectModel: projectModel,
+		TaskModel:    taskModel,
+		Events:       bus,
+	}
+}
+
+// @Summary Get the board of a project
+// @Description The project's tasks grouped in one column per status, each column in rank order. Tasks that were never
+// @Description moved on the board come last in their column.
+// @Tags board
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} models.Board
+// @Router /projects/{id}/board [get]
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (bh *BoardHandler) GetBoardHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := bh.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	board, err := bh.BoardModel.GetBoard(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(board)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Set the WIP limits of a board
+// @Description Replaces the limits of all columns. Columns already above their new limit keep their tasks but take no
+// @Description more.
+// @Tags board
+// @Accept json
+// @Param id path int true "Project ID"
+// @Param limits body WIPLimitsInput true "Limits by status"
+// @Success 200 {string} string "Limits updated"
+// @Router /projects/{id}/board/limits [put]
+// @Failure 400 {string} string "Invalid limits"
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (bh *BoardHandler) SetWIPLimitsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input WIPLimitsInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limits := make(map[models.StatusEnum]int)
+	for status, limit := range input.Limits {
+		if !models.StatusEnum(status).Valid() {
+			http.Error(writer, "invalid status "+status, http.StatusBadRequest)
+			return
+		}
+		if limit < 0 {
+			http.Error(writer, "limits cannot be negative", http.StatusBadRequest)
+			return
+		}
+		limits[models.StatusEnum(status)] = limit
+	}
+	project, err := bh.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = bh.BoardModel.SetWIPLimits(id, limits)
+	if err != nil {
+		http.Error(writer, "could not update limits: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Move a task on the board
+// @Description Changes the status and the position of the task in one step. Moving a task to done sets its
+// @Description completion date, moving it out of done clears it.
+// @Tags board
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param move body MoveTaskInput true "Target column and position"
+// @Success 200 {object} models.Task
+// @Router /tasks/{id}/move [post]
+// @Failure 400 {string} string "Invalid status"
+// @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "The column is at its WIP limit"
+// @Failure 500 {string} string "Internal server error"
+func (bh *BoardHandler) MoveTaskHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input MoveTaskInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	status := models.StatusEnum(input.Status)
+	if !status.Valid() {
+		http.Error(writer, "invalid status "+input.Status, http.StatusBadRequest)
+		return
+	}
+	previous, err := bh.TaskModel.GetTaskById(id)
+	if previous == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	task, err := bh.BoardModel.MoveTask(id, status, input.Position)
+	if err == models.ErrWIPLimitReached {
+		http.Error(writer, fmt.Sprintf("the %s column is at its WIP limit", status), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(writer, "could not move task: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if task.Status != previous.Status {
+		bh.Events.Publish(events.Event{Type: events.TaskUpdated, Task: task, PreviousTask: previous})
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(task)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
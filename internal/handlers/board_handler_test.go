@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMoveTaskHandler(t *testing.T) {
+	mockTaskModel := &models.MockTaskModel{
+		MockGetTaskById: func(id int) (*models.Task, error) {
+			return &models.Task{ID: id, ProjectID: 1, Status: models.New}, nil
+		},
+	}
+	mockBoardModel := &models.MockBoardModel{
+		MockMoveTask: func(taskID int, status models.StatusEnum, position int) (*models.Task, error) {
+			if status == models.Done {
+				return nil, models.ErrWIPLimitReached
+			}
+			return &models.Task{ID: taskID, ProjectID: 1, Status: status, BoardRank: "i"}, nil
+		},
+	}
+	bus := events.NewBus()
+	var published []events.Event
+	bus.Subscribe(func(event events.Event) {
+		published = append(published, event)
+	})
+	handler := NewBoardHandler(mockBoardModel, &models.MockProjectModel{}, mockTaskModel, bus)
+
+	tests := []struct {
+		body      string
+		status    int
+		published int
+	}{
+		{`{"status":"in_progress","position":0}`, http.StatusOK, 1},
+		{`{"status":"new","position":3}`, http.StatusOK, 0},
+		{`{"status":"done"}`, http.StatusConflict, 0},
+		{`{"status":"blocked"}`, http.StatusBadRequest, 0},
+	}
+	for _, test := range tests {
+		published = nil
+		req, err := http.NewRequest("POST", "/tasks/7/move", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": "7"})
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(handler.MoveTaskHandler).ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", test.body, status, test.status)
+		}
+		if len(published) != test.published {
+			t.Errorf("%s: published %d events, want %d", test.body, len(published), test.published)
+		}
+	}
+}
+
+func TestSetWIPLimitsHandler(t *testing.T) {
+	var saved map[models.StatusEnum]int
+	mockBoardModel := &models.MockBoardModel{
+		MockSetWIPLimits: func(projectID int, limits map[models.StatusEnum]int) error {
+			saved = limits
+			return nil
+		},
+	}
+	mockProjectModel := &models.MockProjectModel{
+		MockGetProjectByID: func(id int) (*models.Project, error) {
+			return &models.Project{ID: id}, nil
+		},
+	}
+	handler := NewBoardHandler(mockBoardModel, mockProjectModel, &models.MockTaskModel{}, nil)
+
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{`{"limits":{"in_progress":3}}`, http.StatusOK},
+		{`{"limits":{"blocked":3}}`, http.StatusBadRequest},
+		{`{"limits":{"in_progress":-1}}`, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		saved = nil
+		req, err := http.NewRequest("PUT", "/projects/1/board/limits", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(handler.SetWIPLimitsHandler).ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", test.body, status, test.status)
+		}
+		if test.status == http.StatusOK && saved[models.InProgress] != 3 {
+			t.Errorf("%s: saved limits %v", test.body, saved)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/ical"
+	"ProjectManagementService/internal/models"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type CalendarHandler struct {
+	CalendarModel models.CalendarModel
+	UserModel     models.UserModel
+	ProjectModel  models.ProjectModel
+	// UIDDomain is the domain of the UIDs of the entries, see ical.Feed.
+	UIDDomain string
+}
+
+func NewCalendarHandler(calendarModel models.CalendarModel, userModel models.UserModel, projectModel models.ProjectModel, uidDomain string) *CalendarHandler {
+	return &CalendarHandler{
+		CalendarModel: calendarModel,
+		UserModel:     userModel,
+		ProjectModel:  projectModel,
+		UIDDomain:     uidDomain,
+	}
+}
+
+// @Summary Get the calendar feed of a user
+// @Description The feed lists the due dates of the tasks the user is responsible for and the milestones of their projects.
+// @Tags calendar
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} models.CalendarFeed
+// @Router /users/{id}/calendar-feed [get]
+// @Failure 404 {string} string "User has no calendar feed"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CalendarHandler) GetUserFeedHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(request)["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	feed, err := ch.CalendarModel.GetUserFeed(id)
+	ch.writeFeed(writer, request, feed, err, http.StatusOK)
+}
+
+// @Summary Create the calendar feed of a user
+// @Description Creates the secret feed link, or replaces its token so that the old link stops working.
+// @Tags calendar
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 201 {object} models.CalendarFeed
+// @Router /users/{id}/calendar-feed [post]
+// @Failure 404 {string} string "User not found"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CalendarHandler) CreateUserFeedHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(request)["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	user, err := ch.UserModel.GetUserById(id)
+	if user == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	token, err := newFeedToken()
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	feed, err := ch.CalendarModel.SetUserFeed(id, token)
+	if err != nil {
+		http.Error(writer, "could not create calendar feed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ch.writeFeed(writer, request, feed, nil, http.StatusCreated)
+}
+
+// @Summary Delete the calendar feed of a user
+// @Tags calendar
+// @Param id path int true "User ID"
+// @Success 200 {string} string "Calendar feed deleted"
+// @Router /users/{id}/calendar-feed [delete]
+// @Failure 404 {string} string "User has no calendar feed"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CalendarHandler) DeleteUserFeedHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(request)["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := ch.CalendarModel.DeleteUserFeed(id)
+	writeFeedDeleted(writer, deletedId, err)
+}
+
+// @Summary Get the calendar feed of a project
+// @Description The feed lists the due dates of the tasks and the milestones of the project.
+// @Tags calendar
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} models.CalendarFeed
+// @Router /projects/{id}/calendar-feed [get]
+// @Failure 404 {string} string "Project has no calendar feed"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CalendarHandler) GetProjectFeedHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(request)["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	feed, err := ch.CalendarModel.GetProjectFeed(id)
+	ch.writeFeed(writer, request, feed, err, http.StatusOK)
+}
+
+// @Summary Create the calendar feed of a project
+// @Description Creates the secret feed link, or replaces its token so that the old link stops working.
+// @Tags calendar
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 201 {object} models.CalendarFeed
+// @Router /projects/{id}/calendar-feed [post]
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CalendarHandler) CreateProjectFeedHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(request)["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := ch.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	token, err := newFeedToken()
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	feed, err := ch.CalendarModel.SetProjectFeed(id, token)
+	if err != nil {
+		http.Error(writer, "could not create calendar feed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ch.writeFeed(writer, request, feed, nil, http.StatusCreated)
+}
+
+// @Summary Delete the calendar feed of a project
+// @Tags calendar
+// @Param id path int true "Project ID"
+// @Success 200 {string} string "Calendar feed deleted"
+// @Router /projects/{id}/calendar-feed [delete]
+// @Failure 404 {string} string "Project has no calendar feed"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CalendarHandler) DeleteProjectFeedHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(request)["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := ch.CalendarModel.DeleteProjectFeed(id)
+	writeFeedDeleted(writer, deletedId, err)
+}
+
+// @Summary Get a calendar feed
+// @Description Renders the feed as iCalendar (RFC 5545) for calendar applications to subscribe to. Tasks with a due date
+// @Description are all-day events on it, or to-dos with todos=true; milestones are all-day events on their target date.
+// @Description The token is the secret of the feed link, so no other authentication is needed.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token"
+// @Param todos query bool false "Render tasks as VTODO entries"
+// @Success 200 {string} string "iCalendar feed"
+// @Success 304 {string} string "Feed not modified since the ETag in If-None-Match"
+// @Router /calendar/{token}.ics [get]
+// @Failure 404 {string} string "Feed not found"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CalendarHandler) GetCalendarHandler(writer http.ResponseWriter, request *http.Request) {
+	todos := false
+	if value := request.URL.Query().Get("todos"); value != "" {
+		var err error
+		todos, err = strconv.ParseBool(value)
+		if err != nil {
+			http.Error(writer, "invalid todos: "+value, http.StatusBadRequest)
+			return
+		}
+	}
+	feed, err := ch.CalendarModel.GetFeedByToken(mux.Vars(request)["token"])
+	if feed == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	name, err := ch.feedName(feed)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := ch.CalendarModel.GetFeedTasks(feed)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	milestones, err := ch.CalendarModel.GetFeedMilestones(feed)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	taskIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	revisions, err := ch.CalendarModel.GetTaskRevisions(taskIDs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	milestoneIDs := make([]int, 0, len(milestones))
+	for _, milestone := range milestones {
+		milestoneIDs = append(milestoneIDs, milestone.ID)
+	}
+	milestoneRevisions, err := ch.CalendarModel.GetMilestoneRevisions(milestoneIDs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	calendar := (&ical.Feed{Name: name, Domain: ch.UIDDomain, Tasks: tasks, Milestones: milestones, Revisions: revisions, MilestoneRevisions: milestoneRevisions, Todos: todos}).Calendar()
+	var body bytes.Buffer
+	if err := calendar.Encode(&body); err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// calendar applications poll feeds, the ETag spares them unchanged ones
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	writer.Header().Set("ETag", etag)
+	writer.Header().Set("Cache-Control", "private, no-cache")
+	if request.Header.Get("If-None-Match") == etag {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writer.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	writer.WriteHeader(http.StatusOK)
+	_, _ = body.WriteTo(writer)
+}
+
+// feedName is the name calendar applications show for feed.
+func (ch *CalendarHandler) feedName(feed *models.CalendarFeed) (string, error) {
+	if feed.UserID != 0 {
+		user, err := ch.UserModel.GetUserById(feed.UserID)
+		if err != nil {
+			return "", err
+		}
+		return "Tasks of " + user.Name, nil
+	}
+	project, err := ch.ProjectModel.GetProjectByID(feed.ProjectID)
+	if err != nil {
+		return "", err
+	}
+	return project.Title, nil
+}
+
+func (ch *CalendarHandler) writeFeed(writer http.ResponseWriter, request *http.Request, feed *models.CalendarFeed, err error, status int) {
+	if feed == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	scheme := "http"
+	if request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := request.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	feed.URL = fmt.Sprintf("%s://%s/calendar/%s.ics", scheme, request.Host, feed.Token)
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	err = json.NewEncoder(writer).Encode(feed)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func writeFeedDeleted(writer http.ResponseWriter, deletedId int, err error) {
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// newFeedToken returns a random token of 256 bits, which cannot be guessed.
+func newFeedToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
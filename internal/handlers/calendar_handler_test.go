@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateUserFeedHandler(t *testing.T) {
+	var stored string
+	mockCalendarModel := &models.MockCalendarModel{
+		MockSetUserFeed: func(userID int, token string) (*models.CalendarFeed, error) {
+			stored = token
+			return &models.CalendarFeed{ID: 1, Token: token, UserID: userID}, nil
+		},
+	}
+	mockUserModel := &models.MockUserModel{
+		MockGetUserById: func(id int) (*models.User, error) {
+			return &models.User{ID: id}, nil
+		},
+	}
+	handler := NewCalendarHandler(mockCalendarModel, mockUserModel, &models.MockProjectModel{}, "pms.example.com")
+
+	req, err := http.NewRequest("POST", "https://pms.example.com/users/2/calendar-feed", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.CreateUserFeedHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+	var feed models.CalendarFeed
+	if err := json.NewDecoder(rr.Body).Decode(&feed); err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 64 || feed.Token != stored || feed.UserID != 2 {
+		t.Errorf("unexpected feed %+v with token %q", feed, stored)
+	}
+	if feed.URL != "https://pms.example.com/calendar/"+stored+".ics" {
+		t.Errorf("unexpected url %q", feed.URL)
+	}
+}
+
+func TestGetCalendarHandler(t *testing.T) {
+	mockCalendarModel := &models.MockCalendarModel{
+		MockGetFeedByToken: func(token string) (*models.CalendarFeed, error) {
+			if token != "abc123" {
+				return nil, nil
+			}
+			return &models.CalendarFeed{ID: 1, Token: token, ProjectID: 4}, nil
+		},
+		MockGetFeedTasks: func(feed *models.CalendarFeed) ([]*models.Task, error) {
+			return []*models.Task{{ID: 7, Title: "Login page", Status: models.New, Priority: models.High, CreationDate: "2021-09-01T00:00:00Z", DueDate: "2021-09-10T00:00:00Z"}}, nil
+		},
+		MockGetFeedMilestones: func(feed *models.CalendarFeed) ([]*models.Milestone, error) {
+			return []*models.Milestone{{ID: 3, Title: "Beta", TargetDate: "2021-10-01T00:00:00Z"}}, nil
+		},
+		MockGetTaskRevisions: func(taskIDs []int) (map[int]*models.Revision, error) {
+			return map[int]*models.Revision{}, nil
+		},
+		MockGetMilestoneRevisions: func(milestoneIDs []int) (map[int]*models.Revision, error) {
+			return map[int]*models.Revision{3: {ID: 3, Sequence: 1, Modified: time.Date(2021, 9, 20, 9, 0, 0, 0, time.UTC)}}, nil
+		},
+	}
+	mockProjectModel := &models.MockProjectModel{
+		MockGetProjectByID: func(id int) (*models.Project, error) {
+			return &models.Project{ID: id, Title: "Website"}, nil
+		},
+	}
+	handler := NewCalendarHandler(mockCalendarModel, &models.MockUserModel{}, mockProjectModel, "pms.example.com")
+
+	get := func(token, etag string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", "/calendar/"+token+".ics?todos=true", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "pms.example.com"
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		req = mux.SetURLVars(req, map[string]string{"token": token})
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(handler.GetCalendarHandler).ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("abc123", "")
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != "text/calendar; charset=utf-8" {
+		t.Errorf("unexpected content type %q", contentType)
+	}
+	body := rr.Body.String()
+	for _, line := range []string{"X-WR-CALNAME:Website", "BEGIN:VTODO", "UID:task-7@pms.example.com", "DUE;VALUE=DATE:20210910", "UID:milestone-3@pms.example.com", "LAST-MODIFIED:20210920T090000Z"} {
+		if !strings.Contains(body, line+"\r\n") {
+			t.Errorf("feed lacks %q:\n%s", line, body)
+		}
+	}
+
+	etag := rr.Header().Get("ETag")
+	if rr := get("abc123", etag); etag == "" || rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+		t.Errorf("unchanged feed with ETag %q returned %v", etag, rr.Code)
+	}
+	if rr := get("unknown", ""); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown token returned %v, want %v", rr.Code, http.StatusNotFound)
+	}
+}
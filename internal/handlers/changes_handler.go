@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultChangesLimit = 100
+	maxChangesLimit     = 1000
+	maxChangesWait      = 60
+)
+
+// changesPollInterval is how often a long-polling request re-checks the feed.
+var changesPollInterval = 500 * time.Millisecond
+
+type ChangesResponse struct {
+	Changes   []*models.Change `json:"changes"`
+	NextSince int64            `json:"next_since"`
+}
+
+type ChangesHandler struct {
+	ChangeModel models.ChangeModel
+}
+
+func NewChangesHandler(changeModel models.ChangeModel) *ChangesHandler {
+	return &ChangesHandler{
+		ChangeModel: changeModel,
+	}
+}
+
+// @Summary Get changes feed
+// @Description Returns users, projects and tasks changed after the given sequence number, deletions included as tombstones.
+// @Description Pass the returned next_since on the next call. With wait > 0 the request is held open until a change arrives or the wait expires.
+// @Tags changes
+// @Produce json
+// @Param since query int false "Last sequence number seen by the client"
+// @Param limit query int false "Maximum number of changes to return (default 100, max 1000)"
+// @Param wait query int false "Seconds to wait for new changes (max 60)"
+// @Success 200 {object} ChangesResponse
+// @Router /changes [get]
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 410 {string} string "Sequence is ahead of the feed, full resync required"
+// @Failure 500 {string} string "Internal server error"
+func (ch *ChangesHandler) GetChangesHandler(writer http.ResponseWriter, request *http.Request) {
+	since, err := queryInt64(request, "since", 0)
+	if err != nil || since < 0 {
+		http.Error(writer, "invalid since parameter", http.StatusBadRequest)
+		return
+	}
+	limit, err := queryInt64(request, "limit", defaultChangesLimit)
+	if err != nil || limit <= 0 {
+		http.Error(writer, "invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+	if limit > maxChangesLimit {
+		limit = maxChangesLimit
+	}
+	wait, err := queryInt64(request, "wait", 0)
+	if err != nil || wait < 0 {
+		http.Error(writer, "invalid wait parameter", http.StatusBadRequest)
+		return
+	}
+	if wait > maxChangesWait {
+		wait = maxChangesWait
+	}
+
+	latest, err := ch.ChangeModel.GetLatestSeq()
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if since > latest {
+		http.Error(writer, "sequence is ahead of the feed, full resync required", http.StatusGone)
+		return
+	}
+
+	deadline := time.Now().Add(time.Duration(wait) * time.Second)
+	var changes []*models.Change
+	for {
+		changes, err = ch.ChangeModel.GetChangesSince(since, int(limit))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(changes) > 0 || !time.Now().Before(deadline) {
+			break
+		}
+		select {
+		case <-request.Context().Done():
+			return
+		case <-time.After(changesPollInterval):
+		}
+	}
+
+	response := ChangesResponse{Changes: changes, NextSince: since}
+	if len(changes) > 0 {
+		response.NextSince = changes[len(changes)-1].Seq
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(response)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func queryInt64(request *http.Request, name string, fallback int64) (int64, error) {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetChangesHandler(t *testing.T) {
+	mockChangeModel := &models.MockChangeModel{
+		MockGetLatestSeq: func() (int64, error) {
+			return 7, nil
+		},
+		MockGetChangesSince: func(since int64, limit int) ([]*models.Change, error) {
+			if since != 5 || limit != defaultChangesLimit {
+				t.Errorf("Unexpected input: %v, %v", since, limit)
+			}
+			return []*models.Change{
+				{Seq: 6, Entity: "tasks", EntityID: 3, Operation: models.ChangeUpsert, Data: json.RawMessage(`{"id":3}`)},
+				{Seq: 7, Entity: "tasks", EntityID: 4, Operation: models.ChangeDelete},
+			}, nil
+		},
+	}
+
+	handler := NewChangesHandler(mockChangeModel)
+	req, err := http.NewRequest("GET", "/changes?since=5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.GetChangesHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var response ChangesResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.NextSince != 7 || len(response.Changes) != 2 {
+		t.Errorf("handler returned unexpected body: %v", rr.Body.String())
+	}
+	if response.Changes[1].Data != nil {
+		t.Errorf("tombstone should not carry data: %s", response.Changes[1].Data)
+	}
+}
+
+func TestGetChangesHandlerLongPoll(t *testing.T) {
+	changesPollInterval = time.Millisecond
+	calls := 0
+	mockChangeModel := &models.MockChangeModel{
+		MockGetLatestSeq: func() (int64, error) {
+			return 2, nil
+		},
+		MockGetChangesSince: func(since int64, limit int) ([]*models.Change, error) {
+			calls++
+			if calls < 3 {
+				return []*models.Change{}, nil
+			}
+			return []*models.Change{{Seq: 3, Entity: "users", EntityID: 1, Operation: models.ChangeUpsert}}, nil
+		},
+	}
+
+	handler := NewChangesHandler(mockChangeModel)
+	req, err := http.NewRequest("GET", "/changes?since=2&wait=5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.GetChangesHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	if calls != 3 {
+		t.Errorf("expected the feed to be polled 3 times, got %v", calls)
+	}
+	expected := `{"changes":[{"seq":3,"entity":"users","entity_id":1,"operation":"upsert","changed_at":""}],"next_since":3}` + "\n"
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+}
+
+func TestGetChangesHandlerAheadOfFeed(t *testing.T) {
+	mockChangeModel := &models.MockChangeModel{
+		MockGetLatestSeq: func() (int64, error) {
+			return 10, nil
+		},
+	}
+
+	handler := NewChangesHandler(mockChangeModel)
+	req, err := http.NewRequest("GET", "/changes?since=11", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.GetChangesHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusGone {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusGone)
+	}
+}
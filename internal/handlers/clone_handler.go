@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type CloneHandler struct {
+	CloneModel   models.CloneModel
+	ProjectModel models.ProjectModel
+	TaskModel    models.TaskModel
+	Events       *events.Bus
+}
+
+func NewCloneHandler(cloneModel models.CloneModel, projectModel models.ProjectModel, taskModel models.TaskModel, bus *events.Bus) *CloneHandler {
+	return &CloneHandler{
+		CloneModel:   cloneModel,
+		ProjectModel: projectModel,
+		TaskModel:    taskModel,
+		Events:       bus,
+	}
+}
+
+// @Summary Clone a project
+// @Description Copies the project with all of its tasks, keeping their hierarchy, labels and due dates, in one transaction.
+// @Description Defaults: keep_assignees true, reset_status true, comments false. project_id and subtasks do not apply.
+// @Tags clone
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param options body models.CloneOptions false "Clone options"
+// @Success 201 {object} models.Project
+// @Router /projects/{id}/clone [post]
+// @Failure 400 {string} string "Invalid clone options"
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CloneHandler) CloneProjectHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	options, err := decodeCloneOptions(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := ch.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cloneID, err := ch.CloneModel.CloneProject(id, options)
+	if err != nil {
+		http.Error(writer, "could not clone project: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	clone, err := ch.ProjectModel.GetProjectByID(cloneID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ch.Events.Publish(events.Event{Type: events.ProjectCreated, Project: clone})
+	if tasks, err := ch.ProjectModel.GetProjectTasks(cloneID); err == nil {
+		for i := range tasks {
+			ch.Events.Publish(events.Event{Type: events.TaskCreated, Task: &tasks[i]})
+		}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(clone)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Clone a task
+// @Description Copies the task, with its subtasks unless subtasks is false, into project_id (default: the same project) in one transaction.
+// @Description Defaults: keep_assignees true, reset_status true, comments false, subtasks true.
+// @Tags clone
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param options body models.CloneOptions false "Clone options"
+// @Success 201 {object} models.Task
+// @Router /tasks/{id}/clone [post]
+// @Failure 400 {string} string "Invalid clone options"
+// @Failure 404 {string} string "Task or target project not found"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CloneHandler) CloneTaskHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	options, err := decodeCloneOptions(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	task, err := ch.TaskModel.GetTaskById(id)
+	if task == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if options.ProjectID != 0 {
+		project, err := ch.ProjectModel.GetProjectByID(options.ProjectID)
+		if project == nil {
+			http.Error(writer, "target project not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	cloneID, err := ch.CloneModel.CloneTask(id, options)
+	if err != nil {
+		http.Error(writer, "could not clone task: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	clone, err := ch.TaskModel.GetTaskById(cloneID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ch.Events.Publish(events.Event{Type: events.TaskCreated, Task: clone})
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(clone)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// decodeCloneOptions reads the optional request body over the default options.
+func decodeCloneOptions(request *http.Request) (*models.CloneOptions, error) {
+	options := models.DefaultCloneOptions()
+	err := json.NewDecoder(request.Body).Decode(options)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if options.Attachments {
+		return nil, errors.New("attachments cannot be cloned: the service does not store attachments")
+	}
+	return options, nil
+}
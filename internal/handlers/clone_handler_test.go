@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newCloneTaskRequest(t *testing.T, body string) *http.Request {
+	req, err := http.NewRequest("POST", "/tasks/5/clone", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mux.SetURLVars(req, map[string]string{"id": "5"})
+}
+
+func TestCloneTaskHandlerOptions(t *testing.T) {
+	var options *models.CloneOptions
+	mockCloneModel := &models.MockCloneModel{
+		MockCloneTask: func(id int, o *models.CloneOptions) (int, error) {
+			options = o
+			return 6, nil
+		},
+	}
+	mockTaskModel := &models.MockTaskModel{
+		MockGetTaskById: func(id int) (*models.Task, error) {
+			return &models.Task{ID: id, Title: "Review"}, nil
+		},
+	}
+	mockProjectModel := &models.MockProjectModel{
+		MockGetProjectByID: func(id int) (*models.Project, error) {
+			return &models.Project{ID: id}, nil
+		},
+	}
+	handler := NewCloneHandler(mockCloneModel, mockProjectModel, mockTaskModel, nil)
+
+	tests := []struct {
+		body     string
+		expected models.CloneOptions
+	}{
+		{"", models.CloneOptions{KeepAssignees: true, ResetStatus: true, Subtasks: true}},
+		{`{"project_id":3,"keep_assignees":false,"comments":true,"subtasks":false}`, models.CloneOptions{ProjectID: 3, ResetStatus: true, Comments: true}},
+	}
+	for _, test := range tests {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(handler.CloneTaskHandler).ServeHTTP(rr, newCloneTaskRequest(t, test.body))
+
+		if status := rr.Code; status != http.StatusCreated {
+			t.Fatalf("handler returned wrong status code: got %v want %v: %s", status, http.StatusCreated, rr.Body.String())
+		}
+		if *options != test.expected {
+			t.Errorf("unexpected options for %q: got %+v want %+v", test.body, *options, test.expected)
+		}
+	}
+}
+
+func TestCloneTaskHandlerRejectsAttachments(t *testing.T) {
+	handler := NewCloneHandler(&models.MockCloneModel{}, &models.MockProjectModel{}, &models.MockTaskModel{}, nil)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.CloneTaskHandler).ServeHTTP(rr, newCloneTaskRequest(t, `{"attachments":true}`))
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type CommentInput struct {
+	UserID int    `json:"user_id"`
+	Body   string `json:"body"`
+}
+
+type CommentHandler struct {
+	CommentModel models.CommentModel
+	TaskModel    models.TaskModel
+	Events       *events.Bus
+}
+
+func NewCommentHandler(commentModel models.CommentModel, taskModel models.TaskModel, bus *events.Bus) *CommentHandler {
+	return &CommentHandler{
+		CommentModel: commentModel,
+		TaskModel:    taskModel,
+		Events:       bus,
+	}
+}
+
+// @Summary Get task comments
+// @Tags comments
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {array} models.Comment
+// @Router /tasks/{id}/comments [get]
+// @Failure 404 {string} string "No comments found"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CommentHandler) GetTaskCommentsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	comments, err := ch.CommentModel.GetTaskComments(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(comments) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(comments)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Comment on a task
+// @Description Watchers and the assignee are notified, users mentioned as @email in the body get a mention notification.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param comment body CommentInput true "Comment"
+// @Success 201 {object} models.Comment
+// @Router /tasks/{id}/comments [post]
+// @Failure 400 {string} string "Missing required fields"
+// @Failure 404 {string} string "Task not found"
+// @Failure 500 {string} string "Internal server error"
+func (ch *CommentHandler) CreateTaskCommentHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input CommentInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if input.UserID == 0 || input.Body == "" {
+		http.Error(writer, "missing required fields", http.StatusBadRequest)
+		return
+	}
+	task, err := ch.TaskModel.GetTaskById(id)
+	if task == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	comment, err := ch.CommentModel.CreateComment(task.ID, input.UserID, input.Body)
+	if err != nil {
+		http.Error(writer, "could not create comment: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ch.Events.Publish(events.Event{Type: events.CommentCreated, Task: task, Comment: comment})
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(comment)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
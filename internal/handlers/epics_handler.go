@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type EpicInput struct {
+	Title       string `json:"title" example:"Billing"`
+	Description string `json:"description"`
+}
+
+type EpicHandler struct {
+	EpicModel          models.EpicModel
+	ProjectModel       models.ProjectModel
+	StatusHistoryModel models.StatusHistoryModel
+}
+
+func NewEpicHandler(epicModel models.EpicModel, projectModel models.ProjectModel, statusHistoryModel models.StatusHistoryModel) *EpicHandler {
+	return &EpicHandler{
+		EpicModel:          epicModel,
+		ProjectModel:       projectModel,
+		StatusHistoryModel: statusHistoryModel,
+	}
+}
+
+// @Summary Get the epics of a project
+// @Tags epics
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {array} models.Epic
+// @Router /projects/{id}/epics [get]
+// @Failure 404 {string} string "No epics found"
+// @Failure 500 {string} string "Internal server error"
+func (eh *EpicHandler) GetProjectEpicsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	epics, err := eh.EpicModel.GetProjectEpics(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(epics) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(epics)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Create an epic
+// @Tags epics
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param epic body EpicInput true "Epic"
+// @Success 201 {object} models.Epic
+// @Router /projects/{id}/epics [post]
+// @Failure 400 {string} string "Invalid epic"
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (eh *EpicHandler) CreateEpicHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input EpicInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	epic := &models.Epic{ProjectID: id, Title: input.Title, Description: input.Description}
+	if err := epic.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := eh.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	epicID, err := eh.EpicModel.CreateEpic(epic)
+	if err != nil {
+		http.Error(writer, "could not create epic: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, err := eh.EpicModel.GetEpicById(epicID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(created)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get epic by ID
+// @Tags epics
+// @Produce json
+// @Param id path int true "Epic ID"
+// @Success 200 {object} models.Epic
+// @Router /epics/{id} [get]
+// @Failure 404 {string} string "Epic not found"
+// @Failure 500 {string} string "Internal server error"
+func (eh *EpicHandler) GetEpicHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	epic, err := eh.EpicModel.GetEpicById(id)
+	if epic == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(epic)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Update an epic
+// @Tags epics
+// @Accept json
+// @Param id path int true "Epic ID"
+// @Param epic body EpicInput true "Epic"
+// @Success 200 {string} string "Epic updated"
+// @Router /epics/{id} [put]
+// @Failure 400 {string} string "Invalid epic"
+// @Failure 404 {string} string "Epic not found"
+// @Failure 500 {string} string "Internal server error"
+func (eh *EpicHandler) UpdateEpicHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input EpicInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	epic, err := eh.EpicModel.GetEpicById(id)
+	if epic == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	epic.Title, epic.Description = input.Title, input.Description
+	if err := epic.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = eh.EpicModel.UpdateEpic(epic)
+	if err != nil {
+		http.Error(writer, "could not update epic: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete an epic
+// @Description Its tasks are kept and no longer belong to an epic.
+// @Tags epics
+// @Param id path int true "Epic ID"
+// @Success 200 {string} string "Epic deleted"
+// @Router /epics/{id} [delete]
+// @Failure 404 {string} string "Epic not found"
+// @Failure 500 {string} string "Internal server error"
+func (eh *EpicHandler) DeleteEpicHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := eh.EpicModel.DeleteEpic(id)
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get the tasks of an epic
+// @Description Tasks join an epic through their epic_id.
+// @Tags epics
+// @Produce json
+// @Param id path int true "Epic ID"
+// @Success 200 {array} models.Task
+// @Router /epics/{id}/tasks [get]
+// @Failure 404 {string} string "No tasks found"
+// @Failure 500 {string} string "Internal server error"
+func (eh *EpicHandler) GetEpicTasksHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tasks, err := eh.EpicModel.GetEpicTasks(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(tasks) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(tasks)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get the progress of an epic
+// @Description Task counts by status, percent done, and the date the last task is projected to be done at the pace tasks
+// @Description were completed so far.
+// @Tags epics
+// @Produce json
+// @Param id path int true "Epic ID"
+// @Success 200 {object} metrics.Progress
+// @Router /epics/{id}/progress [get]
+// @Failure 404 {string} string "Epic not found"
+// @Failure 500 {string} string "Internal server error"
+func (eh *EpicHandler) GetEpicProgressHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	epic, err := eh.EpicModel.GetEpicById(id)
+	if epic == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := eh.EpicModel.GetEpicTasks(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	progress, err := taskProgress(eh.StatusHistoryModel, tasks)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(progress)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
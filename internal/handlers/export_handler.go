@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/export"
+	"ProjectManagementService/internal/models"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var taskExportColumns = []export.Column{
+	{Name: "id", Numeric: true},
+	{Name: "title"},
+	{Name: "description"},
+	{Name: "priority"},
+	{Name: "status"},
+	{Name: "responsible_user_id", Numeric: true},
+	{Name: "project_id", Numeric: true},
+	{Name: "creation_date"},
+	{Name: "start_date"},
+	{Name: "due_date"},
+	{Name: "completion_date"},
+	{Name: "parent_id", Numeric: true},
+	{Name: "labels"},
+	{Name: "sprint_id", Numeric: true},
+	{Name: "milestone_id", Numeric: true},
+	{Name: "epic_id", Numeric: true},
+	{Name: "story_points", Numeric: true},
+	{Name: "original_estimate_minutes", Numeric: true},
+	{Name: "remaining_estimate_minutes", Numeric: true},
+}
+
+// taskExportValues returns the values of task in taskExportColumns order.
+func taskExportValues(task *models.Task) []string {
+	storyPoints := ""
+	if task.StoryPoints != nil {
+		storyPoints = strconv.FormatFloat(*task.StoryPoints, 'f', -1, 64)
+	}
+	return []string{
+		strconv.Itoa(task.ID),
+		task.Title,
+		task.Description,
+		string(task.Priority),
+		string(task.Status),
+		exportID(task.ResponsibleUserID),
+		exportID(task.ProjectID),
+		csvDate(task.CreationDate),
+		csvDate(task.StartDate),
+		csvDate(task.DueDate),
+		csvDate(task.CompletionDate),
+		exportID(task.ParentID),
+		strings.Join(task.Labels, ";"),
+		exportID(task.SprintID),
+		exportID(task.MilestoneID),
+		exportID(task.EpicID),
+		storyPoints,
+		exportMinutes(task.OriginalEstimateMinutes),
+		exportMinutes(task.RemainingEstimateMinutes),
+	}
+}
+
+var projectExportColumns = []export.Column{
+	{Name: "id", Numeric: true},
+	{Name: "title"},
+	{Name: "description"},
+	{Name: "creation_date"},
+	{Name: "completion_date"},
+	{Name: "manager_id", Numeric: true},
+}
+
+func projectExportValues(project *models.Project) []string {
+	return []string{
+		strconv.Itoa(project.ID),
+		project.Title,
+		project.Description,
+		csvDate(project.CreationDate),
+		csvDate(project.CompletionDate),
+		exportID(project.ManagerID),
+	}
+}
+
+var userExportColumns = []export.Column{
+	{Name: "id", Numeric: true},
+	{Name: "name"},
+	{Name: "email"},
+	{Name: "registration_date"},
+	{Name: "role"},
+}
+
+func userExportValues(user *models.User) []string {
+	return []string{
+		strconv.Itoa(user.ID),
+		user.Name,
+		user.Email,
+		csvDate(user.RegistrationDate),
+		user.Role,
+	}
+}
+
+type ExportHandler struct {
+	ExportModel models.ExportModel
+}
+
+func NewExportHandler(exportModel models.ExportModel) *ExportHandler {
+	return &ExportHandler{ExportModel: exportModel}
+}
+
+// @Summary Export tasks
+// @Description Streams the tasks matching every filter given, all tasks without filters, as CSV or XLSX. The format
+// @Description parameter takes precedence over the Accept header; CSV is the default. Labels are separated by semicolons.
+// @Tags export
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv or xlsx"
+// @Param columns query string false "Comma separated columns, in order; all by default"
+// @Param title query string false "Title"
+// @Param status query string false "Status"
+// @Param priority query string false "Priority"
+// @Param assignee query int false "Responsible user ID"
+// @Param project query int false "Project ID"
+// @Success 200 {string} string "Tasks"
+// @Router /tasks/export [get]
+// @Failure 400 {string} string "Invalid format, columns or filters"
+// @Failure 406 {string} string "Unsupported Accept header"
+// @Failure 500 {string} string "Internal server error"
+func (eh *ExportHandler) ExportTasksHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	filter := models.TaskFilter{
+		Title:    query.Get("title"),
+		Status:   models.StatusEnum(query.Get("status")),
+		Priority: models.PriorityEnum(query.Get("priority")),
+	}
+	switch filter.Status {
+	case "", models.New, models.InProgress, models.Done:
+	default:
+		http.Error(writer, "Invalid status", http.StatusBadRequest)
+		return
+	}
+	switch filter.Priority {
+	case "", models.Low, models.Medium, models.High:
+	default:
+		http.Error(writer, "Invalid priority", http.StatusBadRequest)
+		return
+	}
+	var ok bool
+	if filter.ResponsibleUserID, ok = queryID(writer, request, "assignee"); !ok {
+		return
+	}
+	if filter.ProjectID, ok = queryID(writer, request, "project"); !ok {
+		return
+	}
+	writeExport(writer, request, "tasks", taskExportColumns, func(write func(values []string) error) error {
+		return eh.ExportModel.EachTask(filter, func(task *models.Task) error {
+			return write(taskExportValues(task))
+		})
+	})
+}
+
+// @Summary Export projects
+// @Description Streams the projects matching every filter given, all projects without filters, as CSV or XLSX. The
+// @Description format parameter takes precedence over the Accept header; CSV is the default.
+// @Tags export
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv or xlsx"
+// @Param columns query string false "Comma separated columns, in order; all by default"
+// @Param title query string false "Title"
+// @Param manager query int false "Manager ID"
+// @Success 200 {string} string "Projects"
+// @Router /projects/export [get]
+// @Failure 400 {string} string "Invalid format, columns or filters"
+// @Failure 406 {string} string "Unsupported Accept header"
+// @Failure 500 {string} string "Internal server error"
+func (eh *ExportHandler) ExportProjectsHandler(writer http.ResponseWriter, request *http.Request) {
+	filter := models.ProjectFilter{Title: request.URL.Query().Get("title")}
+	var ok bool
+	if filter.ManagerID, ok = queryID(writer, request, "manager"); !ok {
+		return
+	}
+	writeExport(writer, request, "projects", projectExportColumns, func(write func(values []string) error) error {
+		return eh.ExportModel.EachProject(filter, func(project *models.Project) error {
+			return write(projectExportValues(project))
+		})
+	})
+}
+
+// @Summary Export users
+// @Description Streams the users matching every filter given, all users without filters, as CSV or XLSX. The format
+// @Description parameter takes precedence over the Accept header; CSV is the default.
+// @Tags export
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv or xlsx"
+// @Param columns query string false "Comma separated columns, in order; all by default"
+// @Param email query string false "Email"
+// @Param name query string false "Name"
+// @Success 200 {string} string "Users"
+// @Router /users/export [get]
+// @Failure 400 {string} string "Invalid format or columns"
+// @Failure 406 {string} string "Unsupported Accept header"
+// @Failure 500 {string} string "Internal server error"
+func (eh *ExportHandler) ExportUsersHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	filter := models.UserFilter{Email: query.Get("email"), Name: query.Get("name")}
+	writeExport(writer, request, "users", userExportColumns, func(write func(values []string) error) error {
+		return eh.ExportModel.EachUser(filter, func(user *models.User) error {
+			return write(userExportValues(user))
+		})
+	})
+}
+
+// writeExport negotiates the format and the columns of an export, then
+// streams the rows each passes to write. The response starts with the first
+// row, so that errors before it are still reported with their status.
+func writeExport(writer http.ResponseWriter, request *http.Request, name string, columns []export.Column, each func(write func(values []string) error) error) {
+	var (
+		format export.Format
+		err    error
+	)
+	if value := request.URL.Query().Get("format"); value != "" {
+		format, err = export.ParseFormat(value)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		format, err = export.Negotiate(request.Header.Get("Accept"))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusNotAcceptable)
+			return
+		}
+	}
+	selected, indexes, err := selectColumns(columns, request.URL.Query().Get("columns"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var out export.Writer
+	start := func() error {
+		writer.Header().Set("Content-Type", format.ContentType())
+		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.%s\"", name, format))
+		writer.WriteHeader(http.StatusOK)
+		var err error
+		out, err = export.NewWriter(writer, format, strings.ToUpper(name[:1])+name[1:], selected)
+		return err
+	}
+	row := make([]string, len(indexes))
+	err = each(func(values []string) error {
+		if out == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		for i, index := range indexes {
+			row[i] = values[index]
+		}
+		return out.Write(row)
+	})
+	if err != nil && out == nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		// the status is sent already, the client gets a truncated file
+		log.Printf("export: could not export %s: %v\n", name, err)
+		return
+	}
+	if out == nil {
+		if err := start(); err != nil {
+			log.Printf("export: could not export %s: %v\n", name, err)
+			return
+		}
+	}
+	if err := out.Close(); err != nil {
+		log.Printf("export: could not export %s: %v\n", name, err)
+	}
+}
+
+// selectColumns returns the columns named in the comma separated list, in
+// its order, and their indexes in columns. An empty list selects them all.
+func selectColumns(columns []export.Column, list string) ([]export.Column, []int, error) {
+	if list == "" {
+		indexes := make([]int, len(columns))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return columns, indexes, nil
+	}
+	selected := make([]export.Column, 0)
+	indexes := make([]int, 0)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		index := -1
+		for i, column := range columns {
+			if column.Name == name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, nil, fmt.Errorf("unknown column %q", name)
+		}
+		selected = append(selected, columns[index])
+		indexes = append(indexes, index)
+	}
+	return selected, indexes, nil
+}
+
+// queryID reads an optional id query parameter, 0 when absent, and writes
+// the error response when it is not a number.
+func queryID(writer http.ResponseWriter, request *http.Request, name string) (int, bool) {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return 0, true
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		http.Error(writer, "invalid "+name, http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func exportID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+func exportMinutes(minutes *int) string {
+	if minutes == nil {
+		return ""
+	}
+	return strconv.Itoa(*minutes)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExportTasksHandler(t *testing.T) {
+	var filter models.TaskFilter
+	points := 3.5
+	mockExportModel := &models.MockExportModel{
+		MockEachTask: func(f models.TaskFilter, fn func(task *models.Task) error) error {
+			filter = f
+			if f.ProjectID == 9 {
+				return errors.New("connection refused")
+			}
+			for _, task := range []*models.Task{
+				{ID: 1, Title: "Design, then build", Status: models.Done, Labels: []string{"ui", "web"}, DueDate: "2021-09-10T00:00:00Z"},
+				{ID: 2, Title: "Ship", Status: models.New, StoryPoints: &points},
+			} {
+				if err := fn(task); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	handler := NewExportHandler(mockExportModel)
+
+	tests := []struct {
+		query       string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"columns=id,title,labels,due_date,story_points&status=new&project=2", "", http.StatusOK, "text/csv",
+			"id,title,labels,due_date,story_points\n1,\"Design, then build\",ui;web,2021-09-10,\n2,Ship,,,3.5\n"},
+		{"columns=title", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", ""},
+		{"columns=title&format=csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", http.StatusOK, "text/csv", "title\n\"Design, then build\"\nShip\n"},
+		{"columns=board_rank", "", http.StatusBadRequest, "", ""},
+		{"format=pdf", "", http.StatusBadRequest, "", ""},
+		{"status=closed", "", http.StatusBadRequest, "", ""},
+		{"", "application/json", http.StatusNotAcceptable, "", ""},
+		{"project=9", "", http.StatusInternalServerError, "", ""},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", "/tasks/export?"+test.query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+		rr := httptest.NewRecorder()
+		handler.ExportTasksHandler(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", test.query, status, test.status)
+			continue
+		}
+		if test.status != http.StatusOK {
+			continue
+		}
+		if contentType := rr.Header().Get("Content-Type"); contentType != test.contentType {
+			t.Errorf("%s: content type %s, want %s", test.query, contentType, test.contentType)
+		}
+		if test.body != "" && rr.Body.String() != test.body {
+			t.Errorf("%s: got %q, want %q", test.query, rr.Body.String(), test.body)
+		}
+		if test.query == tests[0].query && (filter.Status != models.New || filter.ProjectID != 2) {
+			t.Errorf("%s: unexpected filter %+v", test.query, filter)
+		}
+	}
+}
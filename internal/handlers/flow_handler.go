@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/metrics"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxFlowDays bounds the range of the flow report, which has a cumulative
+// flow point per day.
+const maxFlowDays = 366
+
+const (
+	defaultForecastTrials = 10000
+	maxForecastTrials     = 100000
+	maxForecastWeeks      = 52
+)
+
+type FlowHandler struct {
+	ProjectModel       models.ProjectModel
+	TaskModel          models.TaskModel
+	StatusHistoryModel models.StatusHistoryModel
+}
+
+func NewFlowHandler(projectModel models.ProjectModel, taskModel models.TaskModel, statusHistoryModel models.StatusHistoryModel) *FlowHandler {
+	return &FlowHandler{
+		ProjectModel:       projectModel,
+		TaskModel:          taskModel,
+		StatusHistoryModel: statusHistoryModel,
+	}
+}
+
+// @Summary Get the flow metrics of a project
+// @Description Cycle time (first move to in progress to done) and lead time (creation to done) of the tasks completed in
+// @Description the range, as percentiles in days and per task, the number of tasks completed per week, and the cumulative
+// @Description flow: tasks per status at the end of each day. Tasks reopened before the end of the range are not counted
+// @Description as completed. The range defaults to the last 12 weeks and cannot exceed 366 days.
+// @Tags reports
+// @Produce json
+// @Param project_id query int true "Project ID"
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD; defaults to today"
+// @Success 200 {object} metrics.Flow
+// @Router /reports/flow [get]
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (fh *FlowHandler) GetFlowHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	projectID, err := strconv.Atoi(query.Get("project_id"))
+	if err != nil {
+		http.Error(writer, "project_id is required", http.StatusBadRequest)
+		return
+	}
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if value := query.Get("to"); value != "" {
+		to, err = models.ParseDate(value)
+		if err != nil {
+			http.Error(writer, "invalid to, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+	from := models.WeekStart(to).AddDate(0, 0, -7*11)
+	if value := query.Get("from"); value != "" {
+		from, err = models.ParseDate(value)
+		if err != nil {
+			http.Error(writer, "invalid from, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+	if to.Before(from) {
+		http.Error(writer, "to cannot be before from", http.StatusBadRequest)
+		return
+	}
+	if to.Sub(from).Hours()/24 >= maxFlowDays {
+		http.Error(writer, "the range cannot exceed 366 days", http.StatusBadRequest)
+		return
+	}
+	project, err := fh.ProjectModel.GetProjectByID(projectID)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := fh.TaskModel.SearchTaskByProjectID(projectID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	taskIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	history, err := fh.StatusHistoryModel.GetStatusHistory(taskIDs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(metrics.NewFlow(projectID, tasks, history, from, to))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Forecast when a project will be done
+// @Description Runs a Monte Carlo simulation over the open tasks of the project: each simulated day, starting today,
+// @Description completes as many tasks as a day drawn at random from the last weeks of the project. Returns the dates by
+// @Description which all open tasks are done at 50, 85 and 95 percent confidence; dates more than five years ahead are
+// @Description left empty. The same seed, history and trials give the same dates; without a seed a random one is used and
+// @Description returned.
+// @Tags projects
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param history_weeks query int false "Weeks of throughput to sample, 1 to 52; defaults to 12"
+// @Param trials query int false "Number of simulations, 1 to 100000; defaults to 10000"
+// @Param seed query int false "Seed of the simulation"
+// @Success 200 {object} metrics.Forecast
+// @Router /projects/{id}/forecast [get]
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 404 {string} string "Project not found"
+// @Failure 409 {string} string "No tasks completed in the history"
+// @Failure 500 {string} string "Internal server error"
+func (fh *FlowHandler) GetForecastHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := request.URL.Query()
+	weeks := 12
+	if value := query.Get("history_weeks"); value != "" {
+		weeks, err = strconv.Atoi(value)
+		if err != nil || weeks < 1 || weeks > maxForecastWeeks {
+			http.Error(writer, "history_weeks must be between 1 and 52", http.StatusBadRequest)
+			return
+		}
+	}
+	trials := defaultForecastTrials
+	if value := query.Get("trials"); value != "" {
+		trials, err = strconv.Atoi(value)
+		if err != nil || trials < 1 || trials > maxForecastTrials {
+			http.Error(writer, "trials must be between 1 and 100000", http.StatusBadRequest)
+			return
+		}
+	}
+	seed := time.Now().UnixNano()
+	if value := query.Get("seed"); value != "" {
+		seed, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			http.Error(writer, "invalid seed", http.StatusBadRequest)
+			return
+		}
+	}
+	project, err := fh.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := fh.TaskModel.SearchTaskByProjectID(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	taskIDs := make([]int, 0, len(tasks))
+	remaining := 0
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+		if task.Status != models.Done {
+			remaining++
+		}
+	}
+	history, err := fh.StatusHistoryModel.GetStatusHistory(taskIDs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from, to := today.AddDate(0, 0, -7*weeks), today.AddDate(0, 0, -1)
+	throughput := metrics.DailyThroughput(tasks, history, from, to)
+	forecast := &metrics.Forecast{
+		ProjectID:      id,
+		RemainingTasks: remaining,
+		HistoryFrom:    from.Format(models.DateLayout),
+		HistoryTo:      to.Format(models.DateLayout),
+		Trials:         trials,
+		Seed:           seed,
+	}
+	for _, completed := range throughput {
+		forecast.Completed += completed
+	}
+	if forecast.Completed == 0 && remaining > 0 {
+		http.Error(writer, fmt.Sprintf("no tasks were completed in the last %d weeks", weeks), http.StatusConflict)
+		return
+	}
+	forecast.Dates = metrics.Simulate(throughput, remaining, today, trials, rand.New(rand.NewSource(seed)))
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(forecast)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/metrics"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetFlowHandler(t *testing.T) {
+	mockProjectModel := &models.MockProjectModel{
+		MockGetProjectByID: func(id int) (*models.Project, error) {
+			if id != 1 {
+				return nil, nil
+			}
+			return &models.Project{ID: id}, nil
+		},
+	}
+	mockTaskModel := &models.MockTaskModel{
+		MockSearchTaskByProjectID: func(projectID int) ([]*models.Task, error) {
+			return []*models.Task{{ID: 1, ProjectID: projectID, Status: models.New, CreationDate: "2021-09-01T00:00:00Z"}}, nil
+		},
+	}
+	handler := NewFlowHandler(mockProjectModel, mockTaskModel, &models.MockStatusHistoryModel{})
+
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{"project_id=1&from=2021-09-06&to=2021-09-19", http.StatusOK},
+		{"project_id=1&to=2021-09-19", http.StatusOK},
+		{"from=2021-09-06", http.StatusBadRequest},
+		{"project_id=1&from=2021-09-19&to=2021-09-06", http.StatusBadRequest},
+		{"project_id=1&from=2020-01-01&to=2021-09-06", http.StatusBadRequest},
+		{"project_id=2&from=2021-09-06&to=2021-09-19", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", "/reports/flow?"+test.query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler.GetFlowHandler(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", test.query, status, test.status)
+		}
+	}
+}
+
+func TestGetForecastHandler(t *testing.T) {
+	var history []*models.StatusChange
+	mockProjectModel := &models.MockProjectModel{
+		MockGetProjectByID: func(id int) (*models.Project, error) {
+			return &models.Project{ID: id}, nil
+		},
+	}
+	mockTaskModel := &models.MockTaskModel{
+		MockSearchTaskByProjectID: func(projectID int) ([]*models.Task, error) {
+			return []*models.Task{
+				{ID: 1, Status: models.Done},
+				{ID: 2, Status: models.Done},
+				{ID: 3, Status: models.New},
+				{ID: 4, Status: models.InProgress},
+			}, nil
+		},
+	}
+	mockStatusHistoryModel := &models.MockStatusHistoryModel{
+		MockGetStatusHistory: func(taskIDs []int) ([]*models.StatusChange, error) {
+			return history, nil
+		},
+	}
+	handler := NewFlowHandler(mockProjectModel, mockTaskModel, mockStatusHistoryModel)
+	forecast := func(query string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", "/projects/1/forecast?"+query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+		rr := httptest.NewRecorder()
+		handler.GetForecastHandler(rr, req)
+		return rr
+	}
+
+	if rr := forecast("seed=7"); rr.Code != http.StatusConflict {
+		t.Errorf("without throughput: got %v want %v", rr.Code, http.StatusConflict)
+	}
+	if rr := forecast("trials=0"); rr.Code != http.StatusBadRequest {
+		t.Errorf("with no trials: got %v want %v", rr.Code, http.StatusBadRequest)
+	}
+
+	now := time.Now().UTC()
+	history = []*models.StatusChange{
+		{TaskID: 1, Status: models.New, ChangedAt: now.AddDate(0, 0, -20)},
+		{TaskID: 1, Status: models.Done, ChangedAt: now.AddDate(0, 0, -3)},
+		{TaskID: 2, Status: models.New, ChangedAt: now.AddDate(0, 0, -20)},
+		{TaskID: 2, Status: models.Done, ChangedAt: now.AddDate(0, 0, -10)},
+	}
+	first, second := forecast("seed=7&trials=500"), forecast("seed=7&trials=500")
+	if first.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", first.Code, http.StatusOK)
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Errorf("the same seed gave different forecasts:\n%s\n%s", first.Body, second.Body)
+	}
+	var result metrics.Forecast
+	if err := json.NewDecoder(first.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	if result.RemainingTasks != 2 || result.Completed != 2 || result.Seed != 7 || len(result.Dates) != 3 {
+		t.Errorf("unexpected forecast %+v", result)
+	}
+}
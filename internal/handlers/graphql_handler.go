@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/graphql"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"net/http"
+)
+
+// maxGraphQLBytes bounds the size of GraphQL requests.
+const maxGraphQLBytes = 1 << 20
+
+type GraphQLHandler struct {
+	GraphModel models.GraphModel
+	Schema     *graphql.Schema
+}
+
+func NewGraphQLHandler(graphModel models.GraphModel) *GraphQLHandler {
+	return &GraphQLHandler{
+		GraphModel: graphModel,
+		Schema:     graphql.NewAPI(),
+	}
+}
+
+// @Summary Run a GraphQL query
+// @Description Queries users, projects and tasks with their relationships in one round trip, see GET /graphql/schema.
+// @Description Connections are pages in id order, of 20 nodes by default and 100 at most, that follow the after cursor.
+// @Description Related entities are loaded in batches, with a statement for each kind of entity and level of the query.
+// @Description Queries may be nested 10 levels deep and return 10000 objects at most, counting every page as full.
+// @Description Errors of fields are returned with the data; invalid queries get 400 and errors only.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body graphql.Request true "GraphQL request"
+// @Success 200 {object} graphql.Response
+// @Router /graphql [post]
+// @Failure 400 {object} graphql.Response "Invalid query"
+func (gh *GraphQLHandler) QueryHandler(writer http.ResponseWriter, request *http.Request) {
+	var query graphql.Request
+	if err := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxGraphQLBytes)).Decode(&query); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	gh.execute(writer, request, &query)
+}
+
+// @Summary Run a GraphQL query from the URL
+// @Description Runs a query as POST /graphql does, for clients that cache GET requests.
+// @Tags graphql
+// @Produce json
+// @Param query query string true "Query"
+// @Param operationName query string false "Operation to run, when the query has several"
+// @Param variables query string false "Variables as a JSON object"
+// @Success 200 {object} graphql.Response
+// @Router /graphql [get]
+// @Failure 400 {object} graphql.Response "Invalid query"
+func (gh *GraphQLHandler) GetQueryHandler(writer http.ResponseWriter, request *http.Request) {
+	params := request.URL.Query()
+	query := graphql.Request{Query: params.Get("query"), OperationName: params.Get("operationName")}
+	if variables := params.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &query.Variables); err != nil {
+			http.Error(writer, "invalid variables: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	gh.execute(writer, request, &query)
+}
+
+// @Summary Get the GraphQL schema
+// @Description Describes the types of the GraphQL API in the schema definition language.
+// @Tags graphql
+// @Produce text/plain
+// @Success 200 {string} string "Schema"
+// @Router /graphql/schema [get]
+func (gh *GraphQLHandler) GetSchemaHandler(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write([]byte(gh.Schema.SDL()))
+}
+
+func (gh *GraphQLHandler) execute(writer http.ResponseWriter, request *http.Request, query *graphql.Request) {
+	ctx := graphql.WithLoaders(request.Context(), graphql.NewLoaders(gh.GraphModel))
+	response := graphql.Execute(ctx, gh.Schema, query)
+	status := http.StatusOK
+	if !response.Executed() {
+		status = http.StatusBadRequest
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	err := json.NewEncoder(writer).Encode(response)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGraphQLHandler(t *testing.T) {
+	var loaded []int
+	mockGraphModel := &models.MockGraphModel{
+		MockGetTaskPage: func(filter models.TaskFilter, page models.Page) (*models.TaskPage, error) {
+			if filter.Status != models.New || page.First != 2 {
+				t.Errorf("unexpected filter %+v and page %+v", filter, page)
+			}
+			return &models.TaskPage{Tasks: []*models.Task{{ID: 1, Title: "Login", ResponsibleUserID: 2}, {ID: 2, Title: "Logout", ResponsibleUserID: 3}}, TotalCount: 2}, nil
+		},
+		MockGetUsersByIDs: func(ids []int) ([]*models.User, error) {
+			loaded = append(loaded, ids...)
+			return []*models.User{{ID: 2, Name: "Ann"}, {ID: 3, Name: "Bob"}}, nil
+		},
+	}
+	handler := NewGraphQLHandler(mockGraphModel)
+
+	tests := []struct {
+		method string
+		target string
+		body   string
+		status int
+		want   string
+	}{
+		{"POST", "/graphql", `{"query":"query($n: Int) { tasks(status: NEW, first: $n) { nodes { title assignee { name } } } }","variables":{"n":2}}`, http.StatusOK,
+			`{"data":{"tasks":{"nodes":[{"assignee":{"name":"Ann"},"title":"Login"},{"assignee":{"name":"Bob"},"title":"Logout"}]}}}`},
+		{"GET", "/graphql?" + url.Values{"query": {"{ tasks(first: 2, status: NEW) { totalCount } }"}}.Encode(), "", http.StatusOK,
+			`{"data":{"tasks":{"totalCount":2}}}`},
+		{"POST", "/graphql", `{"query":"{ tasks { count } }"}`, http.StatusBadRequest,
+			`{"errors":[{"message":"Cannot query field \"count\" on type \"TaskConnection\".","locations":[{"line":1,"column":11}]}]}`},
+		{"POST", "/graphql", `{"query":`, http.StatusBadRequest, "unexpected EOF"},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, test.target, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		if test.method == "GET" {
+			http.HandlerFunc(handler.GetQueryHandler).ServeHTTP(rr, req)
+		} else {
+			http.HandlerFunc(handler.QueryHandler).ServeHTTP(rr, req)
+		}
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", test.body, status, test.status)
+		}
+		if body := strings.TrimSpace(rr.Body.String()); body != test.want {
+			t.Errorf("%s: got %s, want %s", test.body, body, test.want)
+		}
+	}
+	if len(loaded) != 2 {
+		t.Errorf("assignees were loaded one by one: %v", loaded)
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/importer"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// maxImportBytes bounds the size of an imported file.
+const maxImportBytes = 10 << 20
+
+type ImportHandler struct {
+	Importer *importer.Importer
+}
+
+func NewImportHandler(importer *importer.Importer) *ImportHandler {
+	return &ImportHandler{Importer: importer}
+}
+
+// @Summary Import users, projects or tasks
+// @Description Creates users, projects or tasks from a CSV file with a header row or a JSON array of objects, all of them
+// @Description or none. Every row is validated first and the report lists the problems of each row; with dry_run nothing
+// @Description is written. Users are referred to by email (manager_email, assignee_email) and projects by title
+// @Description (project_title). Task labels are separated by semicolons. Imports do not send notifications.
+// @Tags import
+// @Accept text/csv
+// @Accept json
+// @Produce json
+// @Param kind path string true "users, projects or tasks"
+// @Param format query string false "csv or json; defaults to the Content-Type of the body"
+// @Param mapping query string false "Column renames as source:field pairs, such as Summary:title,Assignee:assignee_email"
+// @Param dry_run query bool false "Validate without writing"
+// @Success 200 {object} importer.Report "Dry run"
+// @Success 201 {object} importer.Report "Imported"
+// @Router /import/{kind} [post]
+// @Failure 400 {object} importer.Report "Invalid rows, nothing was imported"
+// @Failure 404 {string} string "Unknown kind"
+// @Failure 500 {string} string "Internal server error"
+func (ih *ImportHandler) ImportHandler(writer http.ResponseWriter, request *http.Request) {
+	kind := importer.Kind(mux.Vars(request)["kind"])
+	if _, ok := importer.Fields[kind]; !ok {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	query := request.URL.Query()
+	value := query.Get("format")
+	if value == "" {
+		mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/csv":
+			value = string(importer.CSV)
+		case "application/json":
+			value = string(importer.JSON)
+		}
+	}
+	format, err := importer.ParseFormat(value)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mapping, err := importer.ParseMapping(query.Get("mapping"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dryRun := false
+	if value := query.Get("dry_run"); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			http.Error(writer, "invalid dry_run", http.StatusBadRequest)
+			return
+		}
+	}
+	records, ignored, err := importer.Read(http.MaxBytesReader(writer, request.Body, maxImportBytes), format, kind, mapping)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := ih.Importer.Import(kind, records, dryRun)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	report.IgnoredColumns = ignored
+	writer.Header().Set("Content-Type", "application/json")
+	switch {
+	case report.Committed:
+		writer.WriteHeader(http.StatusCreated)
+	case dryRun:
+		writer.WriteHeader(http.StatusOK)
+	default:
+		writer.WriteHeader(http.StatusBadRequest)
+	}
+	err = json.NewEncoder(writer).Encode(report)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Import a Jira, Trello or GitHub export
+// @Description Creates projects, tasks and comments from a Jira XML or CSV export, a Trello board exported as JSON or a
+// @Description JSON array of GitHub issues, optionally with their comments. Importing the same source again updates what
+// @Description the earlier import created instead of creating it again. People are mapped to users by the users
+// @Description parameter, by earlier imports, or by their username when it is an email; the others are mapped to the
+// @Description default user, or nothing is imported and the report lists them. Imports do not send notifications.
+// @Tags import
+// @Accept json
+// @Accept xml
+// @Accept text/csv
+// @Produce json
+// @Param source path string true "jira, trello or github"
+// @Param users query string false "People to users as username:email pairs, such as jsmith:john@example.com"
+// @Param default_user query string false "Email of the user of unmapped people and of projects without owner"
+// @Param dry_run query bool false "Count what would be created and updated without writing"
+// @Success 200 {object} importer.SyncReport
+// @Router /import/{source} [post]
+// @Failure 400 {object} importer.SyncReport "Unmapped people, nothing was imported"
+// @Failure 500 {string} string "Internal server error"
+func (ih *ImportHandler) SyncHandler(writer http.ResponseWriter, request *http.Request) {
+	source, err := importer.ParseSource(mux.Vars(request)["source"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+	query := request.URL.Query()
+	users, err := importer.ParseMapping(query.Get("users"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	options := importer.SyncOptions{Users: users, DefaultUser: query.Get("default_user")}
+	if value := query.Get("dry_run"); value != "" {
+		options.DryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			http.Error(writer, "invalid dry_run", http.StatusBadRequest)
+			return
+		}
+	}
+	export, err := importer.ReadExport(http.MaxBytesReader(writer, request.Body, maxImportBytes), source)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := ih.Importer.Sync(export, options)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	if !report.Committed && !report.DryRun {
+		writer.WriteHeader(http.StatusBadRequest)
+	}
+	err = json.NewEncoder(writer).Encode(report)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
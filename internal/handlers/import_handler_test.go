@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/importer"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestImportHandler(t *testing.T) {
+	var imported []*models.User
+	mockImportModel := &models.MockImportModel{
+		MockGetUserIDsByEmail: func(emails []string) (map[string]int, error) {
+			return map[string]int{"ann@example.com": 1}, nil
+		},
+		MockImportUsers: func(users []*models.User) error {
+			for i, user := range users {
+				user.ID = 10 + i
+			}
+			imported = users
+			return nil
+		},
+	}
+	handler := NewImportHandler(importer.New(mockImportModel, nil))
+
+	tests := []struct {
+		kind        string
+		query       string
+		contentType string
+		body        string
+		status      int
+		committed   bool
+	}{
+		{"users", "mapping=Full%20name:name", "text/csv", "Full name,email,team\nBob,bob@example.com,web\n", http.StatusCreated, true},
+		{"users", "dry_run=true", "application/json", `[{"name": "Bob", "email": "bob@example.com"}]`, http.StatusOK, false},
+		{"users", "format=json", "", `[{"name": "Ann", "email": "ann@example.com"}]`, http.StatusBadRequest, false},
+		{"users", "", "application/json; charset=utf-8", `{"name": "Bob"}`, http.StatusBadRequest, false},
+		{"users", "", "application/xml", "<users/>", http.StatusBadRequest, false},
+		{"users", "mapping=name", "text/csv", "name\n", http.StatusBadRequest, false},
+		{"comments", "format=csv", "", "body\n", http.StatusNotFound, false},
+	}
+	for _, test := range tests {
+		imported = nil
+		req, err := http.NewRequest("POST", "/import/"+test.kind+"?"+test.query, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.contentType != "" {
+			req.Header.Set("Content-Type", test.contentType)
+		}
+		req = mux.SetURLVars(req, map[string]string{"kind": test.kind})
+		rr := httptest.NewRecorder()
+		handler.ImportHandler(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s?%s: handler returned wrong status code: got %v want %v", test.kind, test.query, status, test.status)
+			continue
+		}
+		if test.committed != (imported != nil) {
+			t.Errorf("%s?%s: committed %v, want %v", test.kind, test.query, imported != nil, test.committed)
+		}
+	}
+
+	req, err := http.NewRequest("POST", "/import/users?mapping=Full%20name:name", strings.NewReader("Full name,email,team\nBob,bob@example.com,web\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "text/csv")
+	req = mux.SetURLVars(req, map[string]string{"kind": "users"})
+	rr := httptest.NewRecorder()
+	handler.ImportHandler(rr, req)
+	var report importer.Report
+	if err := json.NewDecoder(rr.Body).Decode(&report); err != nil {
+		t.Fatal(err)
+	}
+	if !report.Committed || report.Rows[0].ID != 10 || len(report.IgnoredColumns) != 1 || report.IgnoredColumns[0] != "team" {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestSyncHandler(t *testing.T) {
+	var synced []*models.ExternalProject
+	handler := NewImportHandler(importer.New(&models.MockImportModel{
+		MockGetUserIDsByEmail: func(emails []string) (map[string]int, error) {
+			return map[string]int{"ann@example.com": 1}, nil
+		},
+	}, &models.MockExternalModel{
+		MockGetExternalIDs: func(source, kind string, externalIDs []string) (map[string]int, error) {
+			return map[string]int{}, nil
+		},
+		MockSyncExternal: func(source string, users map[string]int, projects []*models.ExternalProject) (*models.ExternalSync, error) {
+			synced = projects
+			return &models.ExternalSync{Projects: models.SyncCount{Created: 1}, Tasks: models.SyncCount{Created: 1}}, nil
+		},
+	}))
+	issues := `[{"id": 501, "number": 7, "title": "Crash", "state": "open", "created_at": "2021-02-01T10:00:00Z", "assignee": {"login": "octocat"}, "repository_url": "https://api.github.com/repos/acme/site"}]`
+
+	tests := []struct {
+		source string
+		query  string
+		body   string
+		status int
+		synced bool
+	}{
+		{"github", "users=octocat:ann@example.com,acme:ann@example.com", issues, http.StatusOK, true},
+		{"github", "default_user=ann@example.com&dry_run=true", issues, http.StatusOK, false},
+		{"github", "users=octocat:ann@example.com", issues, http.StatusBadRequest, false},
+		{"github", "", "{", http.StatusBadRequest, false},
+		{"asana", "", issues, http.StatusNotFound, false},
+	}
+	for _, test := range tests {
+		synced = nil
+		req, err := http.NewRequest("POST", "/import/"+test.source+"?"+test.query, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"source": test.source})
+		rr := httptest.NewRecorder()
+		handler.SyncHandler(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s?%s: handler returned wrong status code: got %v want %v", test.source, test.query, status, test.status)
+			continue
+		}
+		if test.synced != (synced != nil) {
+			t.Errorf("%s?%s: synced %v, want %v", test.source, test.query, synced != nil, test.synced)
+		}
+		if test.status == http.StatusBadRequest && test.body == issues {
+			var report importer.SyncReport
+			if err := json.NewDecoder(rr.Body).Decode(&report); err != nil || len(report.UnmappedUsers) != 1 || report.UnmappedUsers[0] != "acme" {
+				t.Errorf("unexpected report %+v", report)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"net/http"
+)
+
+const defaultJobRunsLimit = 50
+
+type JobHandler struct {
+	JobRunModel models.JobRunModel
+}
+
+func NewJobHandler(jobRunModel models.JobRunModel) *JobHandler {
+	return &JobHandler{
+		JobRunModel: jobRunModel,
+	}
+}
+
+// @Summary Get background job run history
+// @Tags jobs
+// @Produce json
+// @Param job query string false "Job name"
+// @Param limit query int false "Maximum number of runs to return (default 50)"
+// @Success 200 {array} models.JobRun
+// @Router /jobs/runs [get]
+// @Failure 400 {string} string "Invalid limit parameter"
+// @Failure 404 {string} string "No runs found"
+// @Failure 500 {string} string "Internal server error"
+func (jh *JobHandler) GetJobRunsHandler(writer http.ResponseWriter, request *http.Request) {
+	limit, err := queryInt64(request, "limit", defaultJobRunsLimit)
+	if err != nil || limit <= 0 {
+		http.Error(writer, "invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+	runs, err := jh.JobRunModel.GetRuns(request.URL.Query().Get("job"), int(limit))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(runs) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(runs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
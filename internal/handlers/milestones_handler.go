@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/metrics"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type MilestoneInput struct {
+	Title       string `json:"title" example:"Public beta"`
+	Description string `json:"description"`
+	TargetDate  string `json:"target_date" example:"2021-11-30"`
+}
+
+type MilestoneHandler struct {
+	MilestoneModel     models.MilestoneModel
+	ProjectModel       models.ProjectModel
+	StatusHistoryModel models.StatusHistoryModel
+}
+
+func NewMilestoneHandler(milestoneModel models.MilestoneModel, projectModel models.ProjectModel, statusHistoryModel models.StatusHistoryModel) *MilestoneHandler {
+	return &MilestoneHandler{
+		MilestoneModel:     milestoneModel,
+		ProjectModel:       projectModel,
+		StatusHistoryModel: statusHistoryModel,
+	}
+}
+
+// @Summary Get the milestones of a project
+// @Tags milestones
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {array} models.Milestone
+// @Router /projects/{id}/milestones [get]
+// @Failure 404 {string} string "No milestones found"
+// @Failure 500 {string} string "Internal server error"
+func (mh *MilestoneHandler) GetProjectMilestonesHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	milestones, err := mh.MilestoneModel.GetProjectMilestones(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(milestones) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(milestones)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Create a milestone
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param milestone body MilestoneInput true "Milestone"
+// @Success 201 {object} models.Milestone
+// @Router /projects/{id}/milestones [post]
+// @Failure 400 {string} string "Invalid milestone"
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (mh *MilestoneHandler) CreateMilestoneHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input MilestoneInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	milestone := &models.Milestone{ProjectID: id, Title: input.Title, Description: input.Description, TargetDate: input.TargetDate}
+	if err := milestone.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := mh.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	milestoneID, err := mh.MilestoneModel.CreateMilestone(milestone)
+	if err != nil {
+		http.Error(writer, "could not create milestone: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, err := mh.MilestoneModel.GetMilestoneById(milestoneID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(created)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get milestone by ID
+// @Tags milestones
+// @Produce json
+// @Param id path int true "Milestone ID"
+// @Success 200 {object} models.Milestone
+// @Router /milestones/{id} [get]
+// @Failure 404 {string} string "Milestone not found"
+// @Failure 500 {string} string "Internal server error"
+func (mh *MilestoneHandler) GetMilestoneHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	milestone, err := mh.MilestoneModel.GetMilestoneById(id)
+	if milestone == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(milestone)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Update a milestone
+// @Tags milestones
+// @Accept json
+// @Param id path int true "Milestone ID"
+// @Param milestone body MilestoneInput true "Milestone"
+// @Success 200 {string} string "Milestone updated"
+// @Router /milestones/{id} [put]
+// @Failure 400 {string} string "Invalid milestone"
+// @Failure 404 {string} string "Milestone not found"
+// @Failure 500 {string} string "Internal server error"
+func (mh *MilestoneHandler) UpdateMilestoneHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input MilestoneInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	milestone, err := mh.MilestoneModel.GetMilestoneById(id)
+	if milestone == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	milestone.Title, milestone.Description, milestone.TargetDate = input.Title, input.Description, input.TargetDate
+	if err := milestone.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = mh.MilestoneModel.UpdateMilestone(milestone)
+	if err != nil {
+		http.Error(writer, "could not update milestone: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete a milestone
+// @Description Its tasks are kept and no longer belong to a milestone.
+// @Tags milestones
+// @Param id path int true "Milestone ID"
+// @Success 200 {string} string "Milestone deleted"
+// @Router /milestones/{id} [delete]
+// @Failure 404 {string} string "Milestone not found"
+// @Failure 500 {string} string "Internal server error"
+func (mh *MilestoneHandler) DeleteMilestoneHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := mh.MilestoneModel.DeleteMilestone(id)
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get the tasks of a milestone
+// @Description Tasks join a milestone through their milestone_id.
+// @Tags milestones
+// @Produce json
+// @Param id path int true "Milestone ID"
+// @Success 200 {array} models.Task
+// @Router /milestones/{id}/tasks [get]
+// @Failure 404 {string} string "No tasks found"
+// @Failure 500 {string} string "Internal server error"
+func (mh *MilestoneHandler) GetMilestoneTasksHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tasks, err := mh.MilestoneModel.GetMilestoneTasks(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(tasks) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(tasks)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get the progress of a milestone
+// @Description Task counts by status, percent done, and the date the last task is projected to be done at the pace tasks
+// @Description were completed so far, compared with the target date.
+// @Tags milestones
+// @Produce json
+// @Param id path int true "Milestone ID"
+// @Success 200 {object} metrics.Progress
+// @Router /milestones/{id}/progress [get]
+// @Failure 404 {string} string "Milestone not found"
+// @Failure 500 {string} string "Internal server error"
+func (mh *MilestoneHandler) GetMilestoneProgressHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	milestone, err := mh.MilestoneModel.GetMilestoneById(id)
+	if milestone == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := mh.MilestoneModel.GetMilestoneTasks(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	progress, err := taskProgress(mh.StatusHistoryModel, tasks)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(progress.WithTarget(milestone.TargetDate))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// taskProgress loads the status history of tasks and summarizes their progress.
+func taskProgress(statusHistoryModel models.StatusHistoryModel, tasks []*models.Task) (*metrics.Progress, error) {
+	taskIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	history, err := statusHistoryModel.GetStatusHistory(taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	return metrics.NewProgress(tasks, history, time.Now().UTC()), nil
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type UnreadCountResponse struct {
+	UserID int `json:"user_id"`
+	Unread int `json:"unread"`
+}
+
+type WatcherInput struct {
+	UserID int `json:"user_id"`
+}
+
+type NotificationHandler struct {
+	NotificationModel models.NotificationModel
+}
+
+func NewNotificationHandler(notificationModel models.NotificationModel) *NotificationHandler {
+	return &NotificationHandler{
+		NotificationModel: notificationModel,
+	}
+}
+
+// @Summary Get user notifications
+// @Tags notifications
+// @Produce json
+// @Param user_id query int true "User ID"
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {array} models.Notification
+// @Router /notifications [get]
+// @Failure 400 {string} string "Missing user_id parameter"
+// @Failure 404 {string} string "No notifications found"
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) GetNotificationsHandler(writer http.ResponseWriter, request *http.Request) {
+	userID, err := strconv.Atoi(request.URL.Query().Get("user_id"))
+	if err != nil {
+		http.Error(writer, "missing user_id parameter", http.StatusBadRequest)
+		return
+	}
+	unreadOnly := request.URL.Query().Get("unread") == "true"
+	notifications, err := nh.NotificationModel.GetUserNotifications(userID, unreadOnly)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(notifications) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(notifications)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get unread notifications count
+// @Tags notifications
+// @Produce json
+// @Param user_id query int true "User ID"
+// @Success 200 {object} UnreadCountResponse
+// @Router /notifications/unread-count [get]
+// @Failure 400 {string} string "Missing user_id parameter"
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) GetUnreadCountHandler(writer http.ResponseWriter, request *http.Request) {
+	userID, err := strconv.Atoi(request.URL.Query().Get("user_id"))
+	if err != nil {
+		http.Error(writer, "missing user_id parameter", http.StatusBadRequest)
+		return
+	}
+	count, err := nh.NotificationModel.GetUnreadCount(userID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(UnreadCountResponse{UserID: userID, Unread: count})
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Mark notification as read
+// @Tags notifications
+// @Param id path int true "Notification ID"
+// @Success 200 {string} string "Notification marked as read"
+// @Router /notifications/{id}/read [put]
+// @Failure 404 {string} string "Notification not found"
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) MarkReadHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	updatedId, err := nh.NotificationModel.MarkRead(id)
+	if updatedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Mark all notifications of a user as read
+// @Tags notifications
+// @Param user_id query int true "User ID"
+// @Success 200 {string} string "Notifications marked as read"
+// @Router /notifications/read-all [put]
+// @Failure 400 {string} string "Missing user_id parameter"
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) MarkAllReadHandler(writer http.ResponseWriter, request *http.Request) {
+	userID, err := strconv.Atoi(request.URL.Query().Get("user_id"))
+	if err != nil {
+		http.Error(writer, "missing user_id parameter", http.StatusBadRequest)
+		return
+	}
+	_, err = nh.NotificationModel.MarkAllRead(userID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get notification preferences
+// @Tags notifications
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} models.NotificationPreferences
+// @Router /users/{id}/notification-preferences [get]
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) GetPreferencesHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	preferences, err := nh.NotificationModel.GetPreferences(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(preferences)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Update notification preferences
+// @Tags notifications
+// @Accept json
+// @Param id path int true "User ID"
+// @Param preferences body models.NotificationPreferences true "Preferences"
+// @Success 200 {string} string "Preferences updated"
+// @Router /users/{id}/notification-preferences [put]
+// @Failure 400 {string} string "Could not decode preferences"
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) UpdatePreferencesHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	preferences, err := nh.NotificationModel.GetPreferences(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = json.NewDecoder(request.Body).Decode(preferences)
+	if err != nil {
+		http.Error(writer, "could not decode preferences: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	preferences.UserID = id
+	err = nh.NotificationModel.UpdatePreferences(preferences)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get task watchers
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {array} int
+// @Router /tasks/{id}/watchers [get]
+// @Failure 404 {string} string "No watchers found"
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) GetTaskWatchersHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	watchers, err := nh.NotificationModel.GetTaskWatchers(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(watchers) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(watchers)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Watch a task
+// @Tags notifications
+// @Accept json
+// @Param id path int true "Task ID"
+// @Param watcher body WatcherInput true "Watcher"
+// @Success 201 {string} string "Watcher added"
+// @Router /tasks/{id}/watchers [post]
+// @Failure 400 {string} string "Missing required fields"
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) AddTaskWatcherHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input WatcherInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil || input.UserID == 0 {
+		http.Error(writer, "missing required fields", http.StatusBadRequest)
+		return
+	}
+	err = nh.NotificationModel.AddTaskWatcher(id, input.UserID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusCreated)
+}
+
+// @Summary Stop watching a task
+// @Tags notifications
+// @Param id path int true "Task ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {string} string "Watcher removed"
+// @Router /tasks/{id}/watchers/{user_id} [delete]
+// @Failure 404 {string} string "Watcher not found"
+// @Failure 500 {string} string "Internal server error"
+func (nh *NotificationHandler) RemoveTaskWatcherHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	userID, err := strconv.Atoi(vars["user_id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	removedId, err := nh.NotificationModel.RemoveTaskWatcher(id, userID)
+	if removedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"ProjectManagementService/internal/events"
 	"ProjectManagementService/internal/models"
 	"encoding/json"
 	"github.com/gorilla/mux"
@@ -16,11 +17,13 @@ type ProjectInput struct {
 
 type ProjectHandler struct {
 	ProjectModel models.ProjectModel
+	Events       *events.Bus
 }
 
-func NewProjectHandler(projectModel models.ProjectModel) *ProjectHandler {
+func NewProjectHandler(projectModel models.ProjectModel, bus *events.Bus) *ProjectHandler {
 	return &ProjectHandler{
 		ProjectModel: projectModel,
+		Events:       bus,
 	}
 
 }
@@ -68,11 +71,15 @@ func (ph *ProjectHandler) CreateProjectHandler(writer http.ResponseWriter, reque
 		http.Error(writer, "could not decode project: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	err = ph.ProjectModel.CreateProject(project.Title, project.Description, project.ManagerID)
+	id, err := ph.ProjectModel.CreateProject(project.Title, project.Description, project.ManagerID)
 	if err != nil {
 		http.Error(writer, "could not create project: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	created, err := ph.ProjectModel.GetProjectByID(id)
+	if err == nil {
+		ph.Events.Publish(events.Event{Type: events.ProjectCreated, Project: created})
+	}
 	writer.WriteHeader(http.StatusCreated)
 }
 
@@ -136,6 +143,7 @@ func (ph *ProjectHandler) UpdateProjectHandler(writer http.ResponseWriter, reque
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	previous := *project
 	err = json.NewDecoder(request.Body).Decode(&project)
 	if err != nil {
 		http.Error(writer, err.Error(), http.StatusBadRequest)
@@ -146,6 +154,7 @@ func (ph *ProjectHandler) UpdateProjectHandler(writer http.ResponseWriter, reque
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	ph.Events.Publish(events.Event{Type: events.ProjectUpdated, Project: project, PreviousProject: &previous})
 	writer.WriteHeader(http.StatusOK)
 }
 
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"ProjectManagementService/internal/recurrence"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RecurrenceInput struct {
+	RRule     string `json:"rrule" example:"FREQ=WEEKLY;BYDAY=MO"`
+	StartDate string `json:"start_date" example:"2021-09-06"`
+}
+
+type RecurrenceHandler struct {
+	RecurrenceModel models.RecurrenceModel
+	TaskModel       models.TaskModel
+}
+
+func NewRecurrenceHandler(recurrenceModel models.RecurrenceModel, taskModel models.TaskModel) *RecurrenceHandler {
+	return &RecurrenceHandler{
+		RecurrenceModel: recurrenceModel,
+		TaskModel:       taskModel,
+	}
+}
+
+// @Summary Get the recurrence of a task
+// @Tags recurrence
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} models.Recurrence
+// @Router /tasks/{id}/recurrence [get]
+// @Failure 404 {string} string "Task is not recurring"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RecurrenceHandler) GetRecurrenceHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	series, err := rh.RecurrenceModel.GetRecurrenceByTaskID(id)
+	if series == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(series)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Make a task recurring
+// @Description The task becomes the first occurrence. The rule is an RFC 5545 RRULE using FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL, BYDAY and BYMONTHDAY.
+// @Description start_date defaults to the task's due date, or today.
+// @Tags recurrence
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param recurrence body RecurrenceInput true "Recurrence rule"
+// @Success 201 {object} models.Recurrence
+// @Router /tasks/{id}/recurrence [post]
+// @Failure 400 {string} string "Invalid rule or start date"
+// @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "Task is already recurring"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RecurrenceHandler) CreateRecurrenceHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input RecurrenceInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	task, err := rh.TaskModel.GetTaskById(id)
+	if task == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if task.RecurrenceID != 0 {
+		http.Error(writer, "task is already recurring", http.StatusConflict)
+		return
+	}
+	startDate := input.StartDate
+	if startDate == "" {
+		startDate = task.DueDate
+	}
+	if startDate == "" {
+		startDate = time.Now().Format(models.DateLayout)
+	}
+	series := &models.Recurrence{RRule: input.RRule, StartDate: startDate, Occurrences: 1}
+	series.NextDate, err = recurrence.NextDate(series, startDate, 1)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	seriesID, err := rh.RecurrenceModel.CreateRecurrence(task.ID, series)
+	if err != nil {
+		http.Error(writer, "could not create recurrence: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, err := rh.RecurrenceModel.GetRecurrenceById(seriesID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(created)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Edit the recurrence of a task
+// @Description Changes apply to occurrences that have not been generated yet.
+// @Tags recurrence
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param recurrence body RecurrenceInput true "Recurrence rule"
+// @Success 200 {object} models.Recurrence
+// @Router /tasks/{id}/recurrence [put]
+// @Failure 400 {string} string "Invalid rule or start date"
+// @Failure 404 {string} string "Task is not recurring"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RecurrenceHandler) UpdateRecurrenceHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	series, err := rh.RecurrenceModel.GetRecurrenceByTaskID(id)
+	if series == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	input := RecurrenceInput{RRule: series.RRule, StartDate: series.StartDate}
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	series.RRule, series.StartDate = input.RRule, input.StartDate
+	// the next occurrence follows the latest one that was generated
+	after := series.StartDate
+	latest, err := rh.RecurrenceModel.GetLatestOccurrence(series.ID)
+	if err == nil && latest.DueDate != "" {
+		after = latest.DueDate
+	}
+	series.NextDate, err = recurrence.NextDate(series, after, series.Occurrences)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = rh.RecurrenceModel.UpdateRecurrence(series)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(series)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Stop the recurrence of a task
+// @Description No further occurrences are generated; existing tasks are kept.
+// @Tags recurrence
+// @Param id path int true "Task ID"
+// @Success 200 {string} string "Recurrence stopped"
+// @Router /tasks/{id}/recurrence [delete]
+// @Failure 404 {string} string "Task is not recurring"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RecurrenceHandler) DeleteRecurrenceHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	series, err := rh.RecurrenceModel.GetRecurrenceByTaskID(id)
+	if series == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, err = rh.RecurrenceModel.DeleteRecurrence(series.ID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
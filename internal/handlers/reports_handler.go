@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/metrics"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type ReportHandler struct {
+	ReportModel models.ReportModel
+	Thresholds  metrics.HealthThresholds
+}
+
+func NewReportHandler(reportModel models.ReportModel) *ReportHandler {
+	return &ReportHandler{
+		ReportModel: reportModel,
+		Thresholds:  metrics.DefaultHealthThresholds,
+	}
+}
+
+// @Summary Get the portfolio report
+// @Description Per project: task counts by status and priority, percent complete, overdue open tasks, average age of open
+// @Description tasks and a red/amber/green health. Thresholds default to 10/25 percent of open tasks overdue and 30/60 days
+// @Description of average age for amber/red, and can be set per request.
+// @Tags reports
+// @Produce json
+// @Param manager_id query int false "Only the projects of this manager"
+// @Param overdue_amber query number false "Percent of open tasks overdue that turns a project amber"
+// @Param overdue_red query number false "Percent of open tasks overdue that turns a project red"
+// @Param age_amber query number false "Average age of open tasks, in days, that turns a project amber"
+// @Param age_red query number false "Average age of open tasks, in days, that turns a project red"
+// @Success 200 {object} metrics.Portfolio
+// @Router /reports/projects [get]
+// @Failure 400 {string} string "Invalid thresholds"
+// @Failure 500 {string} string "Internal server error"
+func (rh *ReportHandler) GetProjectReportsHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	managerID := 0
+	if value := query.Get("manager_id"); value != "" {
+		var err error
+		managerID, err = strconv.Atoi(value)
+		if err != nil {
+			http.Error(writer, "invalid manager_id", http.StatusBadRequest)
+			return
+		}
+	}
+	thresholds := rh.Thresholds
+	for name, threshold := range map[string]*float64{
+		"overdue_amber": &thresholds.OverdueAmber,
+		"overdue_red":   &thresholds.OverdueRed,
+		"age_amber":     &thresholds.AgeAmber,
+		"age_red":       &thresholds.AgeRed,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			http.Error(writer, "invalid "+name, http.StatusBadRequest)
+			return
+		}
+		*threshold = parsed
+	}
+	if err := thresholds.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reports, err := rh.ReportModel.GetProjectReports(managerID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(metrics.NewPortfolio(reports, thresholds))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+const defaultRuleRunsLimit = 50
+
+type RuleInput struct {
+	Name       string                 `json:"name" example:"Close finished tasks"`
+	ProjectID  int                    `json:"project_id"`
+	Trigger    string                 `json:"trigger" example:"task_status_changed"`
+	Conditions []models.RuleCondition `json:"conditions"`
+	Actions    []models.RuleAction    `json:"actions"`
+	Enabled    bool                   `json:"enabled" example:"true"`
+}
+
+type RuleHandler struct {
+	RuleModel models.RuleModel
+}
+
+func NewRuleHandler(ruleModel models.RuleModel) *RuleHandler {
+	return &RuleHandler{
+		RuleModel: ruleModel,
+	}
+}
+
+// @Summary Get all automation rules
+// @Tags automation
+// @Produce json
+// @Success 200 {array} models.Rule
+// @Router /automation/rules [get]
+// @Failure 404 {string} string "No rules found"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RuleHandler) GetAllRulesHandler(writer http.ResponseWriter, request *http.Request) {
+	rules, err := rh.RuleModel.GetRules()
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(rules) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(rules)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Create an automation rule
+// @Description Rules run when their trigger fires for a task of the project (or any project if project_id is 0) and all conditions match.
+// @Description Triggers: task_created, task_updated, task_status_changed, task_priority_changed, task_assignee_changed, project_tasks_done.
+// @Description Actions: set_status, set_priority, assign_user, set_completion_date, notify_manager, notify_assignee, notify_user, close_project.
+// @Tags automation
+// @Accept json
+// @Produce json
+// @Param rule body RuleInput true "Rule"
+// @Success 201 {object} models.Rule
+// @Router /automation/rules [post]
+// @Failure 400 {string} string "Invalid rule"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RuleHandler) CreateRuleHandler(writer http.ResponseWriter, request *http.Request) {
+	rule := &models.Rule{Enabled: true}
+	err := json.NewDecoder(request.Body).Decode(rule)
+	if err != nil {
+		http.Error(writer, "could not decode rule: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := rule.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := rh.RuleModel.CreateRule(rule)
+	if err != nil {
+		http.Error(writer, "could not create rule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, err := rh.RuleModel.GetRuleById(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(created)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get automation rule by ID
+// @Tags automation
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {object} models.Rule
+// @Router /automation/rules/{id} [get]
+// @Failure 404 {string} string "Rule not found"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RuleHandler) GetRuleHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rule, err := rh.RuleModel.GetRuleById(id)
+	if rule == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(rule)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Update an automation rule
+// @Tags automation
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param rule body RuleInput true "Rule"
+// @Success 200 {string} string "Rule updated"
+// @Router /automation/rules/{id} [put]
+// @Failure 400 {string} string "Invalid rule"
+// @Failure 404 {string} string "Rule not found"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RuleHandler) UpdateRuleHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rule, err := rh.RuleModel.GetRuleById(id)
+	if rule == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = json.NewDecoder(request.Body).Decode(rule)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rule.ID = id
+	if err := rule.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = rh.RuleModel.UpdateRule(rule)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete an automation rule
+// @Tags automation
+// @Param id path int true "Rule ID"
+// @Success 200 {string} string "Rule deleted"
+// @Router /automation/rules/{id} [delete]
+// @Failure 404 {string} string "Rule not found"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RuleHandler) DeleteRuleHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := rh.RuleModel.DeleteRule(id)
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get the execution log of an automation rule
+// @Description Newest first. Runs are skipped when a rule would fire twice in one chain or the chain is too deep.
+// @Tags automation
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param limit query int false "Maximum number of runs to return (default 50)"
+// @Success 200 {array} models.RuleRun
+// @Router /automation/rules/{id}/runs [get]
+// @Failure 400 {string} string "Invalid limit parameter"
+// @Failure 404 {string} string "No runs found"
+// @Failure 500 {string} string "Internal server error"
+func (rh *RuleHandler) GetRuleRunsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := queryInt64(request, "limit", defaultRuleRunsLimit)
+	if err != nil || limit <= 0 {
+		http.Error(writer, "invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+	runs, err := rh.RuleModel.GetRuleRuns(id, int(limit))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(runs) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(runs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,509 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/metrics"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type SprintInput struct {
+	Name      string `json:"name" example:"Sprint 12"`
+	Goal      string `json:"goal" example:"Ship the billing page"`
+	StartDate string `json:"start_date" example:"2021-09-06"`
+	EndDate   string `json:"end_date" example:"2021-09-17"`
+}
+
+type SprintTasksInput struct {
+	TaskIDs []int `json:"task_ids"`
+}
+
+type CompleteSprintInput struct {
+	// NextSprintID receives the unfinished tasks; defaults to the next planned
+	// sprint of the project, or the backlog when there is none.
+	NextSprintID int `json:"next_sprint_id"`
+}
+
+type SprintCompletion struct {
+	Sprint       *models.Sprint `json:"sprint"`
+	MovedTasks   int            `json:"moved_tasks"`
+	NextSprintID int            `json:"next_sprint_id"`
+}
+
+type SprintHandler struct {
+	SprintModel        models.SprintModel
+	ProjectModel       models.ProjectModel
+	TaskModel          models.TaskModel
+	StatusHistoryModel models.StatusHistoryModel
+}
+
+func NewSprintHandler(sprintModel models.SprintModel, projectModel models.ProjectModel, taskModel models.TaskModel, statusHistoryModel models.StatusHistoryModel) *SprintHandler {
+	return &SprintHandler{
+		SprintModel:        sprintModel,
+		ProjectModel:       projectModel,
+		TaskModel:          taskModel,
+		StatusHistoryModel: statusHistoryModel,
+	}
+}
+
+// @Summary Get the sprints of a project
+// @Tags sprints
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {array} models.Sprint
+// @Router /projects/{id}/sprints [get]
+// @Failure 404 {string} string "No sprints found"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) GetProjectSprintsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sprints, err := sh.SprintModel.GetProjectSprints(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(sprints) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(sprints)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Plan a sprint
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param sprint body SprintInput true "Sprint"
+// @Success 201 {object} models.Sprint
+// @Router /projects/{id}/sprints [post]
+// @Failure 400 {string} string "Invalid sprint"
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) CreateSprintHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input SprintInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sprint := &models.Sprint{ProjectID: id, Name: input.Name, Goal: input.Goal, StartDate: input.StartDate, EndDate: input.EndDate}
+	if err := sprint.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := sh.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sprintID, err := sh.SprintModel.CreateSprint(sprint)
+	if err != nil {
+		http.Error(writer, "could not create sprint: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, err := sh.SprintModel.GetSprintById(sprintID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(created)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get sprint by ID
+// @Tags sprints
+// @Produce json
+// @Param id path int true "Sprint ID"
+// @Success 200 {object} models.Sprint
+// @Router /sprints/{id} [get]
+// @Failure 404 {string} string "Sprint not found"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) GetSprintHandler(writer http.ResponseWriter, request *http.Request) {
+	sprint, ok := sh.sprintFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err := json.NewEncoder(writer).Encode(sprint)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Edit a sprint
+// @Description Changes the name, goal and dates. Completed sprints cannot be edited.
+// @Tags sprints
+// @Accept json
+// @Param id path int true "Sprint ID"
+// @Param sprint body SprintInput true "Sprint"
+// @Success 200 {string} string "Sprint updated"
+// @Router /sprints/{id} [put]
+// @Failure 400 {string} string "Invalid sprint"
+// @Failure 404 {string} string "Sprint not found"
+// @Failure 409 {string} string "Sprint is completed"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) UpdateSprintHandler(writer http.ResponseWriter, request *http.Request) {
+	sprint, ok := sh.sprintFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	var input SprintInput
+	err := json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if sprint.Status == models.SprintCompleted {
+		http.Error(writer, "sprint is completed", http.StatusConflict)
+		return
+	}
+	sprint.Name, sprint.Goal, sprint.StartDate, sprint.EndDate = input.Name, input.Goal, input.StartDate, input.EndDate
+	if err := sprint.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = sh.SprintModel.UpdateSprint(sprint)
+	if err != nil {
+		http.Error(writer, "could not update sprint: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete a sprint
+// @Description Tasks of the sprint go back to the backlog.
+// @Tags sprints
+// @Param id path int true "Sprint ID"
+// @Success 200 {string} string "Sprint deleted"
+// @Router /sprints/{id} [delete]
+// @Failure 404 {string} string "Sprint not found"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) DeleteSprintHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := sh.SprintModel.DeleteSprint(id)
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get the tasks of a sprint
+// @Tags sprints
+// @Produce json
+// @Param id path int true "Sprint ID"
+// @Success 200 {array} models.Task
+// @Router /sprints/{id}/tasks [get]
+// @Failure 404 {string} string "No tasks found"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) GetSprintTasksHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tasks, err := sh.SprintModel.GetSprintTasks(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(tasks) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(tasks)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Add tasks to a sprint
+// @Description Tasks must belong to the sprint's project; tasks already in another sprint are moved.
+// @Tags sprints
+// @Accept json
+// @Param id path int true "Sprint ID"
+// @Param tasks body SprintTasksInput true "Task IDs"
+// @Success 200 {string} string "Tasks added"
+// @Router /sprints/{id}/tasks [post]
+// @Failure 400 {string} string "Invalid task"
+// @Failure 404 {string} string "Sprint not found"
+// @Failure 409 {string} string "Sprint is completed"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) AddSprintTasksHandler(writer http.ResponseWriter, request *http.Request) {
+	sprint, ok := sh.sprintFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	var input SprintTasksInput
+	err := json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(input.TaskIDs) == 0 {
+		http.Error(writer, "task_ids is required", http.StatusBadRequest)
+		return
+	}
+	if sprint.Status == models.SprintCompleted {
+		http.Error(writer, "sprint is completed", http.StatusConflict)
+		return
+	}
+	for _, taskID := range input.TaskIDs {
+		task, err := sh.TaskModel.GetTaskById(taskID)
+		if task == nil {
+			http.Error(writer, fmt.Sprintf("task %d not found", taskID), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if task.ProjectID != sprint.ProjectID {
+			http.Error(writer, fmt.Sprintf("task %d belongs to another project", taskID), http.StatusBadRequest)
+			return
+		}
+	}
+	err = sh.SprintModel.AddTasks(sprint.ID, input.TaskIDs)
+	if err != nil {
+		http.Error(writer, "could not add tasks: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Remove a task from a sprint
+// @Description The task goes back to the backlog.
+// @Tags sprints
+// @Param id path int true "Sprint ID"
+// @Param task_id path int true "Task ID"
+// @Success 200 {string} string "Task removed"
+// @Router /sprints/{id}/tasks/{task_id} [delete]
+// @Failure 404 {string} string "Task is not in the sprint"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) RemoveSprintTaskHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	taskID, err := strconv.Atoi(vars["task_id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	removedId, err := sh.SprintModel.RemoveTask(id, taskID)
+	if removedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Start a sprint
+// @Description Only planned sprints can be started, and a project has at most one active sprint.
+// @Tags sprints
+// @Produce json
+// @Param id path int true "Sprint ID"
+// @Success 200 {object} models.Sprint
+// @Router /sprints/{id}/start [post]
+// @Failure 404 {string} string "Sprint not found"
+// @Failure 409 {string} string "Sprint is not planned or another sprint is active"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) StartSprintHandler(writer http.ResponseWriter, request *http.Request) {
+	sprint, ok := sh.sprintFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	if sprint.Status != models.SprintPlanned {
+		http.Error(writer, "sprint is "+string(sprint.Status), http.StatusConflict)
+		return
+	}
+	active, _ := sh.SprintModel.GetActiveSprint(sprint.ProjectID)
+	if active != nil {
+		http.Error(writer, fmt.Sprintf("sprint %d is already active", active.ID), http.StatusConflict)
+		return
+	}
+	err := sh.SprintModel.StartSprint(sprint.ID)
+	if err != nil {
+		http.Error(writer, "could not start sprint: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sprint.Status = models.SprintActive
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(sprint)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Complete a sprint
+// @Description Closes the active sprint and moves its unfinished tasks to next_sprint_id, by default the next planned sprint
+// @Description of the project or the backlog when there is none. The sprint's burndown keeps counting the moved tasks.
+// @Tags sprints
+// @Accept json
+// @Produce json
+// @Param id path int true "Sprint ID"
+// @Param options body CompleteSprintInput false "Where unfinished tasks go"
+// @Success 200 {object} SprintCompletion
+// @Router /sprints/{id}/complete [post]
+// @Failure 400 {string} string "Invalid next sprint"
+// @Failure 404 {string} string "Sprint not found"
+// @Failure 409 {string} string "Sprint is not active"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) CompleteSprintHandler(writer http.ResponseWriter, request *http.Request) {
+	sprint, ok := sh.sprintFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	var input CompleteSprintInput
+	err := json.NewDecoder(request.Body).Decode(&input)
+	if err != nil && err != io.EOF {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if sprint.Status != models.SprintActive {
+		http.Error(writer, "sprint is "+string(sprint.Status), http.StatusConflict)
+		return
+	}
+	nextSprintID := input.NextSprintID
+	if nextSprintID != 0 {
+		next, _ := sh.SprintModel.GetSprintById(nextSprintID)
+		if next == nil || next.ID == sprint.ID || next.ProjectID != sprint.ProjectID || next.Status == models.SprintCompleted {
+			http.Error(writer, "next_sprint_id must be another open sprint of the project", http.StatusBadRequest)
+			return
+		}
+	} else if next, _ := sh.SprintModel.GetNextPlannedSprint(sprint.ProjectID); next != nil {
+		nextSprintID = next.ID
+	}
+	today := time.Now().Format(models.DateLayout)
+	moved, err := sh.SprintModel.CompleteSprint(sprint.ID, nextSprintID, today)
+	if err != nil {
+		http.Error(writer, "could not complete sprint: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sprint.Status, sprint.CompletionDate = models.SprintCompleted, today
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(SprintCompletion{Sprint: sprint, MovedTasks: moved, NextSprintID: nextSprintID})
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get the burndown of a sprint
+// @Description For every day of the sprint: the number of tasks in scope and not done at the end of the day, from the tasks'
+// @Description status history, and the ideal line from the first day's scope down to zero. Days still ahead have null counts.
+// @Tags sprints
+// @Produce json
+// @Param id path int true "Sprint ID"
+// @Success 200 {object} metrics.Burndown
+// @Router /sprints/{id}/burndown [get]
+// @Failure 404 {string} string "Sprint not found"
+// @Failure 500 {string} string "Internal server error"
+func (sh *SprintHandler) GetBurndownHandler(writer http.ResponseWriter, request *http.Request) {
+	sprint, ok := sh.sprintFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	tasks, err := sh.SprintModel.GetBurndownTasks(sprint.ID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	taskIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	history, err := sh.StatusHistoryModel.GetStatusHistory(taskIDs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	burndown, err := metrics.NewBurndown(sprint, tasks, history, time.Now().UTC())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(burndown)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// sprintFromRequest loads the sprint named by the id path variable and writes
+// the error response when it cannot.
+func (sh *SprintHandler) sprintFromRequest(writer http.ResponseWriter, request *http.Request) (*models.Sprint, bool) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	sprint, err := sh.SprintModel.GetSprintById(id)
+	if sprint == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return sprint, true
+}
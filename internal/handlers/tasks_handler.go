@@ -1,29 +1,45 @@
 package handlers
 
 import (
+	"ProjectManagementService/internal/events"
 	"ProjectManagementService/internal/models"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
 )
 
 type TaskInput struct {
-	Title             string `json:"title"`
-	Description       string `json:"description"`
-	Priority          string `json:"priority"`
-	Status            string `json:"status"`
-	ResponsibleUserID int    `json:"responsible_user_id"`
-	ProjectID         int    `json:"project_id"`
+	Title             string   `json:"title"`
+	Description       string   `json:"description"`
+	Priority          string   `json:"priority"`
+	Status            string   `json:"status"`
+	ResponsibleUserID int      `json:"responsible_user_id"`
+	ProjectID         int      `json:"project_id"`
+	StartDate         string   `json:"start_date" example:"2021-09-20"`
+	DueDate           string   `json:"due_date" example:"2021-09-30"`
+	ParentID          int      `json:"parent_id"`
+	Labels            []string `json:"labels"`
+	MilestoneID       int      `json:"milestone_id"`
+	EpicID            int      `json:"epic_id"`
+	// optional, time in minutes
+	StoryPoints              *float64 `json:"story_points" example:"3"`
+	OriginalEstimateMinutes  *int     `json:"original_estimate_minutes" example:"480"`
+	RemainingEstimateMinutes *int     `json:"remaining_estimate_minutes" example:"240"`
 }
 
 type TaskHandler struct {
 	TaskModel models.TaskModel
+	Events    *events.Bus
 }
 
-func NewTaskHandler(taskModel models.TaskModel) *TaskHandler {
+func NewTaskHandler(taskModel models.TaskModel, bus *events.Bus) *TaskHandler {
 	return &TaskHandler{
 		TaskModel: taskModel,
+		Events:    bus,
 	}
 }
 
@@ -66,6 +82,7 @@ func (th *TaskHandler) GetAllTasksHandler(writer http.ResponseWriter, request *h
 // @Success 201 {string} string "Task created"
 // @Router /tasks [post]
 // @Failure 400 {string} string "Bad request"
+// @Failure 409 {string} string "Column at its WIP limit"
 // @Failure 500 {string} string "Internal server error"
 func (th *TaskHandler) CreateTaskHandler(writer http.ResponseWriter, request *http.Request) {
 	var task models.Task
@@ -74,11 +91,35 @@ func (th *TaskHandler) CreateTaskHandler(writer http.ResponseWriter, request *ht
 		http.Error(writer, "data reading error: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	err = th.TaskModel.CreateTask(task.Title, task.Description, task.Priority, task.Status, task.ResponsibleUserID, task.ProjectID)
+	if !validDate(task.DueDate) {
+		http.Error(writer, "invalid due_date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if !validDate(task.StartDate) {
+		http.Error(writer, "invalid start_date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if !validPeriod(task.StartDate, task.DueDate) {
+		http.Error(writer, "start_date cannot be after due_date", http.StatusBadRequest)
+		return
+	}
+	if !validEstimates(&task) {
+		http.Error(writer, "estimates cannot be negative", http.StatusBadRequest)
+		return
+	}
+	id, err := th.TaskModel.CreateTask(&task)
+	if errors.Is(err, models.ErrWIPLimitReached) {
+		http.Error(writer, fmt.Sprintf("the %s column is at its WIP limit", task.Status), http.StatusConflict)
+		return
+	}
 	if err != nil {
 		http.Error(writer, "error creating task: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	created, err := th.TaskModel.GetTaskById(id)
+	if err == nil {
+		th.Events.Publish(events.Event{Type: events.TaskCreated, Task: created})
+	}
 	writer.WriteHeader(http.StatusCreated)
 
 }
@@ -127,6 +168,7 @@ func (th *TaskHandler) GetTaskHandler(writer http.ResponseWriter, request *http.
 // @Router /tasks/{id} [put]
 // @Failure 400 {string} string "Bad request"
 // @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "Column at its WIP limit"
 // @Failure 500 {string} string "Internal server error"
 func (th *TaskHandler) UpdateTaskHandler(writer http.ResponseWriter, request *http.Request) {
 	vars := mux.Vars(request)
@@ -144,16 +186,43 @@ func (th *TaskHandler) UpdateTaskHandler(writer http.ResponseWriter, request *ht
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	previous := *task
 	err = json.NewDecoder(request.Body).Decode(&task)
 	if err != nil {
 		http.Error(writer, err.Error(), http.StatusBadRequest)
 		return
 	}
-	err = th.TaskModel.UpdateTask(task.ID, task.Title, task.Description, task.Priority, task.Status, task.ResponsibleUserID, task.ProjectID)
+	if !validDate(task.DueDate) {
+		http.Error(writer, "invalid due_date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if !validDate(task.StartDate) {
+		http.Error(writer, "invalid start_date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if !validPeriod(task.StartDate, task.DueDate) {
+		http.Error(writer, "start_date cannot be after due_date", http.StatusBadRequest)
+		return
+	}
+	if !validEstimates(task) {
+		http.Error(writer, "estimates cannot be negative", http.StatusBadRequest)
+		return
+	}
+	task.ID = id
+	if th.createsCycle(task.ID, task.ParentID) {
+		http.Error(writer, "parent_id would make the task its own ancestor", http.StatusBadRequest)
+		return
+	}
+	err = th.TaskModel.UpdateTask(task)
+	if errors.Is(err, models.ErrWIPLimitReached) {
+		http.Error(writer, fmt.Sprintf("the %s column is at its WIP limit", task.Status), http.StatusConflict)
+		return
+	}
 	if err != nil {
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	th.Events.Publish(events.Event{Type: events.TaskUpdated, Task: task, PreviousTask: &previous})
 	writer.WriteHeader(http.StatusOK)
 
 }
@@ -165,12 +234,14 @@ func (th *TaskHandler) UpdateTaskHandler(writer http.ResponseWriter, request *ht
 // @Router /tasks/{id} [delete]
 // @Failure 400 {string} string "Bad request"
 // @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "Task has time logged in a submitted or approved timesheet"
 // @Failure 500 {string} string "Internal server error"
 // @Param id path int true "Task ID"
 // @Success 200 {string} string "Task deleted"
 // @Router /tasks/{id} [delete]
 // @Failure 400 {string} string "Bad request"
 // @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "Task has time logged in a submitted or approved timesheet"
 // @Failure 500 {string} string "Internal server error"
 func (th *TaskHandler) DeleteTaskHandler(writer http.ResponseWriter, request *http.Request) {
 	vars := mux.Vars(request)
@@ -180,14 +251,18 @@ func (th *TaskHandler) DeleteTaskHandler(writer http.ResponseWriter, request *ht
 		return
 	}
 	deletedId, err := th.TaskModel.DeleteTask(id)
-	if deletedId == 0 {
-		writer.WriteHeader(http.StatusNotFound)
+	if errors.Is(err, models.ErrTimesheetLocked) {
+		http.Error(writer, "the task has time logged in a submitted or approved timesheet", http.StatusConflict)
 		return
 	}
-	if err != nil {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
 	writer.WriteHeader(http.StatusOK)
 }
 
@@ -307,3 +382,47 @@ func (th *TaskHandler) SearchTasksHandler(writer http.ResponseWriter, request *h
 	_, err = writer.Write(jsonTasks)
 
 }
+
+// createsCycle reports whether making parentID the parent of id would make
+// the task its own ancestor.
+func (th *TaskHandler) createsCycle(id, parentID int) bool {
+	for seen := map[int]bool{}; parentID != 0; {
+		if parentID == id || seen[parentID] {
+			return true
+		}
+		seen[parentID] = true
+		parent, err := th.TaskModel.GetTaskById(parentID)
+		if err != nil || parent == nil {
+			return false
+		}
+		parentID = parent.ParentID
+	}
+	return false
+}
+
+// validDate reports whether value is empty or a date models.ParseDate understands.
+func validDate(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := models.ParseDate(value)
+	return err == nil
+}
+
+// validPeriod reports whether start is not after due; either may be empty.
+// Both must be valid dates.
+func validPeriod(start, due string) bool {
+	if start == "" || due == "" {
+		return true
+	}
+	startDate, _ := models.ParseDate(start)
+	dueDate, _ := models.ParseDate(due)
+	return !startDate.After(dueDate)
+}
+
+// validEstimates reports whether the optional estimates of task are not negative.
+func validEstimates(task *models.Task) bool {
+	return (task.StoryPoints == nil || *task.StoryPoints >= 0) &&
+		(task.OriginalEstimateMinutes == nil || *task.OriginalEstimateMinutes >= 0) &&
+		(task.RemainingEstimateMinutes == nil || *task.RemainingEstimateMinutes >= 0)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTaskHandlerWIPLimit(t *testing.T) {
+	mockTaskModel := &models.MockTaskModel{
+		MockGetTaskById: func(id int) (*models.Task, error) {
+			return &models.Task{ID: id, ProjectID: 1, Status: models.New}, nil
+		},
+		MockCreateTask: func(task *models.Task) (int, error) {
+			if task.Status == models.InProgress {
+				return 0, models.ErrWIPLimitReached
+			}
+			return 8, nil
+		},
+		MockUpdateTask: func(task *models.Task) error {
+			if task.Status == models.InProgress {
+				return models.ErrWIPLimitReached
+			}
+			return nil
+		},
+	}
+	handler := NewTaskHandler(mockTaskModel, events.NewBus())
+
+	tests := []struct {
+		handler http.HandlerFunc
+		body    string
+		status  int
+	}{
+		{handler.UpdateTaskHandler, `{"title":"Login","status":"in_progress"}`, http.StatusConflict},
+		{handler.UpdateTaskHandler, `{"title":"Login","status":"done"}`, http.StatusOK},
+		{handler.CreateTaskHandler, `{"title":"Login","status":"in_progress","project_id":1}`, http.StatusConflict},
+		{handler.CreateTaskHandler, `{"title":"Login","status":"new","project_id":1}`, http.StatusCreated},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("PUT", "/tasks/7", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": "7"})
+		rr := httptest.NewRecorder()
+		test.handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v (%s)", test.body, status, test.status, rr.Body.String())
+		}
+	}
+}
+
+func TestDeleteHandlersTimesheetLocked(t *testing.T) {
+	taskHandler := NewTaskHandler(&models.MockTaskModel{
+		MockDeleteTask: func(id int) (int, error) {
+			if id == 7 {
+				return 0, models.ErrTimesheetLocked
+			}
+			return id, nil
+		},
+	}, events.NewBus())
+	userHandler := NewUserHandler(&models.MockUserModel{
+		MockDeleteUser: func(id int) (int, error) {
+			if id == 7 {
+				return 0, models.ErrTimesheetLocked
+			}
+			return id, nil
+		},
+	})
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		id      string
+		status  int
+		body    string
+	}{
+		{"task", taskHandler.DeleteTaskHandler, "7", http.StatusConflict, "the task has time logged in a submitted or approved timesheet\n"},
+		{"task", taskHandler.DeleteTaskHandler, "8", http.StatusOK, ""},
+		{"user", userHandler.DeleteUserHandler, "7", http.StatusConflict, "the user has time logged in a submitted or approved timesheet\n"},
+		{"user", userHandler.DeleteUserHandler, "8", http.StatusOK, ""},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("DELETE", "/"+test.id, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": test.id})
+		rr := httptest.NewRecorder()
+		test.handler.ServeHTTP(rr, req)
+
+		if rr.Code != test.status || rr.Body.String() != test.body {
+			t.Errorf("%s %s: got %v %q, want %v %q", test.name, test.id, rr.Code, rr.Body.String(), test.status, test.body)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type TemplateFromProjectInput struct {
+	Name        string `json:"name" example:"Client onboarding"`
+	Description string `json:"description"`
+}
+
+type InstantiateTemplateInput struct {
+	Title       string `json:"title" example:"ACME onboarding"`
+	Description string `json:"description"`
+	ManagerID   int    `json:"manager_id"`
+	AssigneeID  int    `json:"assignee_id"`
+	StartDate   string `json:"start_date" example:"2021-09-01"`
+}
+
+type TemplateHandler struct {
+	TemplateModel models.TemplateModel
+	ProjectModel  models.ProjectModel
+	Events        *events.Bus
+}
+
+func NewTemplateHandler(templateModel models.TemplateModel, projectModel models.ProjectModel, bus *events.Bus) *TemplateHandler {
+	return &TemplateHandler{
+		TemplateModel: templateModel,
+		ProjectModel:  projectModel,
+		Events:        bus,
+	}
+}
+
+// @Summary Get all project templates
+// @Description Tasks are omitted, get a single template to see them.
+// @Tags templates
+// @Produce json
+// @Success 200 {array} models.Template
+// @Router /templates [get]
+// @Failure 404 {string} string "No templates found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TemplateHandler) GetAllTemplatesHandler(writer http.ResponseWriter, request *http.Request) {
+	templates, err := th.TemplateModel.GetTemplates()
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(templates) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(templates)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Create a project template
+// @Description Task ids only need to be unique within the request and are used to build the tree through parent_id.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param template body models.Template true "Template"
+// @Success 201 {object} models.Template
+// @Router /templates [post]
+// @Failure 400 {string} string "Invalid template"
+// @Failure 500 {string} string "Internal server error"
+func (th *TemplateHandler) CreateTemplateHandler(writer http.ResponseWriter, request *http.Request) {
+	var template models.Template
+	err := json.NewDecoder(request.Body).Decode(&template)
+	if err != nil {
+		http.Error(writer, "could not decode template: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	th.createTemplate(writer, &template)
+}
+
+// @Summary Create a template from a project
+// @Description Captures the project's tasks with their hierarchy, labels and due dates as offsets from the project's creation date.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param template body TemplateFromProjectInput true "Template name"
+// @Success 201 {object} models.Template
+// @Router /projects/{id}/template [post]
+// @Failure 400 {string} string "Invalid template"
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TemplateHandler) CreateTemplateFromProjectHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input TemplateFromProjectInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := th.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := th.ProjectModel.GetProjectTasks(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	name := input.Name
+	if name == "" {
+		name = project.Title
+	}
+	th.createTemplate(writer, models.NewTemplateFromProject(name, input.Description, project, tasks))
+}
+
+func (th *TemplateHandler) createTemplate(writer http.ResponseWriter, template *models.Template) {
+	if err := template.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := th.TemplateModel.CreateTemplate(template)
+	if err != nil {
+		http.Error(writer, "could not create template: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, err := th.TemplateModel.GetTemplateById(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(created)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get project template by ID
+// @Tags templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} models.Template
+// @Router /templates/{id} [get]
+// @Failure 404 {string} string "Template not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TemplateHandler) GetTemplateHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	template, err := th.TemplateModel.GetTemplateById(id)
+	if template == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(template)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Delete a project template
+// @Tags templates
+// @Param id path int true "Template ID"
+// @Success 200 {string} string "Template deleted"
+// @Router /templates/{id} [delete]
+// @Failure 404 {string} string "Template not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TemplateHandler) DeleteTemplateHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := th.TemplateModel.DeleteTemplate(id)
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Create a project from a template
+// @Description Creates the project and all of its tasks in one transaction. Title and description default to the template's,
+// @Description tasks are assigned to assignee_id (default: the manager) and due dates are counted from start_date (default: today).
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param project body InstantiateTemplateInput true "Project details"
+// @Success 201 {object} models.Project
+// @Router /templates/{id}/instantiate [post]
+// @Failure 400 {string} string "Invalid project details"
+// @Failure 404 {string} string "Template not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TemplateHandler) InstantiateTemplateHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input InstantiateTemplateInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if input.ManagerID == 0 {
+		http.Error(writer, "manager_id is required", http.StatusBadRequest)
+		return
+	}
+	startDate := time.Now()
+	if input.StartDate != "" {
+		startDate, err = models.ParseDate(input.StartDate)
+		if err != nil {
+			http.Error(writer, "invalid start_date, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+	template, err := th.TemplateModel.GetTemplateById(id)
+	if template == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	instance := &models.ProjectInstance{
+		Title:       input.Title,
+		Description: input.Description,
+		ManagerID:   input.ManagerID,
+		AssigneeID:  input.AssigneeID,
+		StartDate:   startDate,
+	}
+	if instance.Title == "" {
+		instance.Title = template.ProjectTitle
+	}
+	if instance.Description == "" {
+		instance.Description = template.ProjectDescription
+	}
+	if instance.AssigneeID == 0 {
+		instance.AssigneeID = instance.ManagerID
+	}
+	projectID, err := th.TemplateModel.InstantiateTemplate(template, instance)
+	if err != nil {
+		http.Error(writer, "could not create project: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	project, err := th.ProjectModel.GetProjectByID(projectID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	th.Events.Publish(events.Event{Type: events.ProjectCreated, Project: project})
+	if tasks, err := th.ProjectModel.GetProjectTasks(projectID); err == nil {
+		for i := range tasks {
+			th.Events.Publish(events.Event{Type: events.TaskCreated, Task: &tasks[i]})
+		}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(project)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateTemplateFromProjectHandler(t *testing.T) {
+	var stored *models.Template
+	mockTemplateModel := &models.MockTemplateModel{
+		MockCreateTemplate: func(template *models.Template) (int, error) {
+			stored = template
+			return 1, nil
+		},
+		MockGetTemplateById: func(id int) (*models.Template, error) {
+			return stored, nil
+		},
+	}
+	mockProjectModel := &models.MockProjectModel{
+		MockGetProjectByID: func(id int) (*models.Project, error) {
+			return &models.Project{ID: id, Title: "Onboarding", CreationDate: "2021-09-01T00:00:00Z"}, nil
+		},
+		MockGetProjectTasks: func(id int) ([]models.Task, error) {
+			return []models.Task{
+				{ID: 12, ParentID: 11, Title: "Kick-off call", Priority: models.High},
+				{ID: 11, Title: "Set up", Priority: models.Medium, DueDate: "2021-09-11T00:00:00Z", Labels: []string{"setup"}},
+				{ID: 13, ParentID: 99, Title: "Invoice", Priority: models.Low},
+			}, nil
+		},
+	}
+
+	handler := NewTemplateHandler(mockTemplateModel, mockProjectModel, nil)
+	req, err := http.NewRequest("POST", "/projects/4/template", strings.NewReader(`{"name":"Client onboarding"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = mux.SetURLVars(req, map[string]string{"id": "4"})
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.CreateTemplateFromProjectHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", status, http.StatusCreated, rr.Body.String())
+	}
+	if stored.Name != "Client onboarding" || stored.ProjectTitle != "Onboarding" {
+		t.Errorf("unexpected template: %+v", stored)
+	}
+	order := make([]int, 0)
+	for _, task := range stored.Tasks {
+		order = append(order, task.ID)
+	}
+	if len(order) != 3 || order[0] != 11 || order[1] != 12 || order[2] != 13 {
+		t.Errorf("parents must be stored before their children, got %v", order)
+	}
+	if offset := stored.Tasks[0].DueOffsetDays; offset == nil || *offset != 10 {
+		t.Errorf("expected a due offset of 10 days, got %v", offset)
+	}
+	if stored.Tasks[1].DueOffsetDays != nil {
+		t.Errorf("tasks without a due date must not get an offset")
+	}
+	if stored.Tasks[2].ParentID != 0 {
+		t.Errorf("parents outside the project must be dropped, got %d", stored.Tasks[2].ParentID)
+	}
+}
+
+func TestCreateTemplateHandlerRejectsCycles(t *testing.T) {
+	handler := NewTemplateHandler(&models.MockTemplateModel{}, &models.MockProjectModel{}, nil)
+	body := `{"name":"Loop","project_title":"Loop","tasks":[{"id":1,"parent_id":2,"title":"A"},{"id":2,"parent_id":1,"title":"B"}]}`
+	req, err := http.NewRequest("POST", "/templates", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.CreateTemplateHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+}
+
+func TestInstantiateTemplateHandler(t *testing.T) {
+	var instance *models.ProjectInstance
+	mockTemplateModel := &models.MockTemplateModel{
+		MockGetTemplateById: func(id int) (*models.Template, error) {
+			return &models.Template{ID: id, Name: "Client onboarding", ProjectTitle: "Onboarding", ProjectDescription: "Standard plan"}, nil
+		},
+		MockInstantiateTemplate: func(template *models.Template, i *models.ProjectInstance) (int, error) {
+			instance = i
+			return 8, nil
+		},
+	}
+	mockProjectModel := &models.MockProjectModel{
+		MockGetProjectByID: func(id int) (*models.Project, error) {
+			return &models.Project{ID: id, Title: "ACME"}, nil
+		},
+	}
+
+	handler := NewTemplateHandler(mockTemplateModel, mockProjectModel, nil)
+	req, err := http.NewRequest("POST", "/templates/1/instantiate", strings.NewReader(`{"title":"ACME","manager_id":2,"start_date":"2021-10-04"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.InstantiateTemplateHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", status, http.StatusCreated, rr.Body.String())
+	}
+	expected := models.ProjectInstance{Title: "ACME", Description: "Standard plan", ManagerID: 2, AssigneeID: 2, StartDate: time.Date(2021, 10, 4, 0, 0, 0, 0, time.UTC)}
+	if *instance != expected {
+		t.Errorf("unexpected instance: got %+v want %+v", *instance, expected)
+	}
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"ProjectManagementService/internal/timeline"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type DependencyInput struct {
+	PredecessorID int `json:"predecessor_id"`
+	LagDays       int `json:"lag_days" example:"0"`
+}
+
+type TimelineHandler struct {
+	DependencyModel models.DependencyModel
+	TaskModel       models.TaskModel
+	ProjectModel    models.ProjectModel
+	Scheduler       *timeline.Service
+}
+
+func NewTimelineHandler(dependencyModel models.DependencyModel, taskModel models.TaskModel, projectModel models.ProjectModel, scheduler *timeline.Service) *TimelineHandler {
+	return &TimelineHandler{
+		DependencyModel: dependencyModel,
+		TaskModel:       taskModel,
+		ProjectModel:    projectModel,
+		Scheduler:       scheduler,
+	}
+}
+
+// @Summary Get the timeline of a project
+// @Description Tasks laid out for a Gantt chart with their dependencies, the early and late dates from the critical path
+// @Description method, their slack and the critical path. Tasks without a start or due date are listed as unscheduled.
+// @Tags timeline
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} timeline.Timeline
+// @Router /projects/{id}/timeline [get]
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimelineHandler) GetTimelineHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := th.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	projectTimeline, err := th.Scheduler.Load(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(projectTimeline)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Auto-schedule a project
+// @Description Pushes back every task that starts before its predecessors allow, keeping its duration, and returns the
+// @Description new dates. This also happens on its own whenever the dates of a task change.
+// @Tags timeline
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {array} models.TaskDates
+// @Router /projects/{id}/timeline/schedule [post]
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimelineHandler) ScheduleProjectHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := th.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shifts, err := th.Scheduler.Reschedule(id)
+	if err != nil {
+		http.Error(writer, "could not schedule project: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(shifts)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get the dependencies of a task
+// @Description The links in which the task is either the predecessor or the successor.
+// @Tags timeline
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {array} models.Dependency
+// @Router /tasks/{id}/dependencies [get]
+// @Failure 404 {string} string "No dependencies found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimelineHandler) GetTaskDependenciesHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dependencies, err := th.DependencyModel.GetTaskDependencies(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(dependencies) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(dependencies)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Add a predecessor to a task
+// @Description The task cannot start before the predecessor is due, plus lag_days. Both tasks must belong to the same
+// @Description project, and the link cannot close a loop. The project is rescheduled right away.
+// @Tags timeline
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param dependency body DependencyInput true "Predecessor"
+// @Success 201 {object} models.Dependency
+// @Router /tasks/{id}/dependencies [post]
+// @Failure 400 {string} string "Invalid dependency"
+// @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "The dependency would create a cycle"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimelineHandler) AddDependencyHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input DependencyInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if input.PredecessorID == 0 || input.PredecessorID == id {
+		http.Error(writer, "predecessor_id must be another task", http.StatusBadRequest)
+		return
+	}
+	if input.LagDays < 0 {
+		http.Error(writer, "lag_days cannot be negative", http.StatusBadRequest)
+		return
+	}
+	task, err := th.TaskModel.GetTaskById(id)
+	if task == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	predecessor, _ := th.TaskModel.GetTaskById(input.PredecessorID)
+	if predecessor == nil {
+		http.Error(writer, "predecessor not found", http.StatusBadRequest)
+		return
+	}
+	if predecessor.ProjectID != task.ProjectID {
+		http.Error(writer, "the predecessor belongs to another project", http.StatusBadRequest)
+		return
+	}
+	existing, err := th.DependencyModel.GetProjectDependencies(task.ProjectID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if timeline.CreatesCycle(existing, predecessor.ID, task.ID) {
+		http.Error(writer, "the dependency would create a cycle", http.StatusConflict)
+		return
+	}
+	dependency := &models.Dependency{PredecessorID: predecessor.ID, SuccessorID: task.ID, LagDays: input.LagDays}
+	err = th.DependencyModel.AddDependency(dependency)
+	if err != nil {
+		http.Error(writer, "could not add dependency: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := th.Scheduler.Reschedule(task.ProjectID); err != nil {
+		log.Printf("timeline: could not reschedule project %d: %v\n", task.ProjectID, err)
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(dependency)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Remove a predecessor from a task
+// @Description Dates are left as they are.
+// @Tags timeline
+// @Param id path int true "Task ID"
+// @Param predecessor_id path int true "Predecessor task ID"
+// @Success 200 {string} string "Dependency removed"
+// @Router /tasks/{id}/dependencies/{predecessor_id} [delete]
+// @Failure 404 {string} string "Dependency not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimelineHandler) RemoveDependencyHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	predecessorID, err := strconv.Atoi(vars["predecessor_id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	removedId, err := th.DependencyModel.RemoveDependency(predecessorID, id)
+	if removedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
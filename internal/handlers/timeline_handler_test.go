@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"ProjectManagementService/internal/timeline"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAddDependencyHandler(t *testing.T) {
+	var added *models.Dependency
+	mockDependencyModel := &models.MockDependencyModel{
+		MockGetProjectDependencies: func(projectID int) ([]*models.Dependency, error) {
+			return []*models.Dependency{{PredecessorID: 2, SuccessorID: 3}}, nil
+		},
+		MockAddDependency: func(dependency *models.Dependency) error {
+			added = dependency
+			return nil
+		},
+	}
+	mockTaskModel := &models.MockTaskModel{
+		MockGetTaskById: func(id int) (*models.Task, error) {
+			projectID := 1
+			if id == 9 {
+				projectID = 2
+			}
+			return &models.Task{ID: id, ProjectID: projectID}, nil
+		},
+	}
+	scheduler := timeline.NewService(mockDependencyModel, mockTaskModel)
+	handler := NewTimelineHandler(mockDependencyModel, mockTaskModel, &models.MockProjectModel{}, scheduler)
+
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{`{"predecessor_id":3,"lag_days":2}`, http.StatusCreated},
+		{`{"predecessor_id":2}`, http.StatusCreated},
+		{`{"predecessor_id":9}`, http.StatusBadRequest},
+		{`{"predecessor_id":4}`, http.StatusBadRequest},
+		{`{"predecessor_id":3,"lag_days":-1}`, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		added = nil
+		req, err := http.NewRequest("POST", "/tasks/4/dependencies", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": "4"})
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(handler.AddDependencyHandler).ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", test.body, status, test.status)
+		}
+		if (added != nil) != (test.status == http.StatusCreated) {
+			t.Errorf("%s: dependency stored: %v", test.body, added)
+		}
+	}
+
+	// task 2 already comes before task 3, so 3 -> 2 closes a loop
+	req, err := http.NewRequest("POST", "/tasks/2/dependencies", strings.NewReader(`{"predecessor_id":3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = mux.SetURLVars(req, map[string]string{"id": "2"})
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(handler.AddDependencyHandler).ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusConflict {
+		t.Errorf("cycle: handler returned wrong status code: got %v want %v", status, http.StatusConflict)
+	}
+}
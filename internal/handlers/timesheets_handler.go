@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gorilla/mux"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type TimesheetInput struct {
+	// Week is any day of the week; the timesheet starts on its Monday.
+	Week string `json:"week" example:"2021-09-08"`
+}
+
+type TimesheetReviewInput struct {
+	ReviewerID int    `json:"reviewer_id"`
+	Comment    string `json:"comment" example:"Please split the Friday entry per task"`
+}
+
+type TimesheetSubmitInput struct {
+	Comment string `json:"comment"`
+}
+
+type TimesheetHandler struct {
+	TimesheetModel models.TimesheetModel
+	UserModel      models.UserModel
+	ProjectModel   models.ProjectModel
+}
+
+func NewTimesheetHandler(timesheetModel models.TimesheetModel, userModel models.UserModel, projectModel models.ProjectModel) *TimesheetHandler {
+	return &TimesheetHandler{
+		TimesheetModel: timesheetModel,
+		UserModel:      userModel,
+		ProjectModel:   projectModel,
+	}
+}
+
+// @Summary Get the timesheets of a user
+// @Description Most recent week first, without worklogs.
+// @Tags timesheets
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {array} models.Timesheet
+// @Router /users/{id}/timesheets [get]
+// @Failure 404 {string} string "No timesheets found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimesheetHandler) GetUserTimesheetsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	timesheets, err := th.TimesheetModel.GetUserTimesheets(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(timesheets) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(timesheets)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Open the timesheet of a week
+// @Description Returns the user's timesheet for the week, creating it when it does not exist yet.
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param timesheet body TimesheetInput true "Week"
+// @Success 200 {object} models.Timesheet
+// @Success 201 {object} models.Timesheet
+// @Router /users/{id}/timesheets [post]
+// @Failure 400 {string} string "Invalid week"
+// @Failure 404 {string} string "User not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimesheetHandler) OpenTimesheetHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input TimesheetInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	day, err := models.ParseDate(input.Week)
+	if err != nil {
+		http.Error(writer, "invalid week, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	user, err := th.UserModel.GetUserById(id)
+	if user == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	timesheet, created, err := th.TimesheetModel.GetOrCreateTimesheet(id, models.WeekStart(day).Format(models.DateLayout))
+	if err != nil {
+		http.Error(writer, "could not open timesheet: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	if created {
+		writer.WriteHeader(http.StatusCreated)
+	} else {
+		writer.WriteHeader(http.StatusOK)
+	}
+	err = json.NewEncoder(writer).Encode(timesheet)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get timesheet by ID
+// @Description Includes the worklogs of the week and the history of submissions and reviews.
+// @Tags timesheets
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Success 200 {object} models.Timesheet
+// @Router /timesheets/{id} [get]
+// @Failure 404 {string} string "Timesheet or reviewer not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimesheetHandler) GetTimesheetHandler(writer http.ResponseWriter, request *http.Request) {
+	timesheet, ok := th.timesheetFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	var err error
+	timesheet.Worklogs, err = th.TimesheetModel.GetTimesheetWorklogs(timesheet)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	timesheet.Transitions, err = th.TimesheetModel.GetTransitions(timesheet.ID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(timesheet)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Submit a timesheet
+// @Description Open and rejected timesheets can be submitted. Worklogs of the week are locked until it is rejected.
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Param submission body TimesheetSubmitInput false "Comment"
+// @Success 200 {object} models.Timesheet
+// @Router /timesheets/{id}/submit [post]
+// @Failure 404 {string} string "Timesheet or reviewer not found"
+// @Failure 409 {string} string "Timesheet cannot be submitted"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimesheetHandler) SubmitTimesheetHandler(writer http.ResponseWriter, request *http.Request) {
+	timesheet, ok := th.timesheetFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	var input TimesheetSubmitInput
+	err := json.NewDecoder(request.Body).Decode(&input)
+	if err != nil && err != io.EOF {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	th.transition(writer, timesheet, models.TimesheetSubmitted, timesheet.UserID, input.Comment)
+}
+
+// @Summary Approve a timesheet
+// @Description Only submitted timesheets can be approved, by the manager of one of the projects the user logged time in
+// @Description that week. The timesheet is approved as a whole.
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Param review body TimesheetReviewInput true "Reviewer"
+// @Success 200 {object} models.Timesheet
+// @Router /timesheets/{id}/approve [post]
+// @Failure 400 {string} string "Invalid review"
+// @Failure 403 {string} string "Reviewer does not manage a project of the week"
+// @Failure 404 {string} string "Timesheet or reviewer not found"
+// @Failure 409 {string} string "Timesheet is not submitted"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimesheetHandler) ApproveTimesheetHandler(writer http.ResponseWriter, request *http.Request) {
+	th.review(writer, request, models.TimesheetApproved)
+}
+
+// @Summary Reject a timesheet
+// @Description Submitted or approved timesheets can be rejected with a comment, by the manager of one of the projects the
+// @Description user logged time in that week. The worklogs of the week can be changed again.
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path int true "Timesheet ID"
+// @Param review body TimesheetReviewInput true "Reviewer and reason"
+// @Success 200 {object} models.Timesheet
+// @Router /timesheets/{id}/reject [post]
+// @Failure 400 {string} string "Invalid review"
+// @Failure 403 {string} string "Reviewer does not manage a project of the week"
+// @Failure 404 {string} string "Timesheet or reviewer not found"
+// @Failure 409 {string} string "Timesheet cannot be rejected"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimesheetHandler) RejectTimesheetHandler(writer http.ResponseWriter, request *http.Request) {
+	th.review(writer, request, models.TimesheetRejected)
+}
+
+func (th *TimesheetHandler) review(writer http.ResponseWriter, request *http.Request, next models.TimesheetStatus) {
+	timesheet, ok := th.timesheetFromRequest(writer, request)
+	if !ok {
+		return
+	}
+	var input TimesheetReviewInput
+	err := json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if input.ReviewerID == 0 {
+		http.Error(writer, "reviewer_id is required", http.StatusBadRequest)
+		return
+	}
+	if next == models.TimesheetRejected && input.Comment == "" {
+		http.Error(writer, "a comment is required to reject a timesheet", http.StatusBadRequest)
+		return
+	}
+	reviewer, err := th.UserModel.GetUserById(input.ReviewerID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if reviewer == nil {
+		http.Error(writer, "reviewer not found", http.StatusNotFound)
+		return
+	}
+	managers, err := th.TimesheetModel.GetTimesheetManagers(timesheet)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !isReviewer(managers, input.ReviewerID) {
+		http.Error(writer, fmt.Sprintf("user %d does not manage a project of the timesheet", input.ReviewerID), http.StatusForbidden)
+		return
+	}
+	th.transition(writer, timesheet, next, input.ReviewerID, input.Comment)
+}
+
+// isReviewer tells whether userID is one of the managers of the projects of a
+// timesheet. A week without managed projects has no reviewer.
+func isReviewer(managers []int, userID int) bool {
+	for _, managerID := range managers {
+		if managerID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (th *TimesheetHandler) transition(writer http.ResponseWriter, timesheet *models.Timesheet, next models.TimesheetStatus, userID int, comment string) {
+	if !timesheet.Status.CanBecome(next) {
+		http.Error(writer, fmt.Sprintf("a timesheet that is %s cannot become %s", timesheet.Status, next), http.StatusConflict)
+		return
+	}
+	err := th.TimesheetModel.Transition(timesheet, next, userID, comment)
+	if err != nil {
+		http.Error(writer, "could not update timesheet: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	timesheet.Status = next
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(timesheet)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Export approved hours of a project
+// @Description CSV of the worklogs on the project's tasks that belong to approved timesheets, for billing.
+// @Tags timesheets
+// @Produce text/csv
+// @Param id path int true "Project ID"
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD"
+// @Success 200 {string} string "CSV with columns date, week_start, user_id, user_name, task_id, task_title, minutes, hours, note"
+// @Router /projects/{id}/timesheets/export [get]
+// @Failure 400 {string} string "Invalid date range"
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (th *TimesheetHandler) ExportApprovedHoursHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to, err := queryDateRange(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := th.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entries, err := th.TimesheetModel.GetApprovedEntries(id, from, to)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "text/csv")
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"project-%d-approved-hours.csv\"", id))
+	writer.WriteHeader(http.StatusOK)
+	out := csv.NewWriter(writer)
+	_ = out.Write([]string{"date", "week_start", "user_id", "user_name", "task_id", "task_title", "minutes", "hours", "note"})
+	for _, entry := range entries {
+		_ = out.Write([]string{
+			csvDate(entry.Date),
+			csvDate(entry.WeekStart),
+			strconv.Itoa(entry.UserID),
+			entry.UserName,
+			strconv.Itoa(entry.TaskID),
+			entry.TaskTitle,
+			strconv.Itoa(entry.Minutes),
+			strconv.FormatFloat(float64(entry.Minutes)/60, 'f', 2, 64),
+			entry.Note,
+		})
+	}
+	out.Flush()
+}
+
+// csvDate drops the time the driver adds to date columns.
+func csvDate(value string) string {
+	if date, err := models.ParseDate(value); err == nil {
+		return date.Format(models.DateLayout)
+	}
+	return value
+}
+
+// timesheetFromRequest loads the timesheet named by the id path variable and
+// writes the error response when it cannot.
+func (th *TimesheetHandler) timesheetFromRequest(writer http.ResponseWriter, request *http.Request) (*models.Timesheet, bool) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	timesheet, err := th.TimesheetModel.GetTimesheetById(id)
+	if timesheet == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return timesheet, true
+}
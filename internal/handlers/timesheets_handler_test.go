@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"database/sql"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReviewTimesheetHandler(t *testing.T) {
+	var moved models.TimesheetStatus
+	managers := map[int][]int{3: {1}, 5: {1, 7}, 6: {}}
+	mockTimesheetModel := &models.MockTimesheetModel{
+		MockGetTimesheetById: func(id int) (*models.Timesheet, error) {
+			return &models.Timesheet{ID: id, UserID: 4, WeekStart: "2021-09-06", Status: models.TimesheetSubmitted}, nil
+		},
+		MockGetTimesheetManagers: func(timesheet *models.Timesheet) ([]int, error) {
+			return managers[timesheet.ID], nil
+		},
+		MockTransition: func(timesheet *models.Timesheet, next models.TimesheetStatus, userID int, comment string) error {
+			moved = next
+			return nil
+		},
+	}
+	mockUserModel := &models.MockUserModel{
+		MockGetUserById: func(id int) (*models.User, error) {
+			switch id {
+			case 8:
+				return nil, sql.ErrNoRows
+			case 9:
+				return nil, errors.New("connection refused")
+			}
+			return &models.User{ID: id}, nil
+		},
+	}
+	handler := NewTimesheetHandler(mockTimesheetModel, mockUserModel, &models.MockProjectModel{})
+
+	tests := []struct {
+		handler http.HandlerFunc
+		id      string
+		body    string
+		status  int
+		moved   models.TimesheetStatus
+	}{
+		{handler.ApproveTimesheetHandler, "3", `{"reviewer_id":1}`, http.StatusOK, models.TimesheetApproved},
+		{handler.ApproveTimesheetHandler, "3", `{"reviewer_id":2}`, http.StatusForbidden, ""},
+		{handler.ApproveTimesheetHandler, "3", `{}`, http.StatusBadRequest, ""},
+		{handler.ApproveTimesheetHandler, "3", `{"reviewer_id":8}`, http.StatusNotFound, ""},
+		{handler.ApproveTimesheetHandler, "3", `{"reviewer_id":9}`, http.StatusInternalServerError, ""},
+		// a week in projects of two managers is reviewed by either of them
+		{handler.ApproveTimesheetHandler, "5", `{"reviewer_id":7}`, http.StatusOK, models.TimesheetApproved},
+		{handler.RejectTimesheetHandler, "5", `{"reviewer_id":1,"comment":"Missing Friday"}`, http.StatusOK, models.TimesheetRejected},
+		{handler.ApproveTimesheetHandler, "5", `{"reviewer_id":2}`, http.StatusForbidden, ""},
+		// nobody reviews a week without managed projects
+		{handler.ApproveTimesheetHandler, "6", `{"reviewer_id":1}`, http.StatusForbidden, ""},
+		{handler.RejectTimesheetHandler, "3", `{"reviewer_id":1,"comment":"Missing Friday"}`, http.StatusOK, models.TimesheetRejected},
+		{handler.RejectTimesheetHandler, "3", `{"reviewer_id":1}`, http.StatusBadRequest, ""},
+		{handler.SubmitTimesheetHandler, "3", ``, http.StatusConflict, ""},
+	}
+	for _, test := range tests {
+		moved = ""
+		req, err := http.NewRequest("POST", "/timesheets/"+test.id, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": test.id})
+		rr := httptest.NewRecorder()
+		test.handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s %s: handler returned wrong status code: got %v want %v", test.id, test.body, status, test.status)
+		}
+		if moved != test.moved {
+			t.Errorf("%s %s: timesheet moved to %q, want %q", test.id, test.body, moved, test.moved)
+		}
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	for _, day := range []string{"2021-09-06", "2021-09-08", "2021-09-12"} {
+		date, _ := models.ParseDate(day)
+		if got := models.WeekStart(date).Format(models.DateLayout); got != "2021-09-06" {
+			t.Errorf("WeekStart(%s) = %s, want 2021-09-06", day, got)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"ProjectManagementService/internal/models"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
@@ -175,6 +177,7 @@ func (uh *UserHandler) UpdateUserHandler(writer http.ResponseWriter, request *ht
 // @Success 200 {string} string "User deleted"
 // @Router /users/{id} [delete]
 // @Failure 404 {string} string "User not found"
+// @Failure 409 {string} string "User has time logged in a submitted or approved timesheet"
 // @Failure 500 {string} string "Internal server error"
 func (uh *UserHandler) DeleteUserHandler(writer http.ResponseWriter, request *http.Request) {
 	vars := mux.Vars(request)
@@ -184,14 +187,18 @@ func (uh *UserHandler) DeleteUserHandler(writer http.ResponseWriter, request *ht
 		return
 	}
 	deleteId, err := uh.UserModel.DeleteUser(id)
-	if deleteId == 0 {
-		writer.WriteHeader(http.StatusNotFound)
+	if errors.Is(err, models.ErrTimesheetLocked) {
+		http.Error(writer, "the user has time logged in a submitted or approved timesheet", http.StatusConflict)
 		return
 	}
-	if err != nil {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if deleteId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
 	writer.WriteHeader(http.StatusOK)
 }
 
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/metrics"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxWorkloadWeeks bounds the workload report to half a year.
+const maxWorkloadWeeks = 26
+
+type CapacityInput struct {
+	WeeklyHours float64 `json:"weekly_hours" example:"32"`
+	Team        string  `json:"team" example:"Platform"`
+}
+
+type TimeOffInput struct {
+	StartDate string `json:"start_date" example:"2021-09-13"`
+	EndDate   string `json:"end_date" example:"2021-09-17"`
+	Reason    string `json:"reason" example:"Vacation"`
+}
+
+type WorkloadHandler struct {
+	WorkloadModel models.WorkloadModel
+	UserModel     models.UserModel
+}
+
+func NewWorkloadHandler(workloadModel models.WorkloadModel, userModel models.UserModel) *WorkloadHandler {
+	return &WorkloadHandler{
+		WorkloadModel: workloadModel,
+		UserModel:     userModel,
+	}
+}
+
+// @Summary Get the capacity of a user
+// @Description Hours a week the user can work and their team. Users without a capacity set work 40 hours in no team.
+// @Tags workload
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} models.Capacity
+// @Router /users/{id}/capacity [get]
+// @Failure 404 {string} string "User not found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorkloadHandler) GetCapacityHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	capacity, err := wh.WorkloadModel.GetCapacity(id)
+	if capacity == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(capacity)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Set the capacity of a user
+// @Tags workload
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param capacity body CapacityInput true "Weekly hours and team"
+// @Success 200 {object} models.Capacity
+// @Router /users/{id}/capacity [put]
+// @Failure 400 {string} string "Invalid capacity"
+// @Failure 404 {string} string "User not found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorkloadHandler) SetCapacityHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input CapacityInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	capacity, err := wh.WorkloadModel.GetCapacity(id)
+	if capacity == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	capacity.WeeklyHours = input.WeeklyHours
+	capacity.Team = input.Team
+	if err := capacity.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = wh.WorkloadModel.SetCapacity(capacity)
+	if err != nil {
+		http.Error(writer, "could not set capacity: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(capacity)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get the time off of a user
+// @Tags workload
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {array} models.TimeOff
+// @Router /users/{id}/time-off [get]
+// @Failure 404 {string} string "No time off found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorkloadHandler) GetUserTimeOffHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entries, err := wh.WorkloadModel.GetUserTimeOff(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(entries) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(entries)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Add time off for a user
+// @Description Both days are included. Weekdays off reduce the user's capacity in the workload report.
+// @Tags workload
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param timeOff body TimeOffInput true "Time off"
+// @Success 201 {object} models.TimeOff
+// @Router /users/{id}/time-off [post]
+// @Failure 400 {string} string "Invalid time off"
+// @Failure 404 {string} string "User not found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorkloadHandler) CreateTimeOffHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input TimeOffInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	timeOff := &models.TimeOff{
+		UserID:    id,
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+		Reason:    input.Reason,
+	}
+	if err := timeOff.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	user, err := wh.UserModel.GetUserById(id)
+	if user == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	timeOff.ID, err = wh.WorkloadModel.CreateTimeOff(timeOff)
+	if err != nil {
+		http.Error(writer, "could not create time off: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(timeOff)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Delete time off
+// @Tags workload
+// @Param id path int true "Time off ID"
+// @Success 200
+// @Router /time-off/{id} [delete]
+// @Failure 404 {string} string "Time off not found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorkloadHandler) DeleteTimeOffHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	deletedId, err := wh.WorkloadModel.DeleteTimeOff(id)
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get the workload report
+// @Description Per user and week: capacity, less weekdays off, and the hours of open tasks assigned to the user. The
+// @Description remaining estimate of each task, or its original one, is spread evenly over the working days from its
+// @Description start date, or today, to its due date; overdue work falls on today. Work without a due date is reported
+// @Description as unscheduled. Weeks above capacity are flagged as over-allocated.
+// @Tags reports
+// @Produce json
+// @Param from query string false "First week, as any day of it; defaults to the current week"
+// @Param weeks query int false "Number of weeks, 1 to 26; defaults to 4"
+// @Param project_id query int false "Only the tasks of this project, and the users responsible for them"
+// @Param team query string false "Only the users of this team"
+// @Success 200 {object} metrics.Workload
+// @Router /reports/workload [get]
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorkloadHandler) GetWorkloadHandler(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	now := time.Now().UTC()
+	from := models.WeekStart(now)
+	if value := query.Get("from"); value != "" {
+		day, err := models.ParseDate(value)
+		if err != nil {
+			http.Error(writer, "invalid from, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		from = models.WeekStart(day)
+	}
+	weeks := 4
+	if value := query.Get("weeks"); value != "" {
+		var err error
+		weeks, err = strconv.Atoi(value)
+		if err != nil || weeks < 1 || weeks > maxWorkloadWeeks {
+			http.Error(writer, "weeks must be between 1 and 26", http.StatusBadRequest)
+			return
+		}
+	}
+	projectID := 0
+	if value := query.Get("project_id"); value != "" {
+		var err error
+		projectID, err = strconv.Atoi(value)
+		if err != nil {
+			http.Error(writer, "invalid project_id", http.StatusBadRequest)
+			return
+		}
+	}
+	capacities, err := wh.WorkloadModel.GetCapacities(projectID, query.Get("team"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := wh.WorkloadModel.GetOpenAssignedTasks(projectID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	to := from.AddDate(0, 0, 7*weeks-1)
+	timeOff, err := wh.WorkloadModel.GetTimeOffBetween(from.Format(models.DateLayout), to.Format(models.DateLayout))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(metrics.NewWorkload(capacities, tasks, timeOff, from, weeks, now))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/metrics"
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSetCapacityHandler(t *testing.T) {
+	var saved *models.Capacity
+	mockWorkloadModel := &models.MockWorkloadModel{
+		MockGetCapacity: func(userID int) (*models.Capacity, error) {
+			if userID != 1 {
+				return nil, nil
+			}
+			return &models.Capacity{UserID: 1, Name: "Ann", WeeklyHours: 40}, nil
+		},
+		MockSetCapacity: func(capacity *models.Capacity) error {
+			saved = capacity
+			return nil
+		},
+	}
+	handler := NewWorkloadHandler(mockWorkloadModel, &models.MockUserModel{})
+
+	tests := []struct {
+		id     string
+		body   string
+		status int
+	}{
+		{"1", `{"weekly_hours":32,"team":"Platform"}`, http.StatusOK},
+		{"1", `{"weekly_hours":200}`, http.StatusBadRequest},
+		{"2", `{"weekly_hours":32}`, http.StatusNotFound},
+	}
+	for _, test := range tests {
+		saved = nil
+		req, err := http.NewRequest("PUT", "/users/"+test.id+"/capacity", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": test.id})
+		rr := httptest.NewRecorder()
+		handler.SetCapacityHandler(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", test.body, status, test.status)
+		}
+		if (saved != nil) != (test.status == http.StatusOK) {
+			t.Errorf("%s: capacity saved %v", test.body, saved)
+		}
+	}
+}
+
+func TestGetWorkloadHandler(t *testing.T) {
+	var timeOffRange []string
+	mockWorkloadModel := &models.MockWorkloadModel{
+		MockGetCapacities: func(projectID int, team string) ([]*models.Capacity, error) {
+			return []*models.Capacity{{UserID: 1, Name: "Ann", WeeklyHours: 40, Team: team}}, nil
+		},
+		MockGetTimeOffBetween: func(from, to string) ([]*models.TimeOff, error) {
+			timeOffRange = []string{from, to}
+			return []*models.TimeOff{}, nil
+		},
+		MockGetOpenAssignedTasks: func(projectID int) ([]*models.Task, error) {
+			return []*models.Task{}, nil
+		},
+	}
+	handler := NewWorkloadHandler(mockWorkloadModel, &models.MockUserModel{})
+
+	req, err := http.NewRequest("GET", "/reports/workload?from=2021-09-08&weeks=2&team=Platform", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.GetWorkloadHandler(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	var workload metrics.Workload
+	if err := json.NewDecoder(rr.Body).Decode(&workload); err != nil {
+		t.Fatal(err)
+	}
+	if workload.From != "2021-09-06" || len(workload.Users) != 1 || len(workload.Users[0].Weeks) != 2 {
+		t.Errorf("unexpected workload %+v", workload)
+	}
+	if timeOffRange[0] != "2021-09-06" || timeOffRange[1] != "2021-09-19" {
+		t.Errorf("time off read from %v", timeOffRange)
+	}
+
+	req, err = http.NewRequest("GET", "/reports/workload?weeks=27", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr = httptest.NewRecorder()
+	handler.GetWorkloadHandler(rr, req)
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+}
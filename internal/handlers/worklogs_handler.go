@@ -0,0 +1,397 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type WorklogInput struct {
+	UserID  int    `json:"user_id"`
+	Date    string `json:"date" example:"2021-09-06"`
+	Minutes int    `json:"minutes" example:"90"`
+	Note    string `json:"note" example:"Code review"`
+}
+
+type WorklogHandler struct {
+	WorklogModel models.WorklogModel
+	TaskModel    models.TaskModel
+	ProjectModel models.ProjectModel
+	UserModel    models.UserModel
+}
+
+func NewWorklogHandler(worklogModel models.WorklogModel, taskModel models.TaskModel, projectModel models.ProjectModel, userModel models.UserModel) *WorklogHandler {
+	return &WorklogHandler{
+		WorklogModel: worklogModel,
+		TaskModel:    taskModel,
+		ProjectModel: projectModel,
+		UserModel:    userModel,
+	}
+}
+
+// @Summary Get the worklogs of a task
+// @Tags worklogs
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {array} models.Worklog
+// @Router /tasks/{id}/worklogs [get]
+// @Failure 404 {string} string "No worklogs found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorklogHandler) GetTaskWorklogsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	worklogs, err := wh.WorklogModel.GetTaskWorklogs(id)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(worklogs) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(worklogs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Log time on a task
+// @Description The minutes are taken off the task's remaining estimate, when it has one.
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param worklog body WorklogInput true "Worklog"
+// @Success 201 {object} models.Worklog
+// @Router /tasks/{id}/worklogs [post]
+// @Failure 400 {string} string "Invalid worklog"
+// @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "Timesheet is submitted or approved"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorklogHandler) CreateWorklogHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input WorklogInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	worklog := &models.Worklog{TaskID: id, UserID: input.UserID, Date: input.Date, Minutes: input.Minutes, Note: input.Note}
+	if err := worklog.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	task, err := wh.TaskModel.GetTaskById(id)
+	if task == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	user, _ := wh.UserModel.GetUserById(input.UserID)
+	if user == nil {
+		http.Error(writer, "user not found", http.StatusBadRequest)
+		return
+	}
+	if !wh.checkUnlocked(writer, worklog.UserID, worklog.Date) {
+		return
+	}
+	worklogID, err := wh.WorklogModel.CreateWorklog(worklog)
+	if err != nil {
+		http.Error(writer, "could not log time: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, err := wh.WorklogModel.GetWorklogById(worklogID)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(writer).Encode(created)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Update a worklog
+// @Description Changes the date, minutes and note. The task's remaining estimate is not adjusted.
+// @Tags worklogs
+// @Accept json
+// @Param id path int true "Worklog ID"
+// @Param worklog body WorklogInput true "Worklog, user_id is ignored"
+// @Success 200 {string} string "Worklog updated"
+// @Router /worklogs/{id} [put]
+// @Failure 400 {string} string "Invalid worklog"
+// @Failure 404 {string} string "Worklog not found"
+// @Failure 409 {string} string "Timesheet is submitted or approved"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorklogHandler) UpdateWorklogHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var input WorklogInput
+	err = json.NewDecoder(request.Body).Decode(&input)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	worklog, err := wh.WorklogModel.GetWorklogById(id)
+	if worklog == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !wh.checkUnlocked(writer, worklog.UserID, worklog.Date) {
+		return
+	}
+	worklog.Date, worklog.Minutes, worklog.Note = input.Date, input.Minutes, input.Note
+	if err := worklog.Validate(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !wh.checkUnlocked(writer, worklog.UserID, worklog.Date) {
+		return
+	}
+	err = wh.WorklogModel.UpdateWorklog(worklog)
+	if err != nil {
+		http.Error(writer, "could not update worklog: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete a worklog
+// @Tags worklogs
+// @Param id path int true "Worklog ID"
+// @Success 200 {string} string "Worklog deleted"
+// @Router /worklogs/{id} [delete]
+// @Failure 404 {string} string "Worklog not found"
+// @Failure 409 {string} string "Timesheet is submitted or approved"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorklogHandler) DeleteWorklogHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	worklog, err := wh.WorklogModel.GetWorklogById(id)
+	if worklog == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !wh.checkUnlocked(writer, worklog.UserID, worklog.Date) {
+		return
+	}
+	deletedId, err := wh.WorklogModel.DeleteWorklog(id)
+	if deletedId == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get the worklogs of a user
+// @Tags worklogs
+// @Produce json
+// @Param id path int true "User ID"
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD"
+// @Success 200 {array} models.Worklog
+// @Router /users/{id}/worklogs [get]
+// @Failure 400 {string} string "Invalid date range"
+// @Failure 404 {string} string "No worklogs found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorklogHandler) GetUserWorklogsHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to, err := queryDateRange(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	worklogs, err := wh.WorklogModel.GetUserWorklogs(id, from, to)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(worklogs) == 0 {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(worklogs)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// @Summary Get estimated vs. logged time of a task
+// @Tags worklogs
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} models.TimeSummary
+// @Router /tasks/{id}/time [get]
+// @Failure 404 {string} string "Task not found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorklogHandler) GetTaskTimeHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	task, err := wh.TaskModel.GetTaskById(id)
+	if task == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	summary, err := wh.WorklogModel.GetTaskTimeSummary(id)
+	wh.writeSummary(writer, summary, err)
+}
+
+// @Summary Get estimated vs. logged time of a user
+// @Description Estimates are those of the tasks the user is responsible for; logged time is the user's, between from and to.
+// @Tags worklogs
+// @Produce json
+// @Param id path int true "User ID"
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD"
+// @Success 200 {object} models.TimeSummary
+// @Router /users/{id}/time [get]
+// @Failure 400 {string} string "Invalid date range"
+// @Failure 404 {string} string "User not found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorklogHandler) GetUserTimeHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to, err := queryDateRange(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	user, err := wh.UserModel.GetUserById(id)
+	if user == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	summary, err := wh.WorklogModel.GetUserTimeSummary(id, from, to)
+	wh.writeSummary(writer, summary, err)
+}
+
+// @Summary Get estimated vs. logged time of a project
+// @Tags worklogs
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} models.TimeSummary
+// @Router /projects/{id}/time [get]
+// @Failure 404 {string} string "Project not found"
+// @Failure 500 {string} string "Internal server error"
+func (wh *WorklogHandler) GetProjectTimeHandler(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project, err := wh.ProjectModel.GetProjectByID(id)
+	if project == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	summary, err := wh.WorklogModel.GetProjectTimeSummary(id)
+	wh.writeSummary(writer, summary, err)
+}
+
+// checkUnlocked writes a conflict when the user's timesheet for the week of
+// date was submitted or approved.
+func (wh *WorklogHandler) checkUnlocked(writer http.ResponseWriter, userID int, date string) bool {
+	locked, err := wh.WorklogModel.IsLocked(userID, date)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	if locked {
+		http.Error(writer, "the timesheet for this week is submitted or approved", http.StatusConflict)
+		return false
+	}
+	return true
+}
+
+func (wh *WorklogHandler) writeSummary(writer http.ResponseWriter, summary *models.TimeSummary, err error) {
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(writer).Encode(summary)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// queryDateRange reads the optional from and to query parameters.
+func queryDateRange(request *http.Request) (string, string, error) {
+	from, to := request.URL.Query().Get("from"), request.URL.Query().Get("to")
+	if !validDate(from) || !validDate(to) {
+		return "", "", errors.New("invalid date range, expected YYYY-MM-DD")
+	}
+	return from, to, nil
+}
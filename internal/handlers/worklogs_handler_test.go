@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"ProjectManagementService/internal/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateWorklogHandler(t *testing.T) {
+	var logged *models.Worklog
+	mockWorklogModel := &models.MockWorklogModel{
+		MockCreateWorklog: func(worklog *models.Worklog) (int, error) {
+			logged = worklog
+			return 1, nil
+		},
+		MockGetWorklogById: func(id int) (*models.Worklog, error) {
+			return &models.Worklog{ID: id}, nil
+		},
+	}
+	mockTaskModel := &models.MockTaskModel{
+		MockGetTaskById: func(id int) (*models.Task, error) {
+			return &models.Task{ID: id}, nil
+		},
+	}
+	mockUserModel := &models.MockUserModel{
+		MockGetUserById: func(id int) (*models.User, error) {
+			return &models.User{ID: id}, nil
+		},
+	}
+	handler := NewWorklogHandler(mockWorklogModel, mockTaskModel, &models.MockProjectModel{}, mockUserModel)
+
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{`{"user_id":2,"date":"2021-09-06","minutes":90,"note":"Review"}`, http.StatusCreated},
+		{`{"date":"2021-09-06","minutes":90}`, http.StatusBadRequest},
+		{`{"user_id":2,"date":"06.09.2021","minutes":90}`, http.StatusBadRequest},
+		{`{"user_id":2,"date":"2021-09-06","minutes":0}`, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		logged = nil
+		req, err := http.NewRequest("POST", "/tasks/5/worklogs", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"id": "5"})
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(handler.CreateWorklogHandler).ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.status {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", test.body, status, test.status)
+		}
+		if test.status != http.StatusCreated {
+			if logged != nil {
+				t.Errorf("%s: invalid worklog was stored", test.body)
+			}
+			continue
+		}
+		if logged == nil || logged.TaskID != 5 || logged.UserID != 2 || logged.Minutes != 90 {
+			t.Errorf("%s: unexpected worklog %+v", test.body, logged)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package ical
+
+import (
+	"ProjectManagementService/internal/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RefreshInterval is how often calendar applications are asked to reload
+// a feed.
+const RefreshInterval = "PT1H"
+
+// Feed is a calendar of the due dates of tasks and the target dates of
+// milestones. Dates are all-day DATE values, which fall on the same day in
+// every time zone; times are in UTC, so the feed needs no VTIMEZONE.
+type Feed struct {
+	Name string
+	// Domain makes the UIDs of the entries globally unique. It is the host
+	// of the deployment rather than the one a feed is fetched through, so
+	// that an entry keeps its UID and calendar applications do not show it
+	// twice, and entries of different deployments are never mixed up.
+	Domain     string
+	Tasks      []*models.Task
+	Milestones []*models.Milestone
+	// Revisions of the tasks and milestones, by id, tell calendar
+	// applications that an entry has changed.
+	Revisions          map[int]*models.Revision
+	MilestoneRevisions map[int]*models.Revision
+	// Todos renders tasks as VTODO rather than VEVENT entries; few calendar
+	// applications show VTODO entries.
+	Todos bool
+}
+
+// Calendar renders the feed. Entries with invalid dates are left out.
+func (f *Feed) Calendar() *Component {
+	calendar := NewComponent("VCALENDAR")
+	calendar.Set("VERSION", "2.0")
+	calendar.Set("PRODID", "-//ProjectManagementService//Calendar Feed//EN")
+	calendar.Set("CALSCALE", "GREGORIAN")
+	calendar.Set("METHOD", "PUBLISH")
+	calendar.SetText("NAME", f.Name)
+	calendar.SetText("X-WR-CALNAME", f.Name)
+	calendar.Set("REFRESH-INTERVAL", RefreshInterval, "VALUE=DURATION")
+	calendar.Set("X-PUBLISHED-TTL", RefreshInterval)
+	for _, task := range f.Tasks {
+		if entry := f.task(task); entry != nil {
+			calendar.Add(entry)
+		}
+	}
+	for _, milestone := range f.Milestones {
+		if entry := f.milestone(milestone); entry != nil {
+			calendar.Add(entry)
+		}
+	}
+	return calendar
+}
+
+func (f *Feed) task(task *models.Task) *Component {
+	due, err := models.ParseDate(task.DueDate)
+	if err != nil {
+		return nil
+	}
+	created, _ := models.ParseDate(task.CreationDate)
+	// DTSTAMP is when the entry last changed, so that the feed only changes
+	// with the tasks
+	modified, sequence := created, 0
+	if revision := f.Revisions[task.ID]; revision != nil {
+		modified, sequence = revision.Modified, revision.Sequence
+	}
+	if modified.IsZero() {
+		modified = due
+	}
+
+	description := task.Description
+	if description != "" {
+		description += "\n\n"
+	}
+	description += fmt.Sprintf("Status: %s\nPriority: %s", strings.ReplaceAll(string(task.Status), "_", " "), task.Priority)
+
+	var entry *Component
+	if f.Todos {
+		entry = NewComponent("VTODO")
+	} else {
+		entry = NewComponent("VEVENT")
+	}
+	entry.Set("UID", fmt.Sprintf("task-%d@%s", task.ID, f.Domain))
+	entry.Set("DTSTAMP", DateTime(modified))
+	if f.Todos {
+		if start, err := models.ParseDate(task.StartDate); err == nil && start.Before(due) {
+			entry.Set("DTSTART", Date(start), "VALUE=DATE")
+		}
+		entry.Set("DUE", Date(due), "VALUE=DATE")
+		if priority, ok := map[models.PriorityEnum]string{models.High: "1", models.Medium: "5", models.Low: "9"}[task.Priority]; ok {
+			entry.Set("PRIORITY", priority)
+		}
+		switch task.Status {
+		case models.Done:
+			entry.Set("STATUS", "COMPLETED")
+			entry.Set("PERCENT-COMPLETE", "100")
+			if completed, err := models.ParseDate(task.CompletionDate); err == nil {
+				entry.Set("COMPLETED", DateTime(completed))
+			}
+		case models.InProgress:
+			entry.Set("STATUS", "IN-PROCESS")
+		default:
+			entry.Set("STATUS", "NEEDS-ACTION")
+		}
+	} else {
+		// an all-day event ends on the next day
+		entry.Set("DTSTART", Date(due), "VALUE=DATE")
+		entry.Set("DTEND", Date(due.AddDate(0, 0, 1)), "VALUE=DATE")
+		entry.Set("TRANSP", "TRANSPARENT")
+	}
+	entry.SetText("SUMMARY", task.Title)
+	entry.SetText("DESCRIPTION", description)
+	if len(task.Labels) > 0 {
+		entry.Set("CATEGORIES", TextList(task.Labels))
+	}
+	if !created.IsZero() {
+		entry.Set("CREATED", DateTime(created))
+	}
+	entry.Set("LAST-MODIFIED", DateTime(modified))
+	entry.Set("SEQUENCE", fmt.Sprint(sequence))
+	return entry
+}
+
+func (f *Feed) milestone(milestone *models.Milestone) *Component {
+	target, err := models.ParseDate(milestone.TargetDate)
+	if err != nil {
+		return nil
+	}
+	created, err := time.Parse(time.RFC3339Nano, milestone.CreationDate)
+	if err != nil {
+		created = target
+	}
+	modified, sequence := created, 0
+	if revision := f.MilestoneRevisions[milestone.ID]; revision != nil {
+		modified, sequence = revision.Modified, revision.Sequence
+	}
+	entry := NewComponent("VEVENT")
+	entry.Set("UID", fmt.Sprintf("milestone-%d@%s", milestone.ID, f.Domain))
+	entry.Set("DTSTAMP", DateTime(modified))
+	entry.Set("DTSTART", Date(target), "VALUE=DATE")
+	entry.Set("DTEND", Date(target.AddDate(0, 0, 1)), "VALUE=DATE")
+	entry.Set("TRANSP", "TRANSPARENT")
+	entry.SetText("SUMMARY", "Milestone: "+milestone.Title)
+	if milestone.Description != "" {
+		entry.SetText("DESCRIPTION", milestone.Description)
+	}
+	entry.Set("CATEGORIES", "Milestone")
+	entry.Set("CREATED", DateTime(created))
+	entry.Set("LAST-MODIFIED", DateTime(modified))
+	entry.Set("SEQUENCE", fmt.Sprint(sequence))
+	return entry
+}
@@ -0,0 +1,113 @@
+// Package ical writes iCalendar (RFC 5545) data and renders tasks and
+// milestones as calendar feeds.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Component is a calendar component such as VCALENDAR, VEVENT or VTODO.
+type Component struct {
+	Name       string
+	Properties []*Property
+	Components []*Component
+}
+
+// Property is a content line. Params are "NAME=value" pairs and Value is
+// encoded already, see Text.
+type Property struct {
+	Name   string
+	Params []string
+	Value  string
+}
+
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Set adds a property with an encoded value.
+func (c *Component) Set(name, value string, params ...string) {
+	c.Properties = append(c.Properties, &Property{Name: name, Params: params, Value: value})
+}
+
+// SetText adds a property with a text value, which is escaped.
+func (c *Component) SetText(name, text string, params ...string) {
+	c.Set(name, Text(text), params...)
+}
+
+func (c *Component) Add(component *Component) {
+	c.Components = append(c.Components, component)
+}
+
+// Encode writes c with CRLF line breaks, folding lines longer than 75
+// octets.
+func (c *Component) Encode(out io.Writer) error {
+	writer := bufio.NewWriter(out)
+	c.encode(writer)
+	return writer.Flush()
+}
+
+func (c *Component) encode(writer *bufio.Writer) {
+	writeLine(writer, "BEGIN:"+c.Name)
+	for _, property := range c.Properties {
+		line := property.Name
+		for _, param := range property.Params {
+			line += ";" + param
+		}
+		writeLine(writer, line+":"+property.Value)
+	}
+	for _, component := range c.Components {
+		component.encode(writer)
+	}
+	writeLine(writer, "END:"+c.Name)
+}
+
+// writeLine folds line into lines of at most 75 octets, not splitting
+// characters; continuation lines start with a space.
+func writeLine(writer *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		writer.WriteString(line[:cut])
+		writer.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space counts towards the limit
+		limit = 74
+	}
+	writer.WriteString(line)
+	writer.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// Text escapes a text value.
+func Text(text string) string {
+	return textEscaper.Replace(text)
+}
+
+// TextList escapes the values of a property holding a list of texts, like
+// CATEGORIES.
+func TextList(texts []string) string {
+	escaped := make([]string, 0, len(texts))
+	for _, text := range texts {
+		escaped = append(escaped, Text(text))
+	}
+	return strings.Join(escaped, ",")
+}
+
+// Date formats a DATE value, for properties with the VALUE=DATE parameter.
+func Date(t time.Time) string {
+	return t.Format("20060102")
+}
+
+// DateTime formats a DATE-TIME value in UTC.
+func DateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
@@ -0,0 +1,106 @@
+package ical
+
+import (
+	"ProjectManagementService/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	event := NewComponent("VEVENT")
+	event.SetText("SUMMARY", "Design, then build; test\\ship\nnow")
+	event.SetText("DESCRIPTION", strings.Repeat("é", 50))
+	event.Set("DTSTART", Date(time.Date(2021, 9, 10, 0, 0, 0, 0, time.UTC)), "VALUE=DATE")
+	calendar := NewComponent("VCALENDAR")
+	calendar.Add(event)
+
+	var out strings.Builder
+	if err := calendar.Encode(&out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n")
+	if lines[0] != "BEGIN:VCALENDAR" || lines[1] != "BEGIN:VEVENT" || lines[len(lines)-1] != "END:VCALENDAR" {
+		t.Errorf("unexpected lines %q", lines)
+	}
+	if lines[2] != `SUMMARY:Design\, then build\; test\\ship\nnow` {
+		t.Errorf("summary %q", lines[2])
+	}
+	// 12 octets of name and 100 of text are folded at 75 octets, between characters
+	if lines[3] != "DESCRIPTION:"+strings.Repeat("é", 31) || lines[4] != " "+strings.Repeat("é", 19) {
+		t.Errorf("description folded as %q and %q", lines[3], lines[4])
+	}
+	for _, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+	}
+	if lines[5] != "DTSTART;VALUE=DATE:20210910" {
+		t.Errorf("start %q", lines[5])
+	}
+}
+
+func TestFeed(t *testing.T) {
+	feed := &Feed{
+		Name:   "Website",
+		Domain: "pms.example.com",
+		Tasks: []*models.Task{
+			{ID: 7, Title: "Login page", Status: models.InProgress, Priority: models.High, Labels: []string{"ui", "web,mobile"},
+				CreationDate: "2021-09-01T00:00:00Z", StartDate: "2021-09-06T00:00:00Z", DueDate: "2021-09-10T00:00:00Z"},
+			{ID: 8, Title: "Deploy", Status: models.Done, Priority: models.Low, CreationDate: "2021-09-01T00:00:00Z", DueDate: "2021-09-30T00:00:00Z", CompletionDate: "2021-09-29T00:00:00Z"},
+		},
+		Milestones: []*models.Milestone{{ID: 3, Title: "Beta", TargetDate: "2021-10-01T00:00:00Z", CreationDate: "2021-09-01T12:30:00.123456Z"}},
+		Revisions:  map[int]*models.Revision{7: {ID: 7, Sequence: 2, Modified: time.Date(2021, 9, 7, 15, 4, 5, 0, time.UTC)}},
+	}
+
+	calendar := feed.Calendar()
+	if len(calendar.Components) != 3 {
+		t.Fatalf("%d entries, want 3", len(calendar.Components))
+	}
+	login := properties(calendar.Components[0])
+	for name, want := range map[string]string{
+		"UID": "task-7@pms.example.com", "DTSTAMP": "20210907T150405Z", "DTSTART;VALUE=DATE": "20210910", "DTEND;VALUE=DATE": "20210911",
+		"SEQUENCE": "2", "LAST-MODIFIED": "20210907T150405Z", "CATEGORIES": `ui,web\,mobile`, "DESCRIPTION": `Status: in progress\nPriority: high`,
+	} {
+		if login[name] != want {
+			t.Errorf("event %s = %q, want %q", name, login[name], want)
+		}
+	}
+	milestone := properties(calendar.Components[2])
+	if milestone["UID"] != "milestone-3@pms.example.com" || milestone["DTSTART;VALUE=DATE"] != "20211001" || milestone["DTSTAMP"] != "20210901T123000Z" || milestone["SEQUENCE"] != "0" {
+		t.Errorf("unexpected milestone %v", milestone)
+	}
+
+	// moving the target date of a milestone is an update of its entry
+	feed.Milestones[0].TargetDate = "2021-10-08T00:00:00Z"
+	feed.MilestoneRevisions = map[int]*models.Revision{3: {ID: 3, Sequence: 1, Modified: time.Date(2021, 9, 20, 9, 0, 0, 0, time.UTC)}}
+	milestone = properties(feed.Calendar().Components[2])
+	if milestone["DTSTART;VALUE=DATE"] != "20211008" || milestone["DTSTAMP"] != "20210920T090000Z" || milestone["LAST-MODIFIED"] != "20210920T090000Z" || milestone["SEQUENCE"] != "1" {
+		t.Errorf("unexpected moved milestone %v", milestone)
+	}
+
+	feed.Todos = true
+	calendar = feed.Calendar()
+	login, deploy := properties(calendar.Components[0]), properties(calendar.Components[1])
+	if calendar.Components[0].Name != "VTODO" || login["DUE;VALUE=DATE"] != "20210910" || login["DTSTART;VALUE=DATE"] != "20210906" || login["STATUS"] != "IN-PROCESS" || login["PRIORITY"] != "1" {
+		t.Errorf("unexpected todo %v", login)
+	}
+	if deploy["STATUS"] != "COMPLETED" || deploy["COMPLETED"] != "20210929T000000Z" || deploy["SEQUENCE"] != "0" || deploy["DTSTAMP"] != "20210901T000000Z" {
+		t.Errorf("unexpected todo %v", deploy)
+	}
+	if calendar.Components[2].Name != "VEVENT" {
+		t.Errorf("milestones are events, got %s", calendar.Components[2].Name)
+	}
+}
+
+func properties(component *Component) map[string]string {
+	values := make(map[string]string)
+	for _, property := range component.Properties {
+		name := property.Name
+		for _, param := range property.Params {
+			name += ";" + param
+		}
+		values[name] = property.Value
+	}
+	return values
+}
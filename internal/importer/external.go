@@ -0,0 +1,432 @@
+package importer
+
+import (
+	"ProjectManagementService/internal/models"
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Source is a tool whose export files can be imported.
+type Source string
+
+const (
+	Jira   Source = "jira"
+	Trello Source = "trello"
+	GitHub Source = "github"
+)
+
+func ParseSource(value string) (Source, error) {
+	switch source := Source(strings.ToLower(value)); source {
+	case Jira, Trello, GitHub:
+		return source, nil
+	}
+	return "", fmt.Errorf("unknown source %q, expected jira, trello or github", value)
+}
+
+// Person is someone named in an export. ID is their username, login or
+// account id in the source tool.
+type Person struct {
+	ID   string
+	Name string
+}
+
+// Board is what the source tool keeps tasks in: a Jira project, a Trello
+// board or a GitHub repository. Owner is the id of a person.
+type Board struct {
+	ID          string
+	Title       string
+	Description string
+	Owner       string
+	Issues      []*Issue
+}
+
+// Issue is a task of the source tool. Created, Resolved and Due are dates.
+type Issue struct {
+	ID          string
+	Title       string
+	Description string
+	Status      models.StatusEnum
+	Priority    models.PriorityEnum
+	Assignee    string
+	Labels      []string
+	Created     string
+	Resolved    string
+	Due         string
+	Comments    []*IssueComment
+}
+
+type IssueComment struct {
+	ID      string
+	Author  string
+	Body    string
+	Created time.Time
+}
+
+// Export is the content of an export file of Source.
+type Export struct {
+	Source Source
+	People map[string]*Person
+	Boards []*Board
+}
+
+// person records someone named in the export and returns their id, or the
+// empty string for nobody.
+func (e *Export) person(id, name string) string {
+	id, name = strings.TrimSpace(id), strings.TrimSpace(name)
+	if id == "" {
+		id = name
+	}
+	if id == "" {
+		return ""
+	}
+	if person, ok := e.People[id]; !ok {
+		e.People[id] = &Person{ID: id, Name: name}
+	} else if person.Name == "" {
+		person.Name = name
+	}
+	return id
+}
+
+// ReadExport reads a Jira XML or CSV export, a Trello board exported as
+// JSON, or a JSON array of GitHub issues.
+func ReadExport(in io.Reader, source Source) (*Export, error) {
+	export := &Export{Source: source, People: make(map[string]*Person)}
+	var err error
+	switch source {
+	case Jira:
+		reader := bufio.NewReader(in)
+		// Jira exports issues as XML or as CSV, XML starts with a tag
+		var start []byte
+		start, err = reader.Peek(64)
+		if len(start) > 0 {
+			err = nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("the file is empty")
+		}
+		if strings.HasPrefix(strings.TrimLeft(strings.TrimPrefix(string(start), "\ufeff"), " \t\r\n"), "<") {
+			err = readJiraXML(reader, export)
+		} else {
+			err = readJiraCSV(reader, export)
+		}
+	case Trello:
+		err = readTrello(in, export)
+	case GitHub:
+		err = readGitHub(in, export)
+	default:
+		err = fmt.Errorf("unknown source %q", source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// SyncOptions tells how the people of an export map to users.
+type SyncOptions struct {
+	// Users maps the ids or names of people to the emails of users. People
+	// whose id is an email are mapped to the user with that email, and the
+	// mappings of an earlier import are kept.
+	Users map[string]string
+	// DefaultUser is the email of the user of the people who are not mapped
+	// otherwise and of the boards without owner.
+	DefaultUser string
+	DryRun      bool
+}
+
+type SyncReport struct {
+	Source Source `json:"source"`
+	DryRun bool   `json:"dry_run"`
+	// Committed is true once the export has been written.
+	Committed bool             `json:"committed"`
+	Projects  models.SyncCount `json:"projects"`
+	Tasks     models.SyncCount `json:"tasks"`
+	Comments  models.SyncCount `json:"comments"`
+	// UnmappedUsers lists the people of the export who are not mapped to a
+	// user when there is no default user.
+	UnmappedUsers []string `json:"unmapped_users"`
+	Errors        []string `json:"errors"`
+}
+
+// Sync writes export unless options.DryRun is set or a person cannot be
+// mapped to a user. Boards, issues and comments written by an earlier sync
+// of the same source are updated instead of created again. The error is
+// only set when the database could not be used.
+func (i *Importer) Sync(export *Export, options SyncOptions) (*SyncReport, error) {
+	source := string(export.Source)
+	report := &SyncReport{Source: export.Source, DryRun: options.DryRun, UnmappedUsers: []string{}, Errors: []string{}}
+	users, links, err := i.mapPeople(export, options, report)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*models.ExternalProject, 0, len(export.Boards))
+	for _, board := range export.Boards {
+		manager := users[board.Owner]
+		if manager == 0 && board.Owner == "" {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s has no owner in the export, set a default user", board.Title))
+		}
+		project := &models.ExternalProject{
+			ExternalID: board.ID,
+			Project:    &models.Project{Title: truncate(board.Title, 255), Description: board.Description, ManagerID: manager},
+			Tasks:      make([]*models.ExternalTask, 0, len(board.Issues)),
+		}
+		for _, issue := range board.Issues {
+			assignee := manager
+			if issue.Assignee != "" {
+				assignee = users[issue.Assignee]
+			}
+			task := &models.ExternalTask{
+				ExternalID: issue.ID,
+				Task: &models.Task{
+					Title:             truncate(issue.Title, 255),
+					Description:       issue.Description,
+					Priority:          issue.Priority,
+					Status:            issue.Status,
+					ResponsibleUserID: assignee,
+					CreationDate:      issue.Created,
+					CompletionDate:    issue.Resolved,
+					DueDate:           issue.Due,
+					Labels:            issue.Labels,
+				},
+				Comments: make([]*models.ExternalComment, 0, len(issue.Comments)),
+			}
+			if task.Task.Labels == nil {
+				task.Task.Labels = []string{}
+			}
+			for _, comment := range issue.Comments {
+				author := manager
+				if comment.Author != "" {
+					author = users[comment.Author]
+				}
+				created := ""
+				if !comment.Created.IsZero() {
+					created = comment.Created.UTC().Format("2006-01-02 15:04:05")
+				}
+				task.Comments = append(task.Comments, &models.ExternalComment{
+					ExternalID: comment.ID,
+					Comment:    &models.Comment{UserID: author, Body: comment.Body, CreationDate: created},
+				})
+			}
+			project.Tasks = append(project.Tasks, task)
+		}
+		projects = append(projects, project)
+	}
+	if len(report.UnmappedUsers) > 0 || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	if options.DryRun {
+		err := i.countSync(source, projects, report)
+		if err != nil {
+			return nil, err
+		}
+		return report, nil
+	}
+	sync, err := i.ExternalModel.SyncExternal(source, links, projects)
+	if err != nil {
+		return nil, err
+	}
+	report.Projects, report.Tasks, report.Comments = sync.Projects, sync.Tasks, sync.Comments
+	report.Committed = true
+	return report, nil
+}
+
+// mapPeople returns the users of the people named in export, and the ones to
+// link to the people for the next sync: the default user is not linked.
+func (i *Importer) mapPeople(export *Export, options SyncOptions, report *SyncReport) (map[string]int, map[string]int, error) {
+	named := make(map[string]bool)
+	for _, board := range export.Boards {
+		named[board.Owner] = true
+		for _, issue := range board.Issues {
+			named[issue.Assignee] = true
+			for _, comment := range issue.Comments {
+				named[comment.Author] = true
+			}
+		}
+	}
+	delete(named, "")
+	ids := make([]string, 0, len(named))
+	for id := range named {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	linked, err := i.ExternalModel.GetExternalIDs(string(export.Source), models.ExternalUserKind, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	mapped := make(map[string]string, len(options.Users))
+	for key, email := range options.Users {
+		mapped[strings.ToLower(key)] = email
+	}
+	emails := make(map[string]string)
+	for _, id := range ids {
+		person := export.People[id]
+		if email, ok := mapped[strings.ToLower(id)]; ok {
+			emails[id] = email
+		} else if email, ok := mapped[strings.ToLower(person.Name)]; ok && person.Name != "" {
+			emails[id] = email
+		} else if linked[id] == 0 && strings.Contains(id, "@") {
+			emails[id] = id
+		}
+	}
+	lookup := make([]string, 0, len(emails)+1)
+	for _, email := range emails {
+		lookup = append(lookup, email)
+	}
+	if options.DefaultUser != "" {
+		lookup = append(lookup, options.DefaultUser)
+	}
+	byEmail, err := i.ImportModel.GetUserIDsByEmail(lookup)
+	if err != nil {
+		return nil, nil, err
+	}
+	defaultUser := byEmail[strings.ToLower(options.DefaultUser)]
+	if options.DefaultUser != "" && defaultUser == 0 {
+		report.Errors = append(report.Errors, fmt.Sprintf("no user has the default email %s", options.DefaultUser))
+	}
+
+	users := map[string]int{"": defaultUser}
+	links := make(map[string]int)
+	for _, id := range ids {
+		email, ok := emails[id]
+		switch {
+		case ok && byEmail[strings.ToLower(email)] != 0:
+			users[id] = byEmail[strings.ToLower(email)]
+			links[id] = users[id]
+		case ok && email != id:
+			report.Errors = append(report.Errors, fmt.Sprintf("%s is mapped to %s, but no user has that email", id, email))
+		case linked[id] != 0:
+			users[id] = linked[id]
+		case defaultUser != 0:
+			users[id] = defaultUser
+		default:
+			report.UnmappedUsers = append(report.UnmappedUsers, export.People[id].String())
+		}
+	}
+	return users, links, nil
+}
+
+// countSync counts what a sync would create and update.
+func (i *Importer) countSync(source string, projects []*models.ExternalProject, report *SyncReport) error {
+	var projectIDs, taskIDs, commentIDs []string
+	for _, project := range projects {
+		projectIDs = append(projectIDs, project.ExternalID)
+		for _, task := range project.Tasks {
+			taskIDs = append(taskIDs, task.ExternalID)
+			for _, comment := range task.Comments {
+				commentIDs = append(commentIDs, comment.ExternalID)
+			}
+		}
+	}
+	for _, count := range []struct {
+		kind  string
+		ids   []string
+		count *models.SyncCount
+	}{
+		{models.ExternalProjectKind, projectIDs, &report.Projects},
+		{models.ExternalTaskKind, taskIDs, &report.Tasks},
+		{models.ExternalCommentKind, commentIDs, &report.Comments},
+	} {
+		existing, err := i.ExternalModel.GetExternalIDs(source, count.kind, count.ids)
+		if err != nil {
+			return err
+		}
+		for _, id := range count.ids {
+			if existing[id] != 0 {
+				count.count.Updated++
+			} else {
+				count.count.Created++
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Person) String() string {
+	if p.Name == "" || p.Name == p.ID {
+		return p.ID
+	}
+	return fmt.Sprintf("%s (%s)", p.ID, p.Name)
+}
+
+// statusOf maps the name of a workflow state, such as a Jira status or a
+// Trello list, to a status.
+func statusOf(name string) models.StatusEnum {
+	name = strings.ToLower(name)
+	for _, word := range []string{"done", "closed", "complete", "resolved", "finished", "shipped", "released"} {
+		if strings.Contains(name, word) {
+			return models.Done
+		}
+	}
+	for _, word := range []string{"progress", "doing", "review", "testing", "started", "development", "active"} {
+		if strings.Contains(name, word) {
+			return models.InProgress
+		}
+	}
+	return models.New
+}
+
+// priorityOf maps the name of a priority, as Jira names them, to a
+// priority. The empty string is returned for unknown names.
+func priorityOf(name string) models.PriorityEnum {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "highest", "high", "blocker", "critical", "urgent", "p0", "p1":
+		return models.High
+	case "medium", "major", "normal", "p2":
+		return models.Medium
+	case "low", "lowest", "minor", "trivial", "p3", "p4":
+		return models.Low
+	}
+	return ""
+}
+
+var priorityLabel = regexp.MustCompile(`^(?:priority[\s:/_-]*(\w+)|(\w+)[\s_-]+priority|(p[0-4]))$`)
+
+// labelPriority finds the priority in labels such as "priority: high",
+// "high priority" or "P1", for the tools without priorities.
+func labelPriority(labels []string) models.PriorityEnum {
+	for _, label := range labels {
+		if match := priorityLabel.FindStringSubmatch(strings.ToLower(strings.TrimSpace(label))); match != nil {
+			if priority := priorityOf(match[1] + match[2] + match[3]); priority != "" {
+				return priority
+			}
+		}
+	}
+	return models.Medium
+}
+
+var (
+	htmlBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|li|h[1-6])>`)
+	htmlTags   = regexp.MustCompile(`<[^>]*>`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlText turns the HTML of Jira XML exports into plain text.
+func htmlText(value string) string {
+	value = htmlBreaks.ReplaceAllString(value, "\n")
+	value = html.UnescapeString(htmlTags.ReplaceAllString(value, ""))
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.ReplaceAll(value, "\r", ""), "\n\n"))
+}
+
+// dateOf returns the date of a time in its own time zone.
+func dateOf(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(models.DateLayout)
+}
+
+func truncate(value string, length int) string {
+	if runes := []rune(value); len(runes) > length {
+		return string(runes[:length])
+	}
+	return value
+}
@@ -0,0 +1,248 @@
+package importer
+
+import (
+	"ProjectManagementService/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const jiraXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- RSS generated by JIRA -->
+<rss version="0.92">
+<channel>
+  <title>Jira</title>
+  <item>
+    <title>[PM-1] Login page</title>
+    <project id="10000" key="PM">Project Management</project>
+    <description>&lt;p&gt;Sign in with &lt;b&gt;email&lt;/b&gt;&lt;/p&gt;&lt;p&gt;and password&lt;/p&gt;</description>
+    <key id="10001">PM-1</key>
+    <summary>Login page</summary>
+    <priority id="2">Highest</priority>
+    <status id="3">Code Review</status>
+    <statusCategory id="4" key="indeterminate" colorName="yellow"/>
+    <assignee username="jsmith">John Smith</assignee>
+    <labels><label>ui</label><label>web</label></labels>
+    <created>Mon, 1 Feb 2021 10:00:00 +0000</created>
+    <due>Wed, 10 Feb 2021 00:00:00 +0000</due>
+    <comments>
+      <comment id="10100" author="ann" created="Tue, 2 Feb 2021 09:30:00 +0100">&lt;p&gt;Looks good&lt;/p&gt;</comment>
+    </comments>
+  </item>
+  <item>
+    <project id="10000" key="PM">Project Management</project>
+    <key id="10002">PM-2</key>
+    <summary>Deploy</summary>
+    <priority id="5">Lowest</priority>
+    <status id="1">Closed</status>
+    <assignee username="-1">Unassigned</assignee>
+    <created>Mon, 1 Feb 2021 10:00:00 +0000</created>
+    <resolved>Fri, 5 Feb 2021 16:00:00 +0000</resolved>
+  </item>
+</channel>
+</rss>`
+
+func TestReadJiraXML(t *testing.T) {
+	export, err := ReadExport(strings.NewReader(jiraXML), Jira)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.Boards) != 1 || export.Boards[0].ID != "10000" || export.Boards[0].Title != "Project Management" || len(export.Boards[0].Issues) != 2 {
+		t.Fatalf("unexpected boards %+v", export.Boards)
+	}
+	login, deploy := export.Boards[0].Issues[0], export.Boards[0].Issues[1]
+	if login.ID != "10001" || login.Status != models.InProgress || login.Priority != models.High || login.Assignee != "jsmith" ||
+		login.Created != "2021-02-01" || login.Due != "2021-02-10" || !reflect.DeepEqual(login.Labels, []string{"ui", "web"}) {
+		t.Errorf("unexpected issue %+v", login)
+	}
+	if login.Description != "Sign in with email\nand password" {
+		t.Errorf("description %q", login.Description)
+	}
+	if len(login.Comments) != 1 || login.Comments[0].Author != "ann" || login.Comments[0].Body != "Looks good" || login.Comments[0].Created.UTC().Hour() != 8 {
+		t.Errorf("unexpected comments %+v", login.Comments)
+	}
+	if deploy.Status != models.Done || deploy.Priority != models.Low || deploy.Assignee != "" || deploy.Resolved != "2021-02-05" {
+		t.Errorf("unexpected issue %+v", deploy)
+	}
+	if export.People["jsmith"].Name != "John Smith" {
+		t.Errorf("unexpected people %v", export.People)
+	}
+}
+
+func TestReadJiraCSV(t *testing.T) {
+	file := "Summary,Issue key,Issue id,Status,Project key,Project name,Project lead,Priority,Assignee,Created,Resolved,Due Date,Labels,Labels,Comment,Comment\n" +
+		"Login page,PM-1,10001,Done,PM,Project Management,ann,Medium,jsmith,01/Feb/21 10:00 AM,03/Feb/21 4:15 PM,10/Feb/21,ui,web,02/Feb/21 9:00 AM;ann;Looks good; ship it,02/Feb/21 9:30 AM;jsmith;Thanks\n"
+	export, err := ReadExport(strings.NewReader(file), Jira)
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := export.Boards[0]
+	if board.ID != "PM" || board.Owner != "ann" || len(board.Issues) != 1 {
+		t.Fatalf("unexpected board %+v", board)
+	}
+	issue := board.Issues[0]
+	if issue.Status != models.Done || issue.Priority != models.Medium || issue.Created != "2021-02-01" || issue.Resolved != "2021-02-03" || issue.Due != "2021-02-10" ||
+		!reflect.DeepEqual(issue.Labels, []string{"ui", "web"}) {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+	if len(issue.Comments) != 2 || issue.Comments[0].ID != "10001#1" || issue.Comments[0].Body != "Looks good; ship it" || issue.Comments[1].Author != "jsmith" {
+		t.Errorf("unexpected comments %+v", issue.Comments)
+	}
+}
+
+func TestReadTrello(t *testing.T) {
+	file := `{
+		"id": "5f1e2d3c4b5a697887766554", "name": "Website", "desc": "Marketing site",
+		"members": [{"id": "m1", "username": "ann", "fullName": "Ann"}, {"id": "m2", "username": "bob", "fullName": "Bob"}],
+		"memberships": [{"idMember": "m2", "memberType": "normal"}, {"idMember": "m1", "memberType": "admin"}],
+		"lists": [{"id": "l1", "name": "To Do"}, {"id": "l2", "name": "Doing"}],
+		"cards": [
+			{"id": "601801e00000000000000001", "name": "Login page", "idList": "l2", "idMembers": ["m2"], "labels": [{"name": "High Priority", "color": "red"}, {"name": "", "color": "green"}], "due": "2021-02-10T12:00:00.000Z"},
+			{"id": "601801e00000000000000002", "name": "Blog", "idList": "l1", "dueComplete": true},
+			{"id": "601801e00000000000000003", "name": "Old", "idList": "l1", "closed": true}
+		],
+		"actions": [
+			{"id": "a2", "type": "commentCard", "date": "2021-02-03T09:00:00.000Z", "idMemberCreator": "m1", "data": {"text": "Second", "card": {"id": "601801e00000000000000001"}}},
+			{"id": "a1", "type": "commentCard", "date": "2021-02-02T09:00:00.000Z", "idMemberCreator": "m2", "data": {"text": "First", "card": {"id": "601801e00000000000000001"}}},
+			{"id": "a0", "type": "createCard", "date": "2021-02-01T09:00:00.000Z", "idMemberCreator": "m2", "data": {"card": {"id": "601801e00000000000000001"}}}
+		]
+	}`
+	export, err := ReadExport(strings.NewReader(file), Trello)
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := export.Boards[0]
+	if board.Title != "Website" || board.Owner != "ann" || len(board.Issues) != 2 {
+		t.Fatalf("unexpected board %+v", board)
+	}
+	login, blog := board.Issues[0], board.Issues[1]
+	if login.Status != models.InProgress || login.Priority != models.High || login.Assignee != "bob" || login.Due != "2021-02-10" ||
+		login.Created != "2021-02-01" || !reflect.DeepEqual(login.Labels, []string{"High Priority", "green"}) {
+		t.Errorf("unexpected card %+v", login)
+	}
+	if len(login.Comments) != 2 || login.Comments[0].Body != "First" || login.Comments[1].Author != "ann" {
+		t.Errorf("unexpected comments %+v", login.Comments)
+	}
+	if blog.Status != models.Done || blog.Priority != models.Medium {
+		t.Errorf("unexpected card %+v", blog)
+	}
+}
+
+func TestReadGitHub(t *testing.T) {
+	file := `{
+		"issues": [
+			{"id": 501, "number": 7, "title": "Crash on login", "body": "Steps", "state": "closed", "closed_at": "2021-02-05T10:00:00Z", "created_at": "2021-02-01T10:00:00Z",
+			 "labels": [{"name": "bug"}, {"name": "priority: low"}], "assignee": {"login": "octocat"}, "milestone": {"due_on": "2021-02-28T08:00:00Z"},
+			 "repository_url": "https://api.github.com/repos/acme/site"},
+			{"id": 502, "number": 8, "title": "Dark mode", "state": "open", "created_at": "2021-02-02T10:00:00Z", "labels": [{"name": "in progress"}],
+			 "repository_url": "https://api.github.com/repos/acme/site"},
+			{"id": 503, "number": 9, "title": "Fix typo", "state": "open", "created_at": "2021-02-02T10:00:00Z", "pull_request": {"url": "https://api.github.com/repos/acme/site/pulls/9"},
+			 "repository_url": "https://api.github.com/repos/acme/site"}
+		],
+		"comments": [
+			{"id": 9001, "issue_url": "https://api.github.com/repos/acme/site/issues/7", "user": {"login": "hubot"}, "body": "Fixed", "created_at": "2021-02-05T09:00:00Z"}
+		]
+	}`
+	export, err := ReadExport(strings.NewReader(file), GitHub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := export.Boards[0]
+	if board.ID != "acme/site" || board.Owner != "acme" || len(board.Issues) != 2 {
+		t.Fatalf("unexpected board %+v", board)
+	}
+	crash, dark := board.Issues[0], board.Issues[1]
+	if crash.ID != "501" || crash.Status != models.Done || crash.Priority != models.Low || crash.Resolved != "2021-02-05" || crash.Due != "2021-02-28" ||
+		crash.Assignee != "octocat" || len(crash.Comments) != 1 || crash.Comments[0].Author != "hubot" {
+		t.Errorf("unexpected issue %+v", crash)
+	}
+	if dark.Status != models.InProgress || dark.Priority != models.Medium || len(dark.Comments) != 0 {
+		t.Errorf("unexpected issue %+v", dark)
+	}
+
+	if _, err := ReadExport(strings.NewReader(`[{"id": 1, "number": 1, "title": "x", "repository_url": ""}]`), GitHub); err == nil {
+		t.Error("an issue without repository was accepted")
+	}
+}
+
+func TestSync(t *testing.T) {
+	export, err := ReadExport(strings.NewReader(jiraXML), Jira)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var links map[string]int
+	var synced []*models.ExternalProject
+	importer := New(&models.MockImportModel{
+		MockGetUserIDsByEmail: func(emails []string) (map[string]int, error) {
+			return map[string]int{"john@example.com": 1, "lead@example.com": 2}, nil
+		},
+	}, &models.MockExternalModel{
+		MockGetExternalIDs: func(source, kind string, externalIDs []string) (map[string]int, error) {
+			// ann was mapped by an earlier import, and PM-1 imported
+			switch kind {
+			case models.ExternalUserKind:
+				return map[string]int{"ann": 3}, nil
+			case models.ExternalTaskKind:
+				return map[string]int{"10001": 40}, nil
+			}
+			return map[string]int{}, nil
+		},
+		MockSyncExternal: func(source string, users map[string]int, projects []*models.ExternalProject) (*models.ExternalSync, error) {
+			links, synced = users, projects
+			return &models.ExternalSync{Projects: models.SyncCount{Updated: 1}, Tasks: models.SyncCount{Updated: 1, Created: 1}, Comments: models.SyncCount{Updated: 1}}, nil
+		},
+	})
+
+	report, err := importer.Sync(export, SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// jsmith is not mapped and the project has no owner in XML exports
+	if report.Committed || !reflect.DeepEqual(report.UnmappedUsers, []string{"jsmith (John Smith)"}) || len(report.Errors) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	options := SyncOptions{Users: map[string]string{"John Smith": "john@example.com"}, DefaultUser: "lead@example.com", DryRun: true}
+	report, err = importer.Sync(export, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Committed || synced != nil || report.Tasks != (models.SyncCount{Created: 1, Updated: 1}) || report.Projects != (models.SyncCount{Created: 1}) {
+		t.Errorf("unexpected dry run %+v", report)
+	}
+
+	options.DryRun = false
+	report, err = importer.Sync(export, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Committed || report.Tasks.Updated != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if !reflect.DeepEqual(links, map[string]int{"jsmith": 1}) {
+		t.Errorf("linked %v, want jsmith only", links)
+	}
+	project := synced[0]
+	login, deploy := project.Tasks[0].Task, project.Tasks[1].Task
+	if project.Project.ManagerID != 2 || login.ResponsibleUserID != 1 || deploy.ResponsibleUserID != 2 || project.Tasks[0].Comments[0].Comment.UserID != 3 {
+		t.Errorf("unexpected users: manager %d, assignees %d and %d", project.Project.ManagerID, login.ResponsibleUserID, deploy.ResponsibleUserID)
+	}
+	if deploy.CompletionDate != "2021-02-05" || project.Tasks[0].Comments[0].Comment.CreationDate != "2021-02-02 08:30:00" {
+		t.Errorf("unexpected dates %q and %q", deploy.CompletionDate, project.Tasks[0].Comments[0].Comment.CreationDate)
+	}
+}
+
+func TestLabelPriority(t *testing.T) {
+	for labels, want := range map[string]models.PriorityEnum{
+		"bug,priority: high": models.High,
+		"Priority/Low":       models.Low,
+		"urgent priority":    models.High,
+		"P2":                 models.Medium,
+		"bug,frontend":       models.Medium,
+		"priority:unknown":   models.Medium,
+	} {
+		if got := labelPriority(strings.Split(labels, ",")); got != want {
+			t.Errorf("labelPriority(%s) = %s, want %s", labels, got, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"ProjectManagementService/internal/models"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubIssue is an issue as listed by the GitHub REST API.
+type githubIssue struct {
+	ID            int64        `json:"id"`
+	Number        int          `json:"number"`
+	Title         string       `json:"title"`
+	Body          string       `json:"body"`
+	State         string       `json:"state"`
+	Labels        []githubName `json:"labels"`
+	Assignee      *githubUser  `json:"assignee"`
+	CreatedAt     time.Time    `json:"created_at"`
+	ClosedAt      *time.Time   `json:"closed_at"`
+	RepositoryURL string       `json:"repository_url"`
+	Milestone     *struct {
+		DueOn *time.Time `json:"due_on"`
+	} `json:"milestone"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+type githubName struct {
+	Name string `json:"name"`
+}
+
+// githubComment is an issue comment as listed by the GitHub REST API.
+type githubComment struct {
+	ID        int64      `json:"id"`
+	IssueURL  string     `json:"issue_url"`
+	User      githubUser `json:"user"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// readGitHub reads a JSON array of issues as listed by the GitHub REST API,
+// or an object with the arrays of "issues" and of their "comments". Each
+// repository becomes a project owned by the owner of the repository. Pull
+// requests are left out.
+func readGitHub(in io.Reader, export *Export) error {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	var file struct {
+		Issues   []*githubIssue   `json:"issues"`
+		Comments []*githubComment `json:"comments"`
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &file.Issues)
+	} else {
+		err = json.Unmarshal(trimmed, &file)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid GitHub issues: %w", err)
+	}
+
+	comments := make(map[string][]*IssueComment)
+	for _, comment := range file.Comments {
+		comments[comment.IssueURL] = append(comments[comment.IssueURL], &IssueComment{
+			ID:      strconv.FormatInt(comment.ID, 10),
+			Author:  export.person(comment.User.Login, ""),
+			Body:    comment.Body,
+			Created: comment.CreatedAt,
+		})
+	}
+	boards := make(map[string]*Board)
+	for _, githubIssue := range file.Issues {
+		if len(githubIssue.PullRequest) > 0 && string(githubIssue.PullRequest) != "null" {
+			continue
+		}
+		// https://api.github.com/repos/{owner}/{repo}
+		_, repository, ok := strings.Cut(githubIssue.RepositoryURL, "/repos/")
+		owner, _, _ := strings.Cut(repository, "/")
+		if !ok || owner == "" {
+			return fmt.Errorf("issue %d: invalid repository_url %q", githubIssue.Number, githubIssue.RepositoryURL)
+		}
+		board, ok := boards[repository]
+		if !ok {
+			board = &Board{ID: repository, Title: repository, Owner: export.person(owner, "")}
+			boards[repository] = board
+			export.Boards = append(export.Boards, board)
+		}
+
+		issue := &Issue{
+			ID:          strconv.FormatInt(githubIssue.ID, 10),
+			Title:       githubIssue.Title,
+			Description: githubIssue.Body,
+			Status:      models.New,
+			Labels:      make([]string, 0, len(githubIssue.Labels)),
+			Created:     dateOf(githubIssue.CreatedAt),
+			Comments:    comments[githubIssue.RepositoryURL+"/issues/"+strconv.Itoa(githubIssue.Number)],
+		}
+		for _, label := range githubIssue.Labels {
+			issue.Labels = append(issue.Labels, label.Name)
+			if statusOf(label.Name) == models.InProgress {
+				issue.Status = models.InProgress
+			}
+		}
+		issue.Priority = labelPriority(issue.Labels)
+		if githubIssue.State == "closed" {
+			issue.Status = models.Done
+			if githubIssue.ClosedAt != nil {
+				issue.Resolved = dateOf(*githubIssue.ClosedAt)
+			}
+		}
+		if githubIssue.Assignee != nil {
+			issue.Assignee = export.person(githubIssue.Assignee.Login, "")
+		}
+		if githubIssue.Milestone != nil && githubIssue.Milestone.DueOn != nil {
+			issue.Due = dateOf(*githubIssue.Milestone.DueOn)
+		}
+		board.Issues = append(board.Issues, issue)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+// Package importer creates users, projects and tasks in bulk from CSV or
+// JSON files. Every row is validated before anything is written, and the
+// rows are then written all together or not at all.
+package importer
+
+import (
+	"ProjectManagementService/internal/models"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Kind string
+
+const (
+	Users    Kind = "users"
+	Projects Kind = "projects"
+	Tasks    Kind = "tasks"
+)
+
+// Fields lists the fields of each kind of import. Users are referred to by
+// email and projects by title.
+var Fields = map[Kind][]string{
+	Users:    {"name", "email", "role"},
+	Projects: {"title", "description", "manager_email"},
+	Tasks: {"title", "description", "priority", "status", "assignee_email", "project_title", "start_date", "due_date",
+		"completion_date", "labels", "story_points", "original_estimate_minutes", "remaining_estimate_minutes"},
+}
+
+// RowReport lists the problems of a row, or the id it was created with.
+type RowReport struct {
+	Row    int      `json:"row"`
+	ID     int      `json:"id,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type Report struct {
+	Kind   Kind `json:"kind"`
+	DryRun bool `json:"dry_run"`
+	// Committed is true once every row has been created.
+	Committed      bool         `json:"committed"`
+	Total          int          `json:"total"`
+	Valid          int          `json:"valid"`
+	Invalid        int          `json:"invalid"`
+	IgnoredColumns []string     `json:"ignored_columns"`
+	Rows           []*RowReport `json:"rows"`
+}
+
+type Importer struct {
+	ImportModel   models.ImportModel
+	ExternalModel models.ExternalModel
+}
+
+func New(importModel models.ImportModel, externalModel models.ExternalModel) *Importer {
+	return &Importer{ImportModel: importModel, ExternalModel: externalModel}
+}
+
+// Import validates records and, unless dryRun is set or a record is
+// invalid, creates them in one transaction. The error is only set when the
+// database could not be used; problems with the rows are in the report.
+func (i *Importer) Import(kind Kind, records []*Record, dryRun bool) (*Report, error) {
+	report := &Report{Kind: kind, DryRun: dryRun, Total: len(records), IgnoredColumns: []string{}, Rows: make([]*RowReport, 0, len(records))}
+	for _, record := range records {
+		report.Rows = append(report.Rows, &RowReport{Row: record.Row})
+	}
+	var commit func() error
+	var ids func(index int) int
+	var err error
+	switch kind {
+	case Users:
+		var users []*models.User
+		users, err = i.users(records, report)
+		commit = func() error { return i.ImportModel.ImportUsers(users) }
+		ids = func(index int) int { return users[index].ID }
+	case Projects:
+		var projects []*models.Project
+		projects, err = i.projects(records, report)
+		commit = func() error { return i.ImportModel.ImportProjects(projects) }
+		ids = func(index int) int { return projects[index].ID }
+	case Tasks:
+		var tasks []*models.Task
+		tasks, err = i.tasks(records, report)
+		commit = func() error { return i.ImportModel.ImportTasks(tasks) }
+		ids = func(index int) int { return tasks[index].ID }
+	default:
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range report.Rows {
+		if len(row.Errors) > 0 {
+			report.Invalid++
+		}
+	}
+	report.Valid = report.Total - report.Invalid
+	if dryRun || report.Invalid > 0 || report.Total == 0 {
+		return report, nil
+	}
+
+	var rowErr *models.ImportError
+	if err := commit(); errors.As(err, &rowErr) {
+		report.Rows[rowErr.Index].Errors = append(report.Rows[rowErr.Index].Errors, rowErr.Err.Error())
+		report.Valid--
+		report.Invalid++
+		return report, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for index, row := range report.Rows {
+		row.ID = ids(index)
+	}
+	report.Committed = true
+	return report, nil
+}
+
+func (i *Importer) users(records []*Record, report *Report) ([]*models.User, error) {
+	emails := make([]string, 0, len(records))
+	for _, record := range records {
+		emails = append(emails, record.Values["email"])
+	}
+	existing, err := i.ImportModel.GetUserIDsByEmail(emails)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]int)
+	users := make([]*models.User, 0, len(records))
+	for index, record := range records {
+		row := report.Rows[index]
+		user := &models.User{Name: record.Values["name"], Email: record.Values["email"], Role: record.Values["role"]}
+		email := strings.ToLower(user.Email)
+		switch {
+		case user.Email == "":
+			row.fail("email is required")
+		case !strings.Contains(user.Email, "@"):
+			row.fail("invalid email %q", user.Email)
+		case existing[email] != 0:
+			row.fail("a user with email %s already exists", user.Email)
+		case seen[email] != 0:
+			row.fail("email %s is already used by row %d", user.Email, seen[email])
+		default:
+			seen[email] = record.Row
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (i *Importer) projects(records []*Record, report *Report) ([]*models.Project, error) {
+	emails := make([]string, 0, len(records))
+	for _, record := range records {
+		emails = append(emails, record.Values["manager_email"])
+	}
+	managers, err := i.ImportModel.GetUserIDsByEmail(emails)
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]*models.Project, 0, len(records))
+	for index, record := range records {
+		row := report.Rows[index]
+		project := &models.Project{Title: record.Values["title"], Description: record.Values["description"]}
+		if project.Title == "" {
+			row.fail("title is required")
+		}
+		project.ManagerID = row.user(managers, "manager_email", record.Values["manager_email"])
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+func (i *Importer) tasks(records []*Record, report *Report) ([]*models.Task, error) {
+	emails := make([]string, 0, len(records))
+	titles := make([]string, 0, len(records))
+	for _, record := range records {
+		emails = append(emails, record.Values["assignee_email"])
+		titles = append(titles, record.Values["project_title"])
+	}
+	assignees, err := i.ImportModel.GetUserIDsByEmail(emails)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := i.ImportModel.GetProjectIDsByTitle(titles)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*models.Task, 0, len(records))
+	for index, record := range records {
+		row := report.Rows[index]
+		values := record.Values
+		task := &models.Task{
+			Title:       values["title"],
+			Description: values["description"],
+			Priority:    models.PriorityEnum(strings.ToLower(values["priority"])),
+			Status:      models.StatusEnum(strings.ToLower(values["status"])),
+			Labels:      []string{},
+		}
+		if task.Title == "" {
+			row.fail("title is required")
+		}
+		switch task.Priority {
+		case "":
+			task.Priority = models.Medium
+		case models.Low, models.Medium, models.High:
+		default:
+			row.fail("invalid priority %q, expected low, medium or high", values["priority"])
+		}
+		switch task.Status {
+		case "":
+			task.Status = models.New
+		case models.New, models.InProgress, models.Done:
+		default:
+			row.fail("invalid status %q, expected new, in_progress or done", values["status"])
+		}
+		task.ResponsibleUserID = row.user(assignees, "assignee_email", values["assignee_email"])
+		switch title, ids := values["project_title"], projects[values["project_title"]]; {
+		case title == "":
+			row.fail("project_title is required")
+		case len(ids) == 0:
+			row.fail("no project is titled %q", title)
+		case len(ids) > 1:
+			row.fail("%d projects are titled %q", len(ids), title)
+		default:
+			task.ProjectID = ids[0]
+		}
+		task.StartDate = row.date("start_date", values["start_date"])
+		task.DueDate = row.date("due_date", values["due_date"])
+		if task.StartDate != "" && task.DueDate != "" && task.StartDate > task.DueDate {
+			row.fail("start_date cannot be after due_date")
+		}
+		// only done tasks are completed, on the import date unless given
+		if completionDate := row.date("completion_date", values["completion_date"]); task.Status == models.Done {
+			task.CompletionDate = completionDate
+		}
+		for _, label := range strings.Split(values["labels"], ";") {
+			if label = strings.TrimSpace(label); label != "" {
+				task.Labels = append(task.Labels, label)
+			}
+		}
+		if value := values["story_points"]; value != "" {
+			points, err := strconv.ParseFloat(value, 64)
+			if err != nil || points < 0 {
+				row.fail("invalid story_points %q", value)
+			}
+			task.StoryPoints = &points
+		}
+		task.OriginalEstimateMinutes = row.minutes("original_estimate_minutes", values["original_estimate_minutes"])
+		task.RemainingEstimateMinutes = row.minutes("remaining_estimate_minutes", values["remaining_estimate_minutes"])
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func (r *RowReport) fail(format string, args ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// user resolves the required email of the field to the id of a user.
+func (r *RowReport) user(ids map[string]int, field, email string) int {
+	if email == "" {
+		r.fail("%s is required", field)
+		return 0
+	}
+	id, ok := ids[strings.ToLower(email)]
+	if !ok {
+		r.fail("no user has the email %s", email)
+	}
+	return id
+}
+
+func (r *RowReport) date(field, value string) string {
+	if value == "" {
+		return ""
+	}
+	date, err := models.ParseDate(value)
+	if err != nil {
+		r.fail("invalid %s %q, expected YYYY-MM-DD", field, value)
+		return ""
+	}
+	return date.Format(models.DateLayout)
+}
+
+func (r *RowReport) minutes(field, value string) *int {
+	if value == "" {
+		return nil
+	}
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes < 0 {
+		r.fail("invalid %s %q", field, value)
+		return nil
+	}
+	return &minutes
+}
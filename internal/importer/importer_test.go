@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"ProjectManagementService/internal/models"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	csvFile := "\ufeffSummary,Assignee,Sprint\nLogin page,ann@example.com,3\n"
+	records, ignored, err := Read(strings.NewReader(csvFile), CSV, Tasks, map[string]string{"Summary": "title", "Assignee": "assignee_email"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"title": "Login page", "assignee_email": "ann@example.com"}; len(records) != 1 || !reflect.DeepEqual(records[0].Values, want) {
+		t.Errorf("unexpected records %v", records)
+	}
+	if !reflect.DeepEqual(ignored, []string{"Sprint"}) {
+		t.Errorf("ignored %v, want [Sprint]", ignored)
+	}
+
+	jsonFile := `[{"title": "Login page", "labels": ["ui", "web"], "story_points": 2.5, "due_date": null}]`
+	records, _, err = Read(strings.NewReader(jsonFile), JSON, Tasks, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"title": "Login page", "labels": "ui;web", "story_points": "2.5", "due_date": ""}; !reflect.DeepEqual(records[0].Values, want) {
+		t.Errorf("unexpected record %v", records[0].Values)
+	}
+
+	if _, _, err := Read(strings.NewReader(jsonFile), JSON, Tasks, map[string]string{"Summary": "name"}); err == nil {
+		t.Error("mapping to an unknown field was accepted")
+	}
+	if _, _, err := Read(strings.NewReader(`[{"title": {"text": "Login"}}]`), JSON, Tasks, nil); err == nil {
+		t.Error("an object value was accepted")
+	}
+
+	mapping, err := ParseMapping("557058:f2b1:ann@example.com, Bob Lee:bob@example.com")
+	if want := map[string]string{"557058:f2b1": "ann@example.com", "Bob Lee": "bob@example.com"}; err != nil || !reflect.DeepEqual(mapping, want) {
+		t.Errorf("ParseMapping = %v, %v", mapping, err)
+	}
+}
+
+func taskRecords() []*Record {
+	return []*Record{
+		{Row: 1, Values: map[string]string{"title": "Login page", "assignee_email": "Ann@Example.com", "project_title": "Website", "labels": "ui; web", "due_date": "2021-09-10"}},
+		{Row: 2, Values: map[string]string{"title": "Deploy", "priority": "HIGH", "status": "in_progress", "assignee_email": "bob@example.com", "project_title": "Website", "original_estimate_minutes": "90", "completion_date": "2021-09-03"}},
+		{Row: 3, Values: map[string]string{"title": "Design", "status": "done", "assignee_email": "ann@example.com", "project_title": "Website", "completion_date": "2021-09-02"}},
+	}
+}
+
+func TestImportTasks(t *testing.T) {
+	var imported []*models.Task
+	model := &models.MockImportModel{
+		MockGetUserIDsByEmail: func(emails []string) (map[string]int, error) {
+			return map[string]int{"ann@example.com": 1, "bob@example.com": 2}, nil
+		},
+		MockGetProjectIDsByTitle: func(titles []string) (map[string][]int, error) {
+			return map[string][]int{"Website": {7}, "Mobile": {8, 9}}, nil
+		},
+		MockImportTasks: func(tasks []*models.Task) error {
+			for i, task := range tasks {
+				task.ID = 100 + i
+			}
+			imported = tasks
+			return nil
+		},
+	}
+	importer := New(model, nil)
+
+	report, err := importer.Import(Tasks, taskRecords(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Committed || report.Valid != 3 || imported != nil {
+		t.Errorf("dry run: unexpected report %+v", report)
+	}
+
+	report, err = importer.Import(Tasks, taskRecords(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Committed || report.Rows[0].ID != 100 || report.Rows[1].ID != 101 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	first, second, third := imported[0], imported[1], imported[2]
+	if first.ResponsibleUserID != 1 || first.ProjectID != 7 || first.Priority != models.Medium || first.Status != models.New || !reflect.DeepEqual(first.Labels, []string{"ui", "web"}) {
+		t.Errorf("unexpected first task %+v", first)
+	}
+	if second.Priority != models.High || second.Status != models.InProgress || *second.OriginalEstimateMinutes != 90 || second.CompletionDate != "" {
+		t.Errorf("unexpected second task %+v", second)
+	}
+	if third.Status != models.Done || third.CompletionDate != "2021-09-02" {
+		t.Errorf("unexpected third task %+v", third)
+	}
+
+	imported = nil
+	records := taskRecords()
+	records[0].Values["project_title"] = "Mobile"
+	records[1].Values["assignee_email"] = "carl@example.com"
+	records[1].Values["start_date"] = "2021-09-20"
+	records[1].Values["due_date"] = "2021-09-01"
+	records[2].Values["completion_date"] = "yesterday"
+	report, err = importer.Import(Tasks, records, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Committed || report.Invalid != 3 || imported != nil {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if len(report.Rows[0].Errors) != 1 || len(report.Rows[1].Errors) != 2 {
+		t.Errorf("unexpected errors %v and %v", report.Rows[0].Errors, report.Rows[1].Errors)
+	}
+}
+
+func TestImportRollback(t *testing.T) {
+	model := &models.MockImportModel{
+		MockGetUserIDsByEmail: func(emails []string) (map[string]int, error) {
+			return map[string]int{"ann@example.com": 1}, nil
+		},
+		MockImportUsers: func(users []*models.User) error {
+			return &models.ImportError{Index: 1, Err: errors.New("value too long for type character varying(255)")}
+		},
+	}
+	records := []*Record{
+		{Row: 1, Values: map[string]string{"name": "Bob", "email": "bob@example.com"}},
+		{Row: 2, Values: map[string]string{"name": strings.Repeat("x", 300), "email": "carl@example.com"}},
+		{Row: 3, Values: map[string]string{"name": "Ann", "email": "ANN@example.com"}},
+		{Row: 4, Values: map[string]string{"name": "Bob", "email": "bob@example.com"}},
+	}
+	report, err := New(model, nil).Import(Users, records, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the existing and the duplicate emails are caught before writing
+	if report.Invalid != 2 || report.Committed {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	report, err = New(model, nil).Import(Users, records[:2], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Committed || report.Invalid != 1 || len(report.Rows[1].Errors) != 1 || report.Rows[0].ID != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
@@ -0,0 +1,260 @@
+package importer
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jiraItem is an issue of an XML export, which is an RSS feed.
+type jiraItem struct {
+	Project struct {
+		ID   string `xml:"id,attr"`
+		Key  string `xml:"key,attr"`
+		Name string `xml:",chardata"`
+	} `xml:"project"`
+	Key struct {
+		ID  string `xml:"id,attr"`
+		Key string `xml:",chardata"`
+	} `xml:"key"`
+	Summary        string   `xml:"summary"`
+	Description    string   `xml:"description"`
+	Priority       string   `xml:"priority"`
+	Status         string   `xml:"status"`
+	StatusCategory jiraKey  `xml:"statusCategory"`
+	Assignee       jiraUser `xml:"assignee"`
+	Labels         []string `xml:"labels>label"`
+	Created        string   `xml:"created"`
+	Resolved       string   `xml:"resolved"`
+	Due            string   `xml:"due"`
+	Comments       []struct {
+		ID      string `xml:"id,attr"`
+		Author  string `xml:"author,attr"`
+		Created string `xml:"created,attr"`
+		Body    string `xml:",chardata"`
+	} `xml:"comments>comment"`
+}
+
+type jiraKey struct {
+	Key string `xml:"key,attr"`
+}
+
+type jiraUser struct {
+	Username  string `xml:"username,attr"`
+	AccountID string `xml:"accountid,attr"`
+	Name      string `xml:",chardata"`
+}
+
+// jiraXMLTime is the layout of the dates of XML exports.
+const jiraXMLTime = "Mon, 2 Jan 2006 15:04:05 -0700"
+
+func readJiraXML(in io.Reader, export *Export) error {
+	var rss struct {
+		Items []*jiraItem `xml:"channel>item"`
+	}
+	if err := xml.NewDecoder(in).Decode(&rss); err != nil {
+		return fmt.Errorf("invalid Jira XML: %w", err)
+	}
+	boards := make(map[string]*Board)
+	for _, item := range rss.Items {
+		issue := &Issue{
+			ID:          item.Key.ID,
+			Title:       strings.TrimSpace(item.Summary),
+			Description: htmlText(item.Description),
+			Status:      jiraStatus(item.StatusCategory.Key, item.Status),
+			Priority:    priorityOf(item.Priority),
+			Labels:      item.Labels,
+		}
+		if issue.ID == "" {
+			issue.ID = strings.TrimSpace(item.Key.Key)
+		}
+		if issue.Priority == "" {
+			issue.Priority = labelPriority(item.Labels)
+		}
+		// unassigned issues name the user -1
+		if item.Assignee.Username != "-1" {
+			issue.Assignee = export.person(first(item.Assignee.AccountID, item.Assignee.Username), item.Assignee.Name)
+		}
+		for _, value := range []struct {
+			field string
+			date  *string
+		}{{item.Created, &issue.Created}, {item.Resolved, &issue.Resolved}, {item.Due, &issue.Due}} {
+			t, err := parseTime(value.field, jiraXMLTime)
+			if err != nil {
+				return fmt.Errorf("issue %s: %w", item.Key.Key, err)
+			}
+			*value.date = dateOf(t)
+		}
+		for _, comment := range item.Comments {
+			created, err := parseTime(comment.Created, jiraXMLTime)
+			if err != nil {
+				return fmt.Errorf("issue %s: %w", item.Key.Key, err)
+			}
+			issue.Comments = append(issue.Comments, &IssueComment{
+				ID:      comment.ID,
+				Author:  export.person(comment.Author, ""),
+				Body:    htmlText(comment.Body),
+				Created: created,
+			})
+		}
+		projectID := first(item.Project.ID, item.Project.Key)
+		board, ok := boards[projectID]
+		if !ok {
+			board = &Board{ID: projectID, Title: strings.TrimSpace(item.Project.Name)}
+			boards[projectID] = board
+			export.Boards = append(export.Boards, board)
+		}
+		board.Issues = append(board.Issues, issue)
+	}
+	return nil
+}
+
+// jiraCSVTimes are the layouts of the dates of CSV exports, which follow the
+// date format settings of the Jira site.
+var jiraCSVTimes = []string{"02/Jan/06 3:04 PM", "2/Jan/06 3:04 PM", "02/Jan/06", "2/Jan/06", "2006-01-02 15:04", "2006-01-02", "01/02/2006 15:04", jiraXMLTime}
+
+// readJiraCSV reads a CSV export, which repeats the Labels and Comment
+// columns for issues with several of them.
+func readJiraCSV(in io.Reader, export *Export) error {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("invalid Jira CSV: %w", err)
+	}
+	columns := make(map[string][]int)
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		columns[column] = append(columns[column], i)
+	}
+	for _, required := range []string{"summary", "issue id"} {
+		if len(columns[required]) == 0 {
+			return fmt.Errorf("invalid Jira CSV: no %s column", required)
+		}
+	}
+
+	boards := make(map[string]*Board)
+	for row := 1; ; row++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid Jira CSV: %w", err)
+		}
+		all := func(column string) []string {
+			found := make([]string, 0)
+			for _, i := range columns[column] {
+				if i < len(values) && strings.TrimSpace(values[i]) != "" {
+					found = append(found, strings.TrimSpace(values[i]))
+				}
+			}
+			return found
+		}
+		get := func(column string) string {
+			if found := all(column); len(found) > 0 {
+				return found[0]
+			}
+			return ""
+		}
+
+		issue := &Issue{
+			ID:          get("issue id"),
+			Title:       get("summary"),
+			Description: get("description"),
+			Status:      jiraStatus(get("status category"), get("status")),
+			Priority:    priorityOf(get("priority")),
+			Assignee:    export.person(get("assignee id"), get("assignee")),
+			Labels:      all("labels"),
+		}
+		if issue.Priority == "" {
+			issue.Priority = labelPriority(issue.Labels)
+		}
+		for _, value := range []struct {
+			column string
+			date   *string
+		}{{"created", &issue.Created}, {"resolved", &issue.Resolved}, {"due date", &issue.Due}} {
+			t, err := parseTime(get(value.column), jiraCSVTimes...)
+			if err != nil {
+				return fmt.Errorf("row %d: %s: %w", row, value.column, err)
+			}
+			*value.date = dateOf(t)
+		}
+		// comments are written as "date;author;body"
+		for i, value := range all("comment") {
+			parts := strings.SplitN(value, ";", 3)
+			if len(parts) < 3 {
+				return fmt.Errorf("row %d: invalid comment %q, expected date;author;body", row, value)
+			}
+			created, err := parseTime(parts[0], jiraCSVTimes...)
+			if err != nil {
+				return fmt.Errorf("row %d: comment: %w", row, err)
+			}
+			issue.Comments = append(issue.Comments, &IssueComment{
+				// comments have no ids in CSV exports
+				ID:      issue.ID + "#" + strconv.Itoa(i+1),
+				Author:  export.person(parts[1], ""),
+				Body:    strings.TrimSpace(parts[2]),
+				Created: created,
+			})
+		}
+
+		projectID := first(get("project id"), get("project key"))
+		board, ok := boards[projectID]
+		if !ok {
+			board = &Board{
+				ID:          projectID,
+				Title:       first(get("project name"), get("project key")),
+				Description: get("project description"),
+				Owner:       export.person(get("project lead id"), get("project lead")),
+			}
+			boards[projectID] = board
+			export.Boards = append(export.Boards, board)
+		}
+		board.Issues = append(board.Issues, issue)
+	}
+}
+
+// jiraStatus maps the status category of an issue, or else its status, to a
+// status. Categories are "new", "indeterminate" and "done" in XML exports
+// and "To Do", "In Progress" and "Done" in CSV exports.
+func jiraStatus(category, status string) models.StatusEnum {
+	switch strings.ToLower(category) {
+	case "new", "to do":
+		return models.New
+	case "indeterminate", "in progress":
+		return models.InProgress
+	case "done":
+		return models.Done
+	}
+	return statusOf(status)
+}
+
+// parseTime parses value with the first layout that fits. The empty string
+// is the zero time.
+func parseTime(value string, layouts ...string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+func first(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	JSON Format = "json"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(value)); format {
+	case CSV, JSON:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown format %q, expected csv or json", value)
+}
+
+// Record is one row of an import by field. Row counts the rows of the file
+// from 1, not counting the CSV header.
+type Record struct {
+	Row    int
+	Values map[string]string
+}
+
+// ParseMapping reads a column mapping written as "source:field" pairs
+// separated by commas, such as "Summary:title,Assignee:assignee_email".
+func ParseMapping(value string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		// sources may hold colons, like the account ids of Jira
+		colon := strings.LastIndex(pair, ":")
+		if colon < 0 {
+			return nil, fmt.Errorf("invalid mapping %q, expected source:field", pair)
+		}
+		source, field := strings.TrimSpace(pair[:colon]), strings.TrimSpace(pair[colon+1:])
+		if source == "" || field == "" {
+			return nil, fmt.Errorf("invalid mapping %q, expected source:field", pair)
+		}
+		mapping[source] = field
+	}
+	return mapping, nil
+}
+
+// Read parses a CSV file with a header row, or a JSON array of objects,
+// into records of the fields of kind. Columns are renamed by mapping first;
+// the columns that are not fields of kind are returned as ignored.
+func Read(in io.Reader, format Format, kind Kind, mapping map[string]string) ([]*Record, []string, error) {
+	fields, ok := Fields[kind]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown kind %q", kind)
+	}
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field] = true
+	}
+	for source, field := range mapping {
+		if !known[field] {
+			return nil, nil, fmt.Errorf("cannot map %s to %s, %s have no such field", source, field, kind)
+		}
+	}
+
+	var rows []map[string]string
+	var err error
+	switch format {
+	case CSV:
+		rows, err = readCSV(in)
+	case JSON:
+		rows, err = readJSON(in)
+	default:
+		err = fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := make([]*Record, 0, len(rows))
+	ignored := make(map[string]bool)
+	for i, row := range rows {
+		record := &Record{Row: i + 1, Values: make(map[string]string)}
+		for column, value := range row {
+			field := column
+			if mapped, ok := mapping[column]; ok {
+				field = mapped
+			}
+			if !known[field] {
+				ignored[column] = true
+				continue
+			}
+			record.Values[field] = strings.TrimSpace(value)
+		}
+		records = append(records, record)
+	}
+	ignoredColumns := make([]string, 0, len(ignored))
+	for column := range ignored {
+		ignoredColumns = append(ignoredColumns, column)
+	}
+	sort.Strings(ignoredColumns)
+	return records, ignoredColumns, nil
+}
+
+func readCSV(in io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(in)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	// spreadsheet applications start UTF-8 files with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	rows := make([]map[string]string, 0)
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[strings.TrimSpace(column)] = values[i]
+		}
+		rows = append(rows, row)
+	}
+}
+
+// readJSON reads an array of objects whose values are strings, numbers,
+// booleans, null or arrays of those, which are joined with semicolons.
+func readJSON(in io.Reader) ([]map[string]string, error) {
+	decoder := json.NewDecoder(in)
+	decoder.UseNumber()
+	var objects []map[string]any
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("expected an array of objects: %w", err)
+	}
+	rows := make([]map[string]string, 0, len(objects))
+	for i, object := range objects {
+		row := make(map[string]string, len(object))
+		for column, value := range object {
+			text, err := jsonText(value)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %s %w", i+1, column, err)
+			}
+			row[column] = text
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func jsonText(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return fmt.Sprint(value), nil
+	case []any:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			text, err := jsonText(item)
+			if _, nested := item.([]any); err != nil || nested {
+				return "", fmt.Errorf("cannot hold nested arrays or objects")
+			}
+			items = append(items, text)
+		}
+		return strings.Join(items, ";"), nil
+	}
+	return "", fmt.Errorf("cannot hold an object")
+}
@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"ProjectManagementService/internal/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// trelloBoard is the part of a board exported as JSON that is imported.
+type trelloBoard struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Desc        string          `json:"desc"`
+	Members     []*trelloMember `json:"members"`
+	Memberships []struct {
+		IDMember   string `json:"idMember"`
+		MemberType string `json:"memberType"`
+	} `json:"memberships"`
+	Lists []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"lists"`
+	Cards []struct {
+		ID          string     `json:"id"`
+		Name        string     `json:"name"`
+		Desc        string     `json:"desc"`
+		Closed      bool       `json:"closed"`
+		IDList      string     `json:"idList"`
+		IDMembers   []string   `json:"idMembers"`
+		Due         *time.Time `json:"due"`
+		DueComplete bool       `json:"dueComplete"`
+		Labels      []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+	Actions []struct {
+		ID              string    `json:"id"`
+		Type            string    `json:"type"`
+		Date            time.Time `json:"date"`
+		IDMemberCreator string    `json:"idMemberCreator"`
+		Data            struct {
+			Text string `json:"text"`
+			Card struct {
+				ID string `json:"id"`
+			} `json:"card"`
+		} `json:"data"`
+	} `json:"actions"`
+}
+
+type trelloMember struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	FullName string `json:"fullName"`
+}
+
+// readTrello reads a board exported as JSON. Archived cards are left out;
+// the status of a card comes from the name of its list, and cards whose due
+// date is marked complete are done.
+func readTrello(in io.Reader, export *Export) error {
+	var trello trelloBoard
+	if err := json.NewDecoder(in).Decode(&trello); err != nil {
+		return fmt.Errorf("invalid Trello board: %w", err)
+	}
+	if trello.ID == "" {
+		return fmt.Errorf("invalid Trello board: no board id")
+	}
+	members := make(map[string]string, len(trello.Members))
+	for _, member := range trello.Members {
+		members[member.ID] = export.person(member.Username, member.FullName)
+	}
+	lists := make(map[string]string, len(trello.Lists))
+	for _, list := range trello.Lists {
+		lists[list.ID] = list.Name
+	}
+	board := &Board{ID: trello.ID, Title: trello.Name, Description: trello.Desc}
+	for _, membership := range trello.Memberships {
+		if membership.MemberType == "admin" && members[membership.IDMember] != "" {
+			board.Owner = members[membership.IDMember]
+			break
+		}
+	}
+
+	comments := make(map[string][]*IssueComment)
+	for _, action := range trello.Actions {
+		if action.Type != "commentCard" {
+			continue
+		}
+		comments[action.Data.Card.ID] = append(comments[action.Data.Card.ID], &IssueComment{
+			ID:      action.ID,
+			Author:  members[action.IDMemberCreator],
+			Body:    action.Data.Text,
+			Created: action.Date,
+		})
+	}
+	for _, card := range trello.Cards {
+		if card.Closed {
+			continue
+		}
+		issue := &Issue{
+			ID:          card.ID,
+			Title:       card.Name,
+			Description: card.Desc,
+			Status:      statusOf(lists[card.IDList]),
+			Labels:      make([]string, 0, len(card.Labels)),
+			Created:     dateOf(trelloCreated(card.ID)),
+		}
+		for _, label := range card.Labels {
+			// labels may have only a color
+			issue.Labels = append(issue.Labels, first(label.Name, label.Color))
+		}
+		issue.Priority = labelPriority(issue.Labels)
+		if card.DueComplete {
+			issue.Status = models.Done
+		}
+		if card.Due != nil {
+			issue.Due = dateOf(*card.Due)
+		}
+		if len(card.IDMembers) > 0 {
+			issue.Assignee = members[card.IDMembers[0]]
+		}
+		// actions are exported newest first
+		cardComments := comments[card.ID]
+		for i := len(cardComments) - 1; i >= 0; i-- {
+			issue.Comments = append(issue.Comments, cardComments[i])
+		}
+		board.Issues = append(board.Issues, issue)
+	}
+	export.Boards = append(export.Boards, board)
+	return nil
+}
+
+// trelloCreated returns when a card was created, which Trello ids start
+// with as seconds since 1970 in hexadecimal.
+func trelloCreated(id string) time.Time {
+	if len(id) < 8 {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
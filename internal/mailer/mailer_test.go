@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"bufio"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// smtpStandIn accepts a single SMTP session and returns the received DATA.
+func smtpStandIn(t *testing.T) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := make(chan string, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+		text.PrintfLine("220 localhost ESMTP stand-in")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch command {
+			case "EHLO", "HELO":
+				text.PrintfLine("250 localhost")
+			case "DATA":
+				text.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+				data, err := text.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				received <- string(data)
+				text.PrintfLine("250 OK")
+			case "QUIT":
+				text.PrintfLine("221 bye")
+				return
+			default:
+				text.PrintfLine("250 OK")
+			}
+		}
+	}()
+	return listener.Addr().String(), received
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	addr, received := smtpStandIn(t)
+	host, port, _ := net.SplitHostPort(addr)
+	mailer := NewSMTPMailer(host, port, "", "", "pms@example.com")
+
+	message, err := Render("assignment", "jane@example.com", map[string]any{
+		"Name": "Jane", "TaskID": 7, "Title": "Review <budget>", "Priority": "high", "Status": "new", "DueDate": "2021-09-30",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message.Subject != `You were assigned to "Review <budget>"` {
+		t.Errorf("unexpected subject: %q", message.Subject)
+	}
+	if err := mailer.Send(message); err != nil {
+		t.Fatal(err)
+	}
+
+	data := <-received
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(data)))
+	header, err := reader.ReadMIMEHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Get("To") != "jane@example.com" || header.Get("From") != "pms@example.com" {
+		t.Errorf("unexpected headers: %v", header)
+	}
+	if !strings.HasPrefix(header.Get("Content-Type"), "multipart/alternative") {
+		t.Errorf("unexpected content type: %v", header.Get("Content-Type"))
+	}
+	for _, expected := range []string{"text/plain", "text/html", "Due date: 2021-09-30", "Review &lt;budget&gt;"} {
+		if !strings.Contains(data, expected) {
+			t.Errorf("message does not contain %q:\n%s", expected, data)
+		}
+	}
+}
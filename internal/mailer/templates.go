@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// Render builds a message from templates/<name>.txt.tmpl and
+// templates/<name>.html.tmpl. The text template must define a "subject" block.
+func Render(name string, to string, data any) (*Message, error) {
+	textTemplate, err := texttemplate.ParseFS(templateFiles, "templates/"+name+".txt.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	htmlTemplate, err := htmltemplate.ParseFS(templateFiles, "templates/"+name+".html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := textTemplate.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := textTemplate.ExecuteTemplate(&text, name+".txt.tmpl", data); err != nil {
+		return nil, err
+	}
+	if err := htmlTemplate.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		return nil, err
+	}
+	return &Message{
+		To:      []string{to},
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
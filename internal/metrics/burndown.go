@@ -0,0 +1,118 @@
+// Package metrics computes agile charts and statistics from tasks and their
+// status history.
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"time"
+)
+
+// BurndownPoint is the state of a sprint at the end of a day. Scope and
+// Remaining are nil for days that have not ended yet.
+type BurndownPoint struct {
+	Date      string  `json:"date" example:"2021-09-06"`
+	Scope     *int    `json:"scope"`
+	Remaining *int    `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+type Burndown struct {
+	SprintID int             `json:"sprint_id"`
+	Days     []BurndownPoint `json:"days"`
+}
+
+// NewBurndown counts, for every day of the sprint up to today, the tasks that
+// existed (scope) and were not done (remaining) at the end of that day. The
+// ideal line goes from the scope of the first day down to zero on the last.
+func NewBurndown(sprint *models.Sprint, tasks []*models.Task, history []*models.StatusChange, now time.Time) (*Burndown, error) {
+	start, err := models.ParseDate(sprint.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := models.ParseDate(sprint.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	timelines := Timelines(tasks, history)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(end.Sub(start).Hours()/24) + 1
+	burndown := &Burndown{SprintID: sprint.ID, Days: make([]BurndownPoint, 0, days)}
+	initialScope := len(tasks)
+	for i := 0; i < days; i++ {
+		day := start.AddDate(0, 0, i)
+		point := BurndownPoint{Date: day.Format(models.DateLayout)}
+		if !day.After(today) {
+			scope, remaining := 0, 0
+			for _, timeline := range timelines {
+				status, exists := timeline.StatusAt(day.AddDate(0, 0, 1))
+				if !exists {
+					continue
+				}
+				scope++
+				if status != models.Done {
+					remaining++
+				}
+			}
+			if i == 0 {
+				initialScope = scope
+			}
+			point.Scope, point.Remaining = &scope, &remaining
+		}
+		burndown.Days = append(burndown.Days, point)
+	}
+	for i := range burndown.Days {
+		if days == 1 {
+			break
+		}
+		burndown.Days[i].Ideal = float64(initialScope) * float64(days-1-i) / float64(days-1)
+	}
+	return burndown, nil
+}
+
+// Timeline is the status history of one task, oldest change first.
+type Timeline struct {
+	Task    *models.Task
+	Changes []*models.StatusChange
+}
+
+// Timelines groups history, ordered by time, by task. A task without any
+// recorded change gets its current status from its creation date.
+func Timelines(tasks []*models.Task, history []*models.StatusChange) []*Timeline {
+	byTask := make(map[int]*Timeline, len(tasks))
+	timelines := make([]*Timeline, 0, len(tasks))
+	for _, task := range tasks {
+		timeline := &Timeline{Task: task}
+		byTask[task.ID] = timeline
+		timelines = append(timelines, timeline)
+	}
+	for _, change := range history {
+		if timeline, ok := byTask[change.TaskID]; ok {
+			timeline.Changes = append(timeline.Changes, change)
+		}
+	}
+	for _, timeline := range timelines {
+		if len(timeline.Changes) > 0 {
+			continue
+		}
+		created, err := models.ParseDate(timeline.Task.CreationDate)
+		if err != nil {
+			continue
+		}
+		timeline.Changes = []*models.StatusChange{{TaskID: timeline.Task.ID, Status: timeline.Task.Status, ChangedAt: created}}
+	}
+	return timelines
+}
+
+// StatusAt returns the status the task had just before t, and false when the
+// task did not exist yet.
+func (t *Timeline) StatusAt(at time.Time) (models.StatusEnum, bool) {
+	var status models.StatusEnum
+	exists := false
+	for _, change := range t.Changes {
+		if !change.ChangedAt.Before(at) {
+			break
+		}
+		status, exists = change.Status, true
+	}
+	return status, exists
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"testing"
+	"time"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNewBurndown(t *testing.T) {
+	sprint := &models.Sprint{ID: 1, StartDate: "2021-09-06T00:00:00Z", EndDate: "2021-09-10T00:00:00Z"}
+	tasks := []*models.Task{
+		{ID: 1, Status: models.Done},
+		{ID: 2, Status: models.Done},
+		{ID: 3, Status: models.InProgress},
+		// added to the sprint scope on the second day
+		{ID: 4, Status: models.New},
+		// no history recorded, falls back to the creation date
+		{ID: 5, Status: models.New, CreationDate: "2021-09-01T00:00:00Z"},
+	}
+	history := []*models.StatusChange{
+		{TaskID: 1, Status: models.New, ChangedAt: at("2021-09-01 10:00")},
+		{TaskID: 1, Status: models.Done, ChangedAt: at("2021-09-06 16:00")},
+		{TaskID: 2, Status: models.New, ChangedAt: at("2021-09-02 10:00")},
+		{TaskID: 2, Status: models.InProgress, ChangedAt: at("2021-09-06 09:00")},
+		{TaskID: 2, Status: models.Done, ChangedAt: at("2021-09-08 00:00")},
+		{TaskID: 3, Status: models.New, ChangedAt: at("2021-09-03 10:00")},
+		{TaskID: 3, Status: models.InProgress, ChangedAt: at("2021-09-07 11:00")},
+		{TaskID: 4, Status: models.New, ChangedAt: at("2021-09-07 12:00")},
+	}
+
+	burndown, err := NewBurndown(sprint, tasks, history, at("2021-09-08 15:00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		date      string
+		scope     int
+		remaining int
+		ideal     float64
+	}{
+		{"2021-09-06", 4, 3, 4},
+		{"2021-09-07", 5, 4, 3},
+		{"2021-09-08", 5, 3, 2},
+		{"2021-09-09", -1, -1, 1},
+		{"2021-09-10", -1, -1, 0},
+	}
+	if len(burndown.Days) != len(expected) {
+		t.Fatalf("unexpected number of days: got %d want %d", len(burndown.Days), len(expected))
+	}
+	for i, want := range expected {
+		got := burndown.Days[i]
+		if got.Date != want.date || got.Ideal != want.ideal {
+			t.Errorf("day %d: got %s ideal %v, want %s ideal %v", i, got.Date, got.Ideal, want.date, want.ideal)
+		}
+		if want.scope < 0 {
+			if got.Scope != nil || got.Remaining != nil {
+				t.Errorf("%s: future day has data", want.date)
+			}
+			continue
+		}
+		if got.Scope == nil || got.Remaining == nil {
+			t.Fatalf("%s: missing data", want.date)
+		}
+		if *got.Scope != want.scope || *got.Remaining != want.remaining {
+			t.Errorf("%s: got scope %d remaining %d, want scope %d remaining %d", want.date, *got.Scope, *got.Remaining, want.scope, want.remaining)
+		}
+	}
+}
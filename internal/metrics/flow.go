@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"math"
+	"sort"
+	"time"
+)
+
+// Percentiles summarizes durations, in days, with the nearest-rank method.
+// All values are zero when Count is.
+type Percentiles struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	P50     float64 `json:"p50"`
+	P85     float64 `json:"p85"`
+	P95     float64 `json:"p95"`
+	Max     float64 `json:"max"`
+}
+
+// CompletedTask is one point of the cycle and lead time distributions.
+// CycleTimeDays is nil for tasks that were never in progress.
+type CompletedTask struct {
+	TaskID        int      `json:"task_id"`
+	Title         string   `json:"title"`
+	CompletedAt   string   `json:"completed_at"`
+	LeadTimeDays  float64  `json:"lead_time_days"`
+	CycleTimeDays *float64 `json:"cycle_time_days"`
+}
+
+type WeeklyThroughput struct {
+	WeekStart string `json:"week_start" example:"2021-09-06"`
+	Completed int    `json:"completed"`
+}
+
+// FlowPoint counts the tasks in each status at the end of a day.
+type FlowPoint struct {
+	Date     string                    `json:"date" example:"2021-09-06"`
+	ByStatus map[models.StatusEnum]int `json:"by_status"`
+}
+
+type Flow struct {
+	ProjectID      int                 `json:"project_id"`
+	From           string              `json:"from"`
+	To             string              `json:"to"`
+	LeadTime       Percentiles         `json:"lead_time"`
+	CycleTime      Percentiles         `json:"cycle_time"`
+	Throughput     []*WeeklyThroughput `json:"throughput"`
+	CumulativeFlow []*FlowPoint        `json:"cumulative_flow"`
+	Completed      []*CompletedTask    `json:"completed"`
+}
+
+// NewFlow measures the tasks completed from from to to, both days included:
+// lead time runs from creation to done and cycle time from the first move to
+// in progress to done. A task counts as completed on its last move to done,
+// and only if it is still done at the end of the range, so reopened work is
+// counted once. Throughput is per week starting on Monday; the first and last
+// weeks may be partial.
+func NewFlow(projectID int, tasks []*models.Task, history []*models.StatusChange, from, to time.Time) *Flow {
+	end := to.AddDate(0, 0, 1)
+	flow := &Flow{
+		ProjectID:      projectID,
+		From:           from.Format(models.DateLayout),
+		To:             to.Format(models.DateLayout),
+		Throughput:     make([]*WeeklyThroughput, 0),
+		CumulativeFlow: make([]*FlowPoint, 0),
+		Completed:      make([]*CompletedTask, 0),
+	}
+	weeks := make(map[string]*WeeklyThroughput)
+	for week := models.WeekStart(from); week.Before(end); week = week.AddDate(0, 0, 7) {
+		throughput := &WeeklyThroughput{WeekStart: week.Format(models.DateLayout)}
+		weeks[throughput.WeekStart] = throughput
+		flow.Throughput = append(flow.Throughput, throughput)
+	}
+
+	timelines := Timelines(tasks, history)
+	leadTimes := make([]float64, 0)
+	cycleTimes := make([]float64, 0)
+	for _, timeline := range timelines {
+		completed, ok := timeline.completion(from, end)
+		if !ok {
+			continue
+		}
+		leadTimes = append(leadTimes, completed.LeadTimeDays)
+		if completed.CycleTimeDays != nil {
+			cycleTimes = append(cycleTimes, *completed.CycleTimeDays)
+		}
+		doneAt, _ := models.ParseDate(completed.CompletedAt)
+		weeks[models.WeekStart(doneAt).Format(models.DateLayout)].Completed++
+		flow.Completed = append(flow.Completed, completed)
+	}
+	sort.SliceStable(flow.Completed, func(i, j int) bool {
+		return flow.Completed[i].CompletedAt < flow.Completed[j].CompletedAt
+	})
+	flow.LeadTime = NewPercentiles(leadTimes)
+	flow.CycleTime = NewPercentiles(cycleTimes)
+
+	for day := from; day.Before(end); day = day.AddDate(0, 0, 1) {
+		point := &FlowPoint{
+			Date:     day.Format(models.DateLayout),
+			ByStatus: map[models.StatusEnum]int{models.New: 0, models.InProgress: 0, models.Done: 0},
+		}
+		for _, timeline := range timelines {
+			if status, exists := timeline.StatusAt(day.AddDate(0, 0, 1)); exists {
+				point.ByStatus[status]++
+			}
+		}
+		flow.CumulativeFlow = append(flow.CumulativeFlow, point)
+	}
+	return flow
+}
+
+// completion returns the task as completed when it was last moved to done
+// between from and end, and not moved out of done before end.
+func (t *Timeline) completion(from, end time.Time) (*CompletedTask, bool) {
+	var last *models.StatusChange
+	for _, change := range t.Changes {
+		if !change.ChangedAt.Before(end) {
+			break
+		}
+		last = change
+	}
+	if last == nil || last.Status != models.Done || last.ChangedAt.Before(from) {
+		return nil, false
+	}
+	completed := &CompletedTask{
+		TaskID:       t.Task.ID,
+		Title:        t.Task.Title,
+		CompletedAt:  last.ChangedAt.UTC().Format(time.RFC3339),
+		LeadTimeDays: days(last.ChangedAt.Sub(t.Changes[0].ChangedAt)),
+	}
+	for _, change := range t.Changes {
+		if change.Status == models.InProgress && change.ChangedAt.Before(last.ChangedAt) {
+			cycleTime := days(last.ChangedAt.Sub(change.ChangedAt))
+			completed.CycleTimeDays = &cycleTime
+			break
+		}
+	}
+	return completed, true
+}
+
+// NewPercentiles summarizes values, in days.
+func NewPercentiles(values []float64) Percentiles {
+	percentiles := Percentiles{Count: len(values)}
+	if len(values) == 0 {
+		return percentiles
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := func(p float64) float64 {
+		return sorted[int(math.Ceil(p/100*float64(len(sorted))))-1]
+	}
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+	percentiles.Average = round(sum / float64(len(sorted)))
+	percentiles.P50 = rank(50)
+	percentiles.P85 = rank(85)
+	percentiles.P95 = rank(95)
+	percentiles.Max = sorted[len(sorted)-1]
+	return percentiles
+}
+
+func days(duration time.Duration) float64 {
+	return round(duration.Hours() / 24)
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"testing"
+)
+
+func TestNewFlow(t *testing.T) {
+	tasks := []*models.Task{
+		{ID: 1, Status: models.Done},
+		{ID: 2, Status: models.Done},
+		{ID: 3, Status: models.Done},
+		{ID: 4, Status: models.InProgress},
+		{ID: 5, Status: models.Done},
+		{ID: 6, Status: models.New, CreationDate: "2021-09-20T00:00:00Z"},
+	}
+	history := []*models.StatusChange{
+		{TaskID: 1, Status: models.New, ChangedAt: at("2021-09-01 00:00")},
+		{TaskID: 1, Status: models.InProgress, ChangedAt: at("2021-09-06 12:00")},
+		{TaskID: 1, Status: models.Done, ChangedAt: at("2021-09-08 12:00")},
+		// done without being in progress
+		{TaskID: 2, Status: models.New, ChangedAt: at("2021-09-07 00:00")},
+		{TaskID: 2, Status: models.Done, ChangedAt: at("2021-09-10 00:00")},
+		// done before the range
+		{TaskID: 3, Status: models.New, ChangedAt: at("2021-09-02 00:00")},
+		{TaskID: 3, Status: models.InProgress, ChangedAt: at("2021-09-03 00:00")},
+		{TaskID: 3, Status: models.Done, ChangedAt: at("2021-09-05 00:00")},
+		// reopened
+		{TaskID: 4, Status: models.New, ChangedAt: at("2021-09-06 00:00")},
+		{TaskID: 4, Status: models.InProgress, ChangedAt: at("2021-09-13 00:00")},
+		{TaskID: 4, Status: models.Done, ChangedAt: at("2021-09-14 00:00")},
+		{TaskID: 4, Status: models.InProgress, ChangedAt: at("2021-09-15 00:00")},
+		{TaskID: 5, Status: models.New, ChangedAt: at("2021-09-13 00:00")},
+		{TaskID: 5, Status: models.InProgress, ChangedAt: at("2021-09-14 00:00")},
+		{TaskID: 5, Status: models.Done, ChangedAt: at("2021-09-16 00:00")},
+	}
+	flow := NewFlow(7, tasks, history, at("2021-09-06 00:00"), at("2021-09-19 00:00"))
+
+	if want := (Percentiles{Count: 3, Average: 4.5, P50: 3, P85: 7.5, P95: 7.5, Max: 7.5}); flow.LeadTime != want {
+		t.Errorf("lead time %+v, want %+v", flow.LeadTime, want)
+	}
+	if want := (Percentiles{Count: 2, Average: 2, P50: 2, P85: 2, P95: 2, Max: 2}); flow.CycleTime != want {
+		t.Errorf("cycle time %+v, want %+v", flow.CycleTime, want)
+	}
+	completed := make([]int, 0)
+	for _, task := range flow.Completed {
+		completed = append(completed, task.TaskID)
+	}
+	if len(completed) != 3 || completed[0] != 1 || completed[1] != 2 || completed[2] != 5 || flow.Completed[1].CycleTimeDays != nil {
+		t.Errorf("unexpected completed tasks %v", completed)
+	}
+	if len(flow.Throughput) != 2 || flow.Throughput[0].Completed != 2 || flow.Throughput[1].Completed != 1 {
+		t.Errorf("unexpected throughput %+v %+v", flow.Throughput[0], flow.Throughput[1])
+	}
+
+	if len(flow.CumulativeFlow) != 14 {
+		t.Fatalf("%d days of cumulative flow, want 14", len(flow.CumulativeFlow))
+	}
+	first, last := flow.CumulativeFlow[0], flow.CumulativeFlow[13]
+	if first.Date != "2021-09-06" || first.ByStatus[models.New] != 1 || first.ByStatus[models.InProgress] != 1 || first.ByStatus[models.Done] != 1 {
+		t.Errorf("unexpected first day %+v", first)
+	}
+	if last.Date != "2021-09-19" || last.ByStatus[models.New] != 0 || last.ByStatus[models.InProgress] != 1 || last.ByStatus[models.Done] != 4 {
+		t.Errorf("unexpected last day %+v", last)
+	}
+}
+
+func TestNewPercentiles(t *testing.T) {
+	values := []float64{9, 1, 2, 3, 4, 5, 6, 7, 8, 10}
+	if got := NewPercentiles(values); got.P50 != 5 || got.P85 != 9 || got.P95 != 10 || got.Average != 5.5 {
+		t.Errorf("unexpected percentiles %+v", got)
+	}
+	if got := NewPercentiles(nil); got != (Percentiles{}) {
+		t.Errorf("unexpected percentiles of nothing %+v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// ForecastHorizonDays is how far a forecast looks ahead. Completion dates
+// beyond it are left empty.
+const ForecastHorizonDays = 5 * 365
+
+// ForecastConfidences are the levels a forecast gives completion dates for.
+var ForecastConfidences = []int{50, 85, 95}
+
+// ForecastDate is the day by which the remaining tasks are done in
+// Confidence percent of the simulations. Date is empty beyond the horizon.
+type ForecastDate struct {
+	Confidence int    `json:"confidence" example:"85"`
+	Date       string `json:"date" example:"2021-10-15"`
+}
+
+type Forecast struct {
+	ProjectID      int `json:"project_id"`
+	RemainingTasks int `json:"remaining_tasks"`
+	// HistoryFrom and HistoryTo are the days whose throughput is sampled.
+	HistoryFrom string `json:"history_from"`
+	HistoryTo   string `json:"history_to"`
+	// Completed is the number of tasks completed in the history.
+	Completed int   `json:"completed"`
+	Trials    int   `json:"trials"`
+	Seed      int64 `json:"seed"`
+	// Dates has one entry per ForecastConfidences level.
+	Dates []*ForecastDate `json:"dates"`
+}
+
+// DailyThroughput counts the tasks completed on each day from from to to,
+// both included, as NewFlow counts them.
+func DailyThroughput(tasks []*models.Task, history []*models.StatusChange, from, to time.Time) []int {
+	end := to.AddDate(0, 0, 1)
+	throughput := make([]int, max(int(end.Sub(from).Hours()/24), 0))
+	for _, timeline := range Timelines(tasks, history) {
+		if completed, ok := timeline.completion(from, end); ok {
+			doneAt, _ := time.Parse(time.RFC3339, completed.CompletedAt)
+			throughput[int(doneAt.Sub(from).Hours()/24)]++
+		}
+	}
+	return throughput
+}
+
+// Simulate runs trials simulations in which each day, starting on start,
+// completes as many tasks as a day drawn at random from throughput, until
+// remaining tasks are done. The same random source gives the same dates.
+// throughput must have at least one completed task unless remaining is zero.
+func Simulate(throughput []int, remaining int, start time.Time, trials int, random *rand.Rand) []*ForecastDate {
+	durations := make([]int, trials)
+	for trial := range durations {
+		done, day := 0, 0
+		for ; done < remaining && day <= ForecastHorizonDays; day++ {
+			done += throughput[random.Intn(len(throughput))]
+		}
+		// the last day drawn is the day the work was done on
+		durations[trial] = max(day-1, 0)
+	}
+	sort.Ints(durations)
+	dates := make([]*ForecastDate, 0, len(ForecastConfidences))
+	for _, confidence := range ForecastConfidences {
+		date := &ForecastDate{Confidence: confidence}
+		index := int(math.Ceil(float64(confidence)/100*float64(trials))) - 1
+		if days := durations[max(index, 0)]; days < ForecastHorizonDays {
+			date.Date = start.AddDate(0, 0, days).Format(models.DateLayout)
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDailyThroughput(t *testing.T) {
+	tasks := []*models.Task{{ID: 1, Status: models.Done}, {ID: 2, Status: models.Done}, {ID: 3, Status: models.Done}}
+	history := []*models.StatusChange{
+		{TaskID: 1, Status: models.New, ChangedAt: at("2021-09-01 00:00")},
+		{TaskID: 1, Status: models.Done, ChangedAt: at("2021-09-06 15:00")},
+		{TaskID: 2, Status: models.New, ChangedAt: at("2021-09-01 00:00")},
+		{TaskID: 2, Status: models.Done, ChangedAt: at("2021-09-08 09:00")},
+		{TaskID: 3, Status: models.New, ChangedAt: at("2021-09-01 00:00")},
+		{TaskID: 3, Status: models.Done, ChangedAt: at("2021-09-08 10:00")},
+	}
+	got := DailyThroughput(tasks, history, at("2021-09-06 00:00"), at("2021-09-09 00:00"))
+	if want := []int{1, 0, 2, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DailyThroughput = %v, want %v", got, want)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	start := at("2021-09-06 00:00")
+	// one task a day leaves no room for chance
+	dates := Simulate([]int{1, 1, 1}, 10, start, 100, rand.New(rand.NewSource(1)))
+	for _, date := range dates {
+		if date.Date != "2021-09-15" {
+			t.Errorf("%d%%: %s, want 2021-09-15", date.Confidence, date.Date)
+		}
+	}
+
+	throughput := []int{0, 0, 1, 3, 0, 2, 0, 0, 1, 0}
+	first := Simulate(throughput, 20, start, 1000, rand.New(rand.NewSource(42)))
+	second := Simulate(throughput, 20, start, 1000, rand.New(rand.NewSource(42)))
+	if !reflect.DeepEqual(first, second) {
+		t.Error("the same seed gave different forecasts")
+	}
+	if len(first) != 3 || first[0].Date > first[1].Date || first[1].Date > first[2].Date {
+		t.Errorf("confidence dates are not increasing: %v %v %v", first[0], first[1], first[2])
+	}
+
+	if dates := Simulate(throughput, 0, start, 10, rand.New(rand.NewSource(1))); dates[2].Date != "2021-09-06" {
+		t.Errorf("nothing remaining is done on %s, want today", dates[2].Date)
+	}
+	if dates := Simulate([]int{0, 1}, 10000, start, 10, rand.New(rand.NewSource(1))); dates[0].Date != "" {
+		t.Errorf("forecast beyond the horizon on %s", dates[0].Date)
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"fmt"
+	"math"
+)
+
+const (
+	HealthGreen = "green"
+	HealthAmber = "amber"
+	HealthRed   = "red"
+)
+
+// HealthThresholds decide when a project turns amber or red: on the share of
+// its open tasks that are overdue, in percent, or on the average age of its
+// open tasks, in days. A project reaching either threshold takes its colour.
+type HealthThresholds struct {
+	OverdueAmber float64 `json:"overdue_amber" example:"10"`
+	OverdueRed   float64 `json:"overdue_red" example:"25"`
+	AgeAmber     float64 `json:"age_amber" example:"30"`
+	AgeRed       float64 `json:"age_red" example:"60"`
+}
+
+var DefaultHealthThresholds = HealthThresholds{OverdueAmber: 10, OverdueRed: 25, AgeAmber: 30, AgeRed: 60}
+
+func (t HealthThresholds) Validate() error {
+	if t.OverdueAmber < 0 || t.AgeAmber < 0 {
+		return fmt.Errorf("thresholds cannot be negative")
+	}
+	if t.OverdueAmber > t.OverdueRed || t.AgeAmber > t.AgeRed {
+		return fmt.Errorf("amber thresholds cannot be above red ones")
+	}
+	return nil
+}
+
+// Portfolio is the health report of a set of projects.
+type Portfolio struct {
+	Thresholds HealthThresholds        `json:"thresholds"`
+	ByHealth   map[string]int          `json:"by_health"`
+	Projects   []*models.ProjectReport `json:"projects"`
+}
+
+// NewPortfolio scores each project against thresholds. Completed projects
+// and projects without open tasks are green.
+func NewPortfolio(reports []*models.ProjectReport, thresholds HealthThresholds) *Portfolio {
+	portfolio := &Portfolio{
+		Thresholds: thresholds,
+		ByHealth:   map[string]int{HealthGreen: 0, HealthAmber: 0, HealthRed: 0},
+		Projects:   reports,
+	}
+	for _, report := range reports {
+		report.Health, report.HealthReasons = thresholds.assess(report)
+		portfolio.ByHealth[report.Health]++
+	}
+	return portfolio
+}
+
+func (t HealthThresholds) assess(report *models.ProjectReport) (string, []string) {
+	reasons := make([]string, 0)
+	if report.CompletionDate != "" || report.Open == 0 {
+		return HealthGreen, reasons
+	}
+	health := HealthGreen
+	raise := func(level string, reason string) {
+		if level == HealthRed || health == HealthGreen {
+			health = level
+		}
+		reasons = append(reasons, reason)
+	}
+	overdue := math.Round(float64(report.Overdue)*1000/float64(report.Open)) / 10
+	switch {
+	case overdue >= t.OverdueRed:
+		raise(HealthRed, fmt.Sprintf("%.1f%% of open tasks are overdue (red at %.1f%%)", overdue, t.OverdueRed))
+	case overdue >= t.OverdueAmber:
+		raise(HealthAmber, fmt.Sprintf("%.1f%% of open tasks are overdue (amber at %.1f%%)", overdue, t.OverdueAmber))
+	}
+	switch age := report.AverageOpenAgeDays; {
+	case age >= t.AgeRed:
+		raise(HealthRed, fmt.Sprintf("open tasks are %.1f days old on average (red at %.1f)", age, t.AgeRed))
+	case age >= t.AgeAmber:
+		raise(HealthAmber, fmt.Sprintf("open tasks are %.1f days old on average (amber at %.1f)", age, t.AgeAmber))
+	}
+	return health, reasons
+}
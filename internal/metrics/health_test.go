@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"testing"
+)
+
+func TestNewPortfolio(t *testing.T) {
+	reports := []*models.ProjectReport{
+		{ProjectID: 1, Total: 20, Open: 10, Overdue: 0, AverageOpenAgeDays: 12},
+		{ProjectID: 2, Total: 20, Open: 10, Overdue: 1, AverageOpenAgeDays: 45},
+		{ProjectID: 3, Total: 20, Open: 8, Overdue: 2, AverageOpenAgeDays: 61},
+		{ProjectID: 4, Total: 20, Open: 0},
+		{ProjectID: 5, Total: 20, Open: 4, Overdue: 4, CompletionDate: "2021-09-01"},
+	}
+	portfolio := NewPortfolio(reports, DefaultHealthThresholds)
+
+	want := []struct {
+		health  string
+		reasons int
+	}{
+		{HealthGreen, 0},
+		// 10% overdue and 45 days old are both amber
+		{HealthAmber, 2},
+		// 25% overdue is red on its own
+		{HealthRed, 2},
+		{HealthGreen, 0},
+		{HealthGreen, 0},
+	}
+	for i, report := range portfolio.Projects {
+		if report.Health != want[i].health || len(report.HealthReasons) != want[i].reasons {
+			t.Errorf("project %d: %s %v, want %s with %d reasons", report.ProjectID, report.Health, report.HealthReasons, want[i].health, want[i].reasons)
+		}
+	}
+	if portfolio.ByHealth[HealthGreen] != 3 || portfolio.ByHealth[HealthAmber] != 1 || portfolio.ByHealth[HealthRed] != 1 {
+		t.Errorf("unexpected health counts %v", portfolio.ByHealth)
+	}
+}
+
+func TestHealthThresholdsValidate(t *testing.T) {
+	if err := DefaultHealthThresholds.Validate(); err != nil {
+		t.Errorf("default thresholds are invalid: %v", err)
+	}
+	if err := (HealthThresholds{OverdueAmber: 30, OverdueRed: 20, AgeAmber: 1, AgeRed: 2}).Validate(); err == nil {
+		t.Error("amber above red was accepted")
+	}
+}
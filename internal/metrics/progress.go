@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"math"
+	"time"
+)
+
+// Progress summarizes a group of tasks such as a milestone or an epic.
+// ProjectedDate is empty while nothing has been done yet.
+type Progress struct {
+	Total         int                       `json:"total"`
+	ByStatus      map[models.StatusEnum]int `json:"by_status"`
+	PercentDone   float64                   `json:"percent_done"`
+	ProjectedDate string                    `json:"projected_date"`
+	TargetDate    string                    `json:"target_date,omitempty"`
+	OnTrack       *bool                     `json:"on_track,omitempty"`
+}
+
+// NewProgress counts the tasks by status and projects the date the last of
+// them will be done, at the pace tasks were completed since the first one
+// of the group was created.
+func NewProgress(tasks []*models.Task, history []*models.StatusChange, now time.Time) *Progress {
+	progress := &Progress{
+		Total:    len(tasks),
+		ByStatus: map[models.StatusEnum]int{models.New: 0, models.InProgress: 0, models.Done: 0},
+	}
+	var first, lastDone time.Time
+	for _, timeline := range Timelines(tasks, history) {
+		progress.ByStatus[timeline.Task.Status]++
+		if len(timeline.Changes) == 0 {
+			continue
+		}
+		if created := timeline.Changes[0].ChangedAt; first.IsZero() || created.Before(first) {
+			first = created
+		}
+		if timeline.Task.Status == models.Done {
+			if doneAt := timeline.Changes[len(timeline.Changes)-1].ChangedAt; doneAt.After(lastDone) {
+				lastDone = doneAt
+			}
+		}
+	}
+	done := progress.ByStatus[models.Done]
+	if progress.Total > 0 {
+		progress.PercentDone = math.Round(float64(done)*1000/float64(progress.Total)) / 10
+	}
+	remaining := progress.Total - done
+	switch {
+	case progress.Total > 0 && remaining == 0 && !lastDone.IsZero():
+		progress.ProjectedDate = lastDone.Format(models.DateLayout)
+	case done > 0 && !first.IsZero():
+		elapsed := math.Max(now.Sub(first).Hours()/24, 1)
+		days := math.Ceil(float64(remaining) * elapsed / float64(done))
+		progress.ProjectedDate = now.AddDate(0, 0, int(days)).Format(models.DateLayout)
+	}
+	return progress
+}
+
+// WithTarget records the target date and whether the projection meets it.
+func (p *Progress) WithTarget(targetDate string) *Progress {
+	target, err := models.ParseDate(targetDate)
+	if err != nil {
+		return p
+	}
+	p.TargetDate = target.Format(models.DateLayout)
+	if projected, err := models.ParseDate(p.ProjectedDate); err == nil {
+		onTrack := !projected.After(target)
+		p.OnTrack = &onTrack
+	}
+	return p
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"testing"
+)
+
+func TestNewProgress(t *testing.T) {
+	tasks := []*models.Task{
+		{ID: 1, Status: models.Done},
+		{ID: 2, Status: models.Done},
+		{ID: 3, Status: models.InProgress},
+		{ID: 4, Status: models.New},
+		{ID: 5, Status: models.New},
+	}
+	history := []*models.StatusChange{
+		{TaskID: 1, Status: models.New, ChangedAt: at("2021-09-01 09:00")},
+		{TaskID: 1, Status: models.Done, ChangedAt: at("2021-09-04 09:00")},
+		{TaskID: 2, Status: models.New, ChangedAt: at("2021-09-02 09:00")},
+		{TaskID: 2, Status: models.Done, ChangedAt: at("2021-09-08 09:00")},
+		{TaskID: 3, Status: models.New, ChangedAt: at("2021-09-02 09:00")},
+		{TaskID: 3, Status: models.InProgress, ChangedAt: at("2021-09-09 09:00")},
+		{TaskID: 4, Status: models.New, ChangedAt: at("2021-09-05 09:00")},
+		{TaskID: 5, Status: models.New, ChangedAt: at("2021-09-05 09:00")},
+	}
+
+	// two tasks done in ten days, three to go: fifteen more days
+	progress := NewProgress(tasks, history, at("2021-09-11 09:00")).WithTarget("2021-09-30T00:00:00Z")
+
+	if progress.Total != 5 || progress.ByStatus[models.Done] != 2 || progress.ByStatus[models.InProgress] != 1 || progress.ByStatus[models.New] != 2 {
+		t.Errorf("unexpected counts: %+v", progress)
+	}
+	if progress.PercentDone != 40 {
+		t.Errorf("unexpected percent done: got %v want 40", progress.PercentDone)
+	}
+	if progress.ProjectedDate != "2021-09-26" {
+		t.Errorf("unexpected projected date: got %q want 2021-09-26", progress.ProjectedDate)
+	}
+	if progress.TargetDate != "2021-09-30" || progress.OnTrack == nil || !*progress.OnTrack {
+		t.Errorf("expected to be on track for 2021-09-30: %+v", progress)
+	}
+}
+
+func TestNewProgressNothingDone(t *testing.T) {
+	tasks := []*models.Task{{ID: 1, Status: models.New, CreationDate: "2021-09-01T00:00:00Z"}}
+
+	progress := NewProgress(tasks, nil, at("2021-09-11 09:00")).WithTarget("2021-09-30")
+
+	if progress.ProjectedDate != "" || progress.OnTrack != nil {
+		t.Errorf("expected no projection: %+v", progress)
+	}
+}
+
+func TestNewProgressAllDone(t *testing.T) {
+	tasks := []*models.Task{{ID: 1, Status: models.Done}, {ID: 2, Status: models.Done}}
+	history := []*models.StatusChange{
+		{TaskID: 1, Status: models.Done, ChangedAt: at("2021-09-03 09:00")},
+		{TaskID: 2, Status: models.Done, ChangedAt: at("2021-09-06 17:00")},
+	}
+
+	progress := NewProgress(tasks, history, at("2021-09-11 09:00"))
+
+	if progress.PercentDone != 100 || progress.ProjectedDate != "2021-09-06" {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+}
@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"math"
+)
+
+// TaskVariance compares a task with its baseline. Baseline fields are empty
+// for added tasks and current fields for removed ones. Slips are in days,
+// positive when the task moved later, and missing when either date is.
+type TaskVariance struct {
+	TaskID                  int               `json:"task_id"`
+	Title                   string            `json:"title"`
+	BaselineStatus          models.StatusEnum `json:"baseline_status,omitempty"`
+	Status                  models.StatusEnum `json:"status,omitempty"`
+	BaselineStartDate       string            `json:"baseline_start_date,omitempty"`
+	StartDate               string            `json:"start_date,omitempty"`
+	StartSlipDays           *int              `json:"start_slip_days,omitempty"`
+	BaselineDueDate         string            `json:"baseline_due_date,omitempty"`
+	DueDate                 string            `json:"due_date,omitempty"`
+	DueSlipDays             *int              `json:"due_slip_days,omitempty"`
+	BaselineEstimateMinutes *int              `json:"baseline_estimate_minutes,omitempty"`
+	EstimateMinutes         *int              `json:"estimate_minutes,omitempty"`
+	BaselineStoryPoints     *float64          `json:"baseline_story_points,omitempty"`
+	StoryPoints             *float64          `json:"story_points,omitempty"`
+}
+
+// VarianceSummary adds up the differences. The estimate and story point
+// changes cover added and removed tasks too, so they measure the change of
+// scope; the finish is the latest due date.
+type VarianceSummary struct {
+	TasksAdded            int     `json:"tasks_added"`
+	TasksRemoved          int     `json:"tasks_removed"`
+	TasksChanged          int     `json:"tasks_changed"`
+	TasksSlipped          int     `json:"tasks_slipped"`
+	TasksAhead            int     `json:"tasks_ahead"`
+	BaselineFinish        string  `json:"baseline_finish"`
+	Finish                string  `json:"finish"`
+	FinishSlipDays        *int    `json:"finish_slip_days,omitempty"`
+	EstimateChangeMinutes int     `json:"estimate_change_minutes"`
+	StoryPointChange      float64 `json:"story_point_change"`
+}
+
+type Variance struct {
+	BaselineID   int             `json:"baseline_id"`
+	BaselineName string          `json:"baseline_name"`
+	BaselineDate string          `json:"baseline_date"`
+	Summary      VarianceSummary `json:"summary"`
+	Added        []*TaskVariance `json:"added"`
+	Removed      []*TaskVariance `json:"removed"`
+	Changed      []*TaskVariance `json:"changed"`
+}
+
+// NewVariance compares the tasks of a baseline with the current tasks of the
+// project. Estimates are the original estimates.
+func NewVariance(baseline *models.Baseline, planned []*models.BaselineTask, tasks []*models.Task) *Variance {
+	variance := &Variance{
+		BaselineID:   baseline.ID,
+		BaselineName: baseline.Name,
+		BaselineDate: baseline.CreationDate,
+		Added:        make([]*TaskVariance, 0),
+		Removed:      make([]*TaskVariance, 0),
+		Changed:      make([]*TaskVariance, 0),
+	}
+	summary := &variance.Summary
+	current := make(map[int]*models.Task, len(tasks))
+	var estimate, baselineEstimate int
+	var points, baselinePoints float64
+	for _, task := range tasks {
+		current[task.ID] = task
+		estimate += intValue(task.OriginalEstimateMinutes)
+		points += floatValue(task.StoryPoints)
+		summary.Finish = later(summary.Finish, task.DueDate)
+	}
+	recorded := make(map[int]bool, len(planned))
+	for _, before := range planned {
+		recorded[before.TaskID] = true
+		baselineEstimate += intValue(before.OriginalEstimateMinutes)
+		baselinePoints += floatValue(before.StoryPoints)
+		summary.BaselineFinish = later(summary.BaselineFinish, before.DueDate)
+		taskVariance := &TaskVariance{
+			TaskID:                  before.TaskID,
+			Title:                   before.Title,
+			BaselineStatus:          before.Status,
+			BaselineStartDate:       formatDate(before.StartDate),
+			BaselineDueDate:         formatDate(before.DueDate),
+			BaselineEstimateMinutes: before.OriginalEstimateMinutes,
+			BaselineStoryPoints:     before.StoryPoints,
+		}
+		task, ok := current[before.TaskID]
+		if !ok {
+			variance.Removed = append(variance.Removed, taskVariance)
+			continue
+		}
+		taskVariance.Title = task.Title
+		taskVariance.Status = task.Status
+		taskVariance.StartDate, taskVariance.DueDate = formatDate(task.StartDate), formatDate(task.DueDate)
+		taskVariance.StartSlipDays = slip(before.StartDate, task.StartDate)
+		taskVariance.DueSlipDays = slip(before.DueDate, task.DueDate)
+		taskVariance.EstimateMinutes, taskVariance.StoryPoints = task.OriginalEstimateMinutes, task.StoryPoints
+		if !taskVariance.changed() {
+			continue
+		}
+		variance.Changed = append(variance.Changed, taskVariance)
+		if days := taskVariance.DueSlipDays; days != nil && *days > 0 {
+			summary.TasksSlipped++
+		} else if days != nil && *days < 0 {
+			summary.TasksAhead++
+		}
+	}
+	for _, task := range tasks {
+		if recorded[task.ID] {
+			continue
+		}
+		variance.Added = append(variance.Added, &TaskVariance{
+			TaskID:          task.ID,
+			Title:           task.Title,
+			Status:          task.Status,
+			StartDate:       formatDate(task.StartDate),
+			DueDate:         formatDate(task.DueDate),
+			EstimateMinutes: task.OriginalEstimateMinutes,
+			StoryPoints:     task.StoryPoints,
+		})
+	}
+	summary.TasksAdded, summary.TasksRemoved, summary.TasksChanged = len(variance.Added), len(variance.Removed), len(variance.Changed)
+	summary.FinishSlipDays = slip(summary.BaselineFinish, summary.Finish)
+	summary.EstimateChangeMinutes = estimate - baselineEstimate
+	summary.StoryPointChange = math.Round((points-baselinePoints)*10) / 10
+	return variance
+}
+
+func (v *TaskVariance) changed() bool {
+	return v.Status != v.BaselineStatus ||
+		v.StartDate != v.BaselineStartDate || v.DueDate != v.BaselineDueDate ||
+		intValue(v.EstimateMinutes) != intValue(v.BaselineEstimateMinutes) || (v.EstimateMinutes == nil) != (v.BaselineEstimateMinutes == nil) ||
+		floatValue(v.StoryPoints) != floatValue(v.BaselineStoryPoints) || (v.StoryPoints == nil) != (v.BaselineStoryPoints == nil)
+}
+
+// slip returns the days from one date to the other, nil when either is not
+// set.
+func slip(from, to string) *int {
+	fromDate, err := models.ParseDate(from)
+	if err != nil {
+		return nil
+	}
+	toDate, err := models.ParseDate(to)
+	if err != nil {
+		return nil
+	}
+	days := int(math.Round(toDate.Sub(fromDate).Hours() / 24))
+	return &days
+}
+
+// later returns the later of a date in DateLayout and a date as stored.
+func later(latest, value string) string {
+	if date := formatDate(value); date > latest {
+		return date
+	}
+	return latest
+}
+
+func formatDate(value string) string {
+	date, err := models.ParseDate(value)
+	if err != nil {
+		return ""
+	}
+	return date.Format(models.DateLayout)
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func floatValue(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"testing"
+)
+
+func TestNewVariance(t *testing.T) {
+	hours := func(value int) *int {
+		minutes := value * 60
+		return &minutes
+	}
+	points := func(value float64) *float64 { return &value }
+	baseline := &models.Baseline{ID: 3, Name: "Kick-off", CreationDate: "2021-09-01T10:00:00Z"}
+	planned := []*models.BaselineTask{
+		{TaskID: 1, Title: "Design", Status: models.New, StartDate: "2021-09-01T00:00:00Z", DueDate: "2021-09-03T00:00:00Z", OriginalEstimateMinutes: hours(8)},
+		{TaskID: 2, Title: "Build", Status: models.New, DueDate: "2021-09-10T00:00:00Z", OriginalEstimateMinutes: hours(16), StoryPoints: points(5)},
+		{TaskID: 3, Title: "Legacy export", Status: models.New, DueDate: "2021-09-08T00:00:00Z", OriginalEstimateMinutes: hours(4)},
+		{TaskID: 4, Title: "Docs", Status: models.New, DueDate: "2021-09-09T00:00:00Z"},
+	}
+	tasks := []*models.Task{
+		{ID: 1, Title: "Design", Status: models.Done, StartDate: "2021-09-01", DueDate: "2021-09-02", OriginalEstimateMinutes: hours(8)},
+		{ID: 2, Title: "Build", Status: models.InProgress, DueDate: "2021-09-14", OriginalEstimateMinutes: hours(24), StoryPoints: points(8)},
+		{ID: 4, Title: "Docs", Status: models.New, DueDate: "2021-09-09T00:00:00Z"},
+		{ID: 5, Title: "Audit log", Status: models.New, DueDate: "2021-09-12", OriginalEstimateMinutes: hours(6)},
+	}
+	variance := NewVariance(baseline, planned, tasks)
+
+	summary := variance.Summary
+	if summary.TasksAdded != 1 || summary.TasksRemoved != 1 || summary.TasksChanged != 2 {
+		t.Errorf("added %d, removed %d, changed %d", summary.TasksAdded, summary.TasksRemoved, summary.TasksChanged)
+	}
+	if summary.TasksSlipped != 1 || summary.TasksAhead != 1 {
+		t.Errorf("slipped %d, ahead %d", summary.TasksSlipped, summary.TasksAhead)
+	}
+	if summary.BaselineFinish != "2021-09-10" || summary.Finish != "2021-09-14" || summary.FinishSlipDays == nil || *summary.FinishSlipDays != 4 {
+		t.Errorf("finish moved from %s to %s (%v days)", summary.BaselineFinish, summary.Finish, summary.FinishSlipDays)
+	}
+	// +8h on build, +6h for the audit log, -4h for the legacy export
+	if summary.EstimateChangeMinutes != 10*60 || summary.StoryPointChange != 3 {
+		t.Errorf("estimate change %d minutes, %v points", summary.EstimateChangeMinutes, summary.StoryPointChange)
+	}
+	if variance.Removed[0].TaskID != 3 || variance.Added[0].TaskID != 5 {
+		t.Errorf("removed %d, added %d", variance.Removed[0].TaskID, variance.Added[0].TaskID)
+	}
+	build := variance.Changed[1]
+	if build.TaskID != 2 || build.DueSlipDays == nil || *build.DueSlipDays != 4 || build.StartSlipDays != nil {
+		t.Errorf("unexpected variance for build: %+v", build)
+	}
+}
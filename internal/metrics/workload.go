@@ -0,0 +1,169 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"math"
+	"time"
+)
+
+// WeekLoad compares the work planned for a user in one week with the
+// hours the user is available.
+type WeekLoad struct {
+	WeekStart          string  `json:"week_start" example:"2021-09-06"`
+	TimeOffDays        int     `json:"time_off_days"`
+	CapacityHours      float64 `json:"capacity_hours"`
+	AssignedHours      float64 `json:"assigned_hours"`
+	UtilizationPercent float64 `json:"utilization_percent"`
+	OverAllocated      bool    `json:"over_allocated"`
+}
+
+// UserWorkload is the load of one user over the weeks of a Workload.
+// UnscheduledHours is estimated work without a due date; UnestimatedTasks
+// counts open tasks without any estimate.
+type UserWorkload struct {
+	UserID             int         `json:"user_id"`
+	Name               string      `json:"name"`
+	Team               string      `json:"team"`
+	WeeklyHours        float64     `json:"weekly_hours"`
+	Weeks              []*WeekLoad `json:"weeks"`
+	OverAllocatedWeeks int         `json:"over_allocated_weeks"`
+	UnscheduledHours   float64     `json:"unscheduled_hours"`
+	UnestimatedTasks   int         `json:"unestimated_tasks"`
+}
+
+type Workload struct {
+	From  string          `json:"from"`
+	To    string          `json:"to"`
+	Users []*UserWorkload `json:"users"`
+	// OverAllocated counts the users over capacity in at least one week.
+	OverAllocated int `json:"over_allocated"`
+}
+
+// NewWorkload spreads the remaining estimate of each open task (the original
+// one when no remaining estimate is set) evenly over the working days from
+// its start, or today if later, to its due date. Work that is overdue falls
+// on today. Capacity is the weekly hours of the user, less a fifth for each
+// weekday off. from must be a Monday; the report covers weeks weeks.
+func NewWorkload(capacities []*models.Capacity, tasks []*models.Task, timeOff []*models.TimeOff, from time.Time, weeks int, now time.Time) *Workload {
+	to := from.AddDate(0, 0, 7*weeks-1)
+	workload := &Workload{
+		From:  from.Format(models.DateLayout),
+		To:    to.Format(models.DateLayout),
+		Users: make([]*UserWorkload, 0, len(capacities)),
+	}
+	users := make(map[int]*UserWorkload, len(capacities))
+	assigned := make(map[int][]float64, len(capacities))
+	daysOff := make(map[int]map[time.Time]bool, len(capacities))
+	for _, capacity := range capacities {
+		user := &UserWorkload{
+			UserID:      capacity.UserID,
+			Name:        capacity.Name,
+			Team:        capacity.Team,
+			WeeklyHours: capacity.WeeklyHours,
+			Weeks:       make([]*WeekLoad, weeks),
+		}
+		users[capacity.UserID] = user
+		assigned[capacity.UserID] = make([]float64, weeks)
+		daysOff[capacity.UserID] = make(map[time.Time]bool)
+		workload.Users = append(workload.Users, user)
+	}
+	for _, entry := range timeOff {
+		off, ok := daysOff[entry.UserID]
+		start, startErr := models.ParseDate(entry.StartDate)
+		end, endErr := models.ParseDate(entry.EndDate)
+		if !ok || startErr != nil || endErr != nil {
+			continue
+		}
+		for day := truncateDay(start); !day.After(end); day = day.AddDate(0, 0, 1) {
+			off[day] = true
+		}
+	}
+
+	today := truncateDay(now)
+	for _, task := range tasks {
+		user, ok := users[task.ResponsibleUserID]
+		if !ok {
+			continue
+		}
+		minutes := task.RemainingEstimateMinutes
+		if minutes == nil {
+			minutes = task.OriginalEstimateMinutes
+		}
+		if minutes == nil {
+			user.UnestimatedTasks++
+			continue
+		}
+		hours := float64(*minutes) / 60
+		due, err := models.ParseDate(task.DueDate)
+		if err != nil {
+			user.UnscheduledHours += hours
+			continue
+		}
+		start := today
+		if planned, err := models.ParseDate(task.StartDate); err == nil && truncateDay(planned).After(start) {
+			start = truncateDay(planned)
+		}
+		due = truncateDay(due)
+		if due.Before(start) {
+			due = start
+		}
+		days := workingDays(start, due)
+		for _, day := range days {
+			week := int(day.Sub(from).Hours()/24) / 7
+			if day.Before(from) || week >= weeks {
+				continue
+			}
+			assigned[user.UserID][week] += hours / float64(len(days))
+		}
+	}
+
+	for _, user := range workload.Users {
+		for week := range user.Weeks {
+			weekStart := from.AddDate(0, 0, 7*week)
+			load := &WeekLoad{WeekStart: weekStart.Format(models.DateLayout)}
+			for _, day := range workingDays(weekStart, weekStart.AddDate(0, 0, 4)) {
+				if daysOff[user.UserID][day] {
+					load.TimeOffDays++
+				}
+			}
+			load.CapacityHours = round(user.WeeklyHours * float64(5-load.TimeOffDays) / 5)
+			load.AssignedHours = round(assigned[user.UserID][week])
+			if load.CapacityHours > 0 {
+				load.UtilizationPercent = round(load.AssignedHours * 100 / load.CapacityHours)
+			}
+			load.OverAllocated = load.AssignedHours > load.CapacityHours
+			if load.OverAllocated {
+				user.OverAllocatedWeeks++
+			}
+			user.Weeks[week] = load
+		}
+		user.UnscheduledHours = round(user.UnscheduledHours)
+		if user.OverAllocatedWeeks > 0 {
+			workload.OverAllocated++
+		}
+	}
+	return workload
+}
+
+// workingDays returns the weekdays from start to end, both included, or
+// start alone when there are none so that weekend work is not lost.
+func workingDays(start, end time.Time) []time.Time {
+	days := make([]time.Time, 0)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days = append(days, day)
+		}
+	}
+	if len(days) == 0 {
+		days = append(days, start)
+	}
+	return days
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"ProjectManagementService/internal/models"
+	"testing"
+	"time"
+)
+
+func minutes(value int) *int {
+	return &value
+}
+
+func TestNewWorkload(t *testing.T) {
+	capacities := []*models.Capacity{
+		{UserID: 1, Name: "Ann", WeeklyHours: 40},
+		{UserID: 2, Name: "Bob", WeeklyHours: 20, Team: "Platform"},
+	}
+	tasks := []*models.Task{
+		{ID: 1, ResponsibleUserID: 1, DueDate: "2021-09-10", OriginalEstimateMinutes: minutes(3600)},
+		{ID: 2, ResponsibleUserID: 1, DueDate: "2021-09-10"},
+		{ID: 3, ResponsibleUserID: 1, OriginalEstimateMinutes: minutes(600), RemainingEstimateMinutes: minutes(300)},
+		{ID: 4, ResponsibleUserID: 2, StartDate: "2021-09-13", DueDate: "2021-09-17", OriginalEstimateMinutes: minutes(1200)},
+		// overdue, so due today
+		{ID: 5, ResponsibleUserID: 2, DueDate: "2021-09-01T00:00:00Z", RemainingEstimateMinutes: minutes(120)},
+		// not in the report
+		{ID: 6, ResponsibleUserID: 3, DueDate: "2021-09-10", RemainingEstimateMinutes: minutes(120)},
+	}
+	timeOff := []*models.TimeOff{
+		{UserID: 2, StartDate: "2021-09-11", EndDate: "2021-09-14"},
+	}
+	from := time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC)
+	workload := NewWorkload(capacities, tasks, timeOff, from, 2, from.Add(10*time.Hour))
+
+	if workload.From != "2021-09-06" || workload.To != "2021-09-19" || workload.OverAllocated != 2 {
+		t.Fatalf("unexpected workload %s to %s with %d over allocated", workload.From, workload.To, workload.OverAllocated)
+	}
+	want := [][]WeekLoad{
+		{
+			{WeekStart: "2021-09-06", CapacityHours: 40, AssignedHours: 60, UtilizationPercent: 150, OverAllocated: true},
+			{WeekStart: "2021-09-13", CapacityHours: 40},
+		},
+		{
+			{WeekStart: "2021-09-06", CapacityHours: 20, AssignedHours: 2, UtilizationPercent: 10},
+			// the weekend off does not reduce capacity
+			{WeekStart: "2021-09-13", TimeOffDays: 2, CapacityHours: 12, AssignedHours: 20, UtilizationPercent: 166.7, OverAllocated: true},
+		},
+	}
+	for i, user := range workload.Users {
+		for j, week := range user.Weeks {
+			if *week != want[i][j] {
+				t.Errorf("user %d week %d: %+v, want %+v", user.UserID, j, *week, want[i][j])
+			}
+		}
+		if user.OverAllocatedWeeks != 1 {
+			t.Errorf("user %d: %d over allocated weeks, want 1", user.UserID, user.OverAllocatedWeeks)
+		}
+	}
+	if ann := workload.Users[0]; ann.UnscheduledHours != 5 || ann.UnestimatedTasks != 1 {
+		t.Errorf("unexpected %v unscheduled hours and %d unestimated tasks", ann.UnscheduledHours, ann.UnestimatedTasks)
+	}
+}
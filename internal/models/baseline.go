@@ -0,0 +1,155 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Baseline is a named snapshot of the plan of a project: the dates,
+// estimates and status of its tasks when it was taken.
+type Baseline struct {
+	ID           int             `json:"id"`
+	ProjectID    int             `json:"project_id"`
+	Name         string          `json:"name" example:"Kick-off plan"`
+	TaskCount    int             `json:"task_count"`
+	CreationDate string          `json:"creation_date"`
+	Tasks        []*BaselineTask `json:"tasks,omitempty"`
+}
+
+func (b *Baseline) Validate() error {
+	if b.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	return nil
+}
+
+// BaselineTask is a task as it was recorded in a baseline.
+type BaselineTask struct {
+	TaskID                   int        `json:"task_id"`
+	Title                    string     `json:"title"`
+	Status                   StatusEnum `json:"status"`
+	StartDate                string     `json:"start_date"`
+	DueDate                  string     `json:"due_date"`
+	StoryPoints              *float64   `json:"story_points"`
+	OriginalEstimateMinutes  *int       `json:"original_estimate_minutes"`
+	RemainingEstimateMinutes *int       `json:"remaining_estimate_minutes"`
+}
+
+type BaselineModel interface {
+	GetProjectBaselines(projectID int) ([]*Baseline, error)
+	GetBaselineById(id int) (*Baseline, error)
+	// CreateBaseline records the current tasks of the project under the
+	// baseline's name.
+	CreateBaseline(baseline *Baseline) (int, error)
+	DeleteBaseline(id int) (int, error)
+	GetBaselineTasks(id int) ([]*BaselineTask, error)
+}
+
+type BaselineModelImpl struct {
+	DB *sql.DB
+}
+
+func NewBaselineModel(db *sql.DB) *BaselineModelImpl {
+	return &BaselineModelImpl{DB: db}
+}
+
+const baselineColumns = "id, project_id, name, (SELECT COUNT(*) FROM baseline_tasks bt WHERE bt.baseline_id = baselines.id), creation_date"
+
+func (m *BaselineModelImpl) GetProjectBaselines(projectID int) ([]*Baseline, error) {
+	rows, err := m.DB.Query("SELECT "+baselineColumns+" FROM baselines WHERE project_id = $1 ORDER BY creation_date DESC, id DESC", projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	baselines := make([]*Baseline, 0)
+	for rows.Next() {
+		baseline := &Baseline{}
+		err := scanBaseline(rows, baseline)
+		if err != nil {
+			return nil, err
+		}
+		baselines = append(baselines, baseline)
+	}
+	return baselines, rows.Err()
+}
+
+func (m *BaselineModelImpl) GetBaselineById(id int) (*Baseline, error) {
+	baseline := &Baseline{}
+	err := scanBaseline(m.DB.QueryRow("SELECT "+baselineColumns+" FROM baselines WHERE id = $1", id), baseline)
+	if err != nil {
+		return nil, err
+	}
+	return baseline, nil
+}
+
+func (m *BaselineModelImpl) CreateBaseline(baseline *Baseline) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var id int
+	err = tx.QueryRow("INSERT INTO baselines (project_id, name) VALUES ($1, $2) RETURNING id", baseline.ProjectID, baseline.Name).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec(`INSERT INTO baseline_tasks (baseline_id, task_id, title, status, start_date, due_date, story_points, original_estimate_minutes, remaining_estimate_minutes)
+		SELECT $1, id, COALESCE(title, ''), status, start_date, due_date, story_points, original_estimate_minutes, remaining_estimate_minutes FROM tasks WHERE project_id = $2`,
+		id, baseline.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func (m *BaselineModelImpl) DeleteBaseline(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM baselines WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+func (m *BaselineModelImpl) GetBaselineTasks(id int) ([]*BaselineTask, error) {
+	rows, err := m.DB.Query(`SELECT task_id, title, status, start_date, due_date, story_points, original_estimate_minutes, remaining_estimate_minutes
+		FROM baseline_tasks WHERE baseline_id = $1 ORDER BY task_id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	tasks := make([]*BaselineTask, 0)
+	for rows.Next() {
+		task := &BaselineTask{}
+		var startDate, dueDate sql.NullString
+		var storyPoints sql.NullFloat64
+		var originalEstimate, remainingEstimate sql.NullInt64
+		err := rows.Scan(&task.TaskID, &task.Title, &task.Status, &startDate, &dueDate, &storyPoints, &originalEstimate, &remainingEstimate)
+		if err != nil {
+			return nil, err
+		}
+		task.StartDate, task.DueDate = startDate.String, dueDate.String
+		if storyPoints.Valid {
+			task.StoryPoints = &storyPoints.Float64
+		}
+		task.OriginalEstimateMinutes = nullableMinutes(originalEstimate)
+		task.RemainingEstimateMinutes = nullableMinutes(remainingEstimate)
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+func scanBaseline(row rowScanner, baseline *Baseline) error {
+	return row.Scan(&baseline.ID, &baseline.ProjectID, &baseline.Name, &baseline.TaskCount, &baseline.CreationDate)
+}
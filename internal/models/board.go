@@ -0,0 +1,239 @@
+package models
+
+import (
+	"ProjectManagementService/internal/rank"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+)
+
+// BoardStatuses are the board columns, from left to right.
+var BoardStatuses = []StatusEnum{New, InProgress, Done}
+
+// ErrWIPLimitReached is returned when a task is moved into a column that
+// already holds as many tasks as its WIP limit allows.
+var ErrWIPLimitReached = errors.New("the column is at its WIP limit")
+
+// wipLimitError maps the error raised by the check_wip_limit trigger, which
+// guards every change of the status or project of a task, to ErrWIPLimitReached.
+func wipLimitError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23514" && pqErr.Constraint == "board_wip_limits" {
+		return ErrWIPLimitReached
+	}
+	return err
+}
+
+type BoardColumn struct {
+	Status StatusEnum `json:"status"`
+	// WIPLimit is the most tasks the column takes, 0 when it is unlimited.
+	WIPLimit int     `json:"wip_limit"`
+	Tasks    []*Task `json:"tasks"`
+}
+
+// Board is a project's tasks grouped by status, each column in rank order.
+type Board struct {
+	ProjectID int            `json:"project_id"`
+	Columns   []*BoardColumn `json:"columns"`
+}
+
+type BoardModel interface {
+	GetBoard(projectID int) (*Board, error)
+	// SetWIPLimits replaces the limits of the project's columns; columns
+	// missing from limits or set to 0 become unlimited.
+	SetWIPLimits(projectID int, limits map[StatusEnum]int) error
+	// MoveTask puts the task at position in the column of status, counted
+	// without the task itself, and returns it as saved.
+	MoveTask(taskID int, status StatusEnum, position int) (*Task, error)
+}
+
+type BoardModelImpl struct {
+	DB *sql.DB
+}
+
+func NewBoardModel(db *sql.DB) *BoardModelImpl {
+	return &BoardModelImpl{DB: db}
+}
+
+func (m *BoardModelImpl) GetBoard(projectID int) (*Board, error) {
+	tasks, err := queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE project_id = $1 ORDER BY board_rank NULLS LAST, id", projectID)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := m.wipLimits(projectID)
+	if err != nil {
+		return nil, err
+	}
+	board := &Board{ProjectID: projectID}
+	columns := make(map[StatusEnum]*BoardColumn)
+	for _, status := range BoardStatuses {
+		column := &BoardColumn{Status: status, WIPLimit: limits[status], Tasks: make([]*Task, 0)}
+		columns[status] = column
+		board.Columns = append(board.Columns, column)
+	}
+	for _, task := range tasks {
+		if column, ok := columns[task.Status]; ok {
+			column.Tasks = append(column.Tasks, task)
+		}
+	}
+	return board, nil
+}
+
+func (m *BoardModelImpl) wipLimits(projectID int) (map[StatusEnum]int, error) {
+	rows, err := m.DB.Query("SELECT status, wip_limit FROM board_wip_limits WHERE project_id = $1", projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	limits := make(map[StatusEnum]int)
+	for rows.Next() {
+		var status StatusEnum
+		var limit int
+		err := rows.Scan(&status, &limit)
+		if err != nil {
+			return nil, err
+		}
+		limits[status] = limit
+	}
+	return limits, rows.Err()
+}
+
+func (m *BoardModelImpl) SetWIPLimits(projectID int, limits map[StatusEnum]int) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec("DELETE FROM board_wip_limits WHERE project_id = $1", projectID)
+	if err != nil {
+		return err
+	}
+	for status, limit := range limits {
+		if limit == 0 {
+			continue
+		}
+		_, err = tx.Exec("INSERT INTO board_wip_limits (project_id, status, wip_limit) VALUES ($1, $2, $3)", projectID, status, limit)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// MoveTask changes the status and rank of the task in one transaction; the
+// WIP limit of the target column is checked by the database. When the
+// neighbours have no rank yet or the new rank would grow too long, the whole
+// column is ranked again.
+func (m *BoardModelImpl) MoveTask(taskID int, status StatusEnum, position int) (*Task, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	task := &Task{}
+	err = scanTask(tx.QueryRow("SELECT "+taskColumns+" FROM tasks WHERE id = $1 FOR UPDATE", taskID), task)
+	if err != nil {
+		return nil, err
+	}
+	ids, ranks, err := columnRanks(tx, task.ProjectID, status, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 {
+		position = 0
+	}
+	if position > len(ids) {
+		position = len(ids)
+	}
+	newRank, err := rankAt(ranks, position)
+	if err != nil || newRank == "" || len(newRank) > rank.MaxLength {
+		newRank, err = spreadColumn(tx, ids, position)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if task.Status != status {
+		_, err = tx.Exec("UPDATE tasks SET status = $1, board_rank = $2, completion_date = CASE WHEN $1 = 'done' THEN CURRENT_DATE END WHERE id = $3", status, newRank, taskID)
+	} else {
+		_, err = tx.Exec("UPDATE tasks SET board_rank = $1 WHERE id = $2", newRank, taskID)
+	}
+	if err != nil {
+		return nil, wipLimitError(err)
+	}
+	moved := &Task{}
+	err = scanTask(tx.QueryRow("SELECT "+taskColumns+" FROM tasks WHERE id = $1", taskID), moved)
+	if err != nil {
+		return nil, err
+	}
+	return moved, tx.Commit()
+}
+
+// columnRanks returns the tasks of a column other than excludeID in board
+// order, with their ranks.
+func columnRanks(tx *sql.Tx, projectID int, status StatusEnum, excludeID int) ([]int, []string, error) {
+	rows, err := tx.Query("SELECT id, board_rank FROM tasks WHERE project_id = $1 AND status = $2 AND id <> $3 ORDER BY board_rank NULLS LAST, id",
+		projectID, status, excludeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	ids := make([]int, 0)
+	ranks := make([]string, 0)
+	for rows.Next() {
+		var id int
+		var boardRank sql.NullString
+		err := rows.Scan(&id, &boardRank)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, id)
+		ranks = append(ranks, boardRank.String)
+	}
+	return ids, ranks, rows.Err()
+}
+
+// rankAt returns a rank between the neighbours of position, or "" when one
+// of them has no rank.
+func rankAt(ranks []string, position int) (string, error) {
+	prev, next := "", ""
+	if position > 0 {
+		prev = ranks[position-1]
+		if prev == "" {
+			return "", nil
+		}
+	}
+	if position < len(ranks) {
+		next = ranks[position]
+		if next == "" {
+			return "", nil
+		}
+	}
+	return rank.Between(prev, next)
+}
+
+// spreadColumn ranks the tasks of a column evenly, leaving position free,
+// and returns the rank for that position.
+func spreadColumn(tx *sql.Tx, ids []int, position int) (string, error) {
+	ranks := rank.Spread(len(ids) + 1)
+	for i, id := range ids {
+		spot := i
+		if i >= position {
+			spot++
+		}
+		_, err := tx.Exec("UPDATE tasks SET board_rank = $1 WHERE id = $2", ranks[spot], id)
+		if err != nil {
+			return "", err
+		}
+	}
+	return ranks[position], nil
+}
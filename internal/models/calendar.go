@@ -0,0 +1,169 @@
+package models
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"time"
+)
+
+// CalendarFeed is the secret link to the iCalendar feed of a user or of a
+// project. Exactly one of UserID and ProjectID is set.
+type CalendarFeed struct {
+	ID           int    `json:"id"`
+	Token        string `json:"token"`
+	UserID       int    `json:"user_id,omitempty"`
+	ProjectID    int    `json:"project_id,omitempty"`
+	CreationDate string `json:"creation_date"`
+	// URL is where calendar applications subscribe to the feed.
+	URL string `json:"url"`
+}
+
+// Revision tells how often a task or a milestone was changed and when last,
+// from the changes feed for tasks and from the milestone itself.
+type Revision struct {
+	ID       int
+	Sequence int
+	Modified time.Time
+}
+
+type CalendarModel interface {
+	GetFeedByToken(token string) (*CalendarFeed, error)
+	GetUserFeed(userID int) (*CalendarFeed, error)
+	GetProjectFeed(projectID int) (*CalendarFeed, error)
+	// SetUserFeed and SetProjectFeed create the feed, or give it the new
+	// token when it exists.
+	SetUserFeed(userID int, token string) (*CalendarFeed, error)
+	SetProjectFeed(projectID int, token string) (*CalendarFeed, error)
+	DeleteUserFeed(userID int) (int, error)
+	DeleteProjectFeed(projectID int) (int, error)
+	// GetFeedTasks returns the tasks with a due date assigned to the user of
+	// feed, or of its project.
+	GetFeedTasks(feed *CalendarFeed) ([]*Task, error)
+	// GetFeedMilestones returns the milestones of the project of feed, or of
+	// the projects its user manages or has tasks in.
+	GetFeedMilestones(feed *CalendarFeed) ([]*Milestone, error)
+	GetTaskRevisions(taskIDs []int) (map[int]*Revision, error)
+	GetMilestoneRevisions(milestoneIDs []int) (map[int]*Revision, error)
+}
+
+type CalendarModelImpl struct {
+	DB *sql.DB
+}
+
+func NewCalendarModel(db *sql.DB) *CalendarModelImpl {
+	return &CalendarModelImpl{DB: db}
+}
+
+const calendarFeedColumns = "id, token, COALESCE(user_id, 0), COALESCE(project_id, 0), creation_date"
+
+func scanCalendarFeed(row rowScanner) (*CalendarFeed, error) {
+	feed := &CalendarFeed{}
+	err := row.Scan(&feed.ID, &feed.Token, &feed.UserID, &feed.ProjectID, &feed.CreationDate)
+	if err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+func (m *CalendarModelImpl) GetFeedByToken(token string) (*CalendarFeed, error) {
+	return scanCalendarFeed(m.DB.QueryRow("SELECT "+calendarFeedColumns+" FROM calendar_feeds WHERE token = $1", token))
+}
+
+func (m *CalendarModelImpl) GetUserFeed(userID int) (*CalendarFeed, error) {
+	return scanCalendarFeed(m.DB.QueryRow("SELECT "+calendarFeedColumns+" FROM calendar_feeds WHERE user_id = $1", userID))
+}
+
+func (m *CalendarModelImpl) GetProjectFeed(projectID int) (*CalendarFeed, error) {
+	return scanCalendarFeed(m.DB.QueryRow("SELECT "+calendarFeedColumns+" FROM calendar_feeds WHERE project_id = $1", projectID))
+}
+
+func (m *CalendarModelImpl) SetUserFeed(userID int, token string) (*CalendarFeed, error) {
+	return scanCalendarFeed(m.DB.QueryRow(`INSERT INTO calendar_feeds (token, user_id) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, creation_date = current_timestamp RETURNING `+calendarFeedColumns, token, userID))
+}
+
+func (m *CalendarModelImpl) SetProjectFeed(projectID int, token string) (*CalendarFeed, error) {
+	return scanCalendarFeed(m.DB.QueryRow(`INSERT INTO calendar_feeds (token, project_id) VALUES ($1, $2)
+		ON CONFLICT (project_id) DO UPDATE SET token = EXCLUDED.token, creation_date = current_timestamp RETURNING `+calendarFeedColumns, token, projectID))
+}
+
+func (m *CalendarModelImpl) DeleteUserFeed(userID int) (int, error) {
+	var deletedId int
+	err := m.DB.QueryRow("DELETE FROM calendar_feeds WHERE user_id = $1 RETURNING id", userID).Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+func (m *CalendarModelImpl) DeleteProjectFeed(projectID int) (int, error) {
+	var deletedId int
+	err := m.DB.QueryRow("DELETE FROM calendar_feeds WHERE project_id = $1 RETURNING id", projectID).Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+func (m *CalendarModelImpl) GetFeedTasks(feed *CalendarFeed) ([]*Task, error) {
+	if feed.UserID != 0 {
+		return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE responsible_user_id = $1 AND due_date IS NOT NULL ORDER BY due_date, id", feed.UserID)
+	}
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE project_id = $1 AND due_date IS NOT NULL ORDER BY due_date, id", feed.ProjectID)
+}
+
+func (m *CalendarModelImpl) GetFeedMilestones(feed *CalendarFeed) ([]*Milestone, error) {
+	rows, err := m.DB.Query("SELECT "+milestoneColumns+` FROM milestones
+		WHERE project_id = $2 OR project_id IN (SELECT id FROM projects WHERE manager_id = $1 UNION SELECT project_id FROM tasks WHERE responsible_user_id = $1)
+		ORDER BY target_date, id`, feed.UserID, feed.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	milestones := make([]*Milestone, 0)
+	for rows.Next() {
+		milestone := &Milestone{}
+		err := scanMilestone(rows, milestone)
+		if err != nil {
+			return nil, err
+		}
+		milestones = append(milestones, milestone)
+	}
+	return milestones, rows.Err()
+}
+
+func (m *CalendarModelImpl) GetTaskRevisions(taskIDs []int) (map[int]*Revision, error) {
+	return m.revisions(`SELECT entity_id, COUNT(*) - 1, MAX(changed_at) FROM changes
+		WHERE entity = 'tasks' AND operation = 'upsert' AND entity_id = ANY($1) GROUP BY entity_id`, taskIDs)
+}
+
+func (m *CalendarModelImpl) GetMilestoneRevisions(milestoneIDs []int) (map[int]*Revision, error) {
+	return m.revisions("SELECT id, sequence, modified_at FROM milestones WHERE id = ANY($1)", milestoneIDs)
+}
+
+func (m *CalendarModelImpl) revisions(query string, ids []int) (map[int]*Revision, error) {
+	rows, err := m.DB.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	revisions := make(map[int]*Revision)
+	for rows.Next() {
+		revision := &Revision{}
+		if err := rows.Scan(&revision.ID, &revision.Sequence, &revision.Modified); err != nil {
+			return nil, err
+		}
+		revisions[revision.ID] = revision
+	}
+	return revisions, rows.Err()
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+const (
+	ChangeUpsert = "upsert"
+	ChangeDelete = "delete"
+)
+
+// Change is a single entry of the changes feed. Data holds the full row after
+// an upsert and is empty for delete tombstones.
+type Change struct {
+	Seq       int64           `json:"seq"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Operation string          `json:"operation"`
+	Data      json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	ChangedAt string          `json:"changed_at"`
+}
+
+type ChangeModel interface {
+	GetChangesSince(since int64, limit int) ([]*Change, error)
+	GetLatestSeq() (int64, error)
+}
+
+type ChangeModelImpl struct {
+	DB *sql.DB
+}
+
+func NewChangeModel(db *sql.DB) *ChangeModelImpl {
+	return &ChangeModelImpl{DB: db}
+}
+
+func (m *ChangeModelImpl) GetChangesSince(since int64, limit int) ([]*Change, error) {
+	rows, err := m.DB.Query("SELECT seq, entity, entity_id, operation, data, changed_at FROM changes WHERE seq > $1 ORDER BY seq LIMIT $2", since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	changes := make([]*Change, 0)
+	for rows.Next() {
+		change := &Change{}
+		var data []byte
+		err := rows.Scan(&change.Seq, &change.Entity, &change.EntityID, &change.Operation, &data, &change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			change.Data = data
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+func (m *ChangeModelImpl) GetLatestSeq() (int64, error) {
+	var seq int64
+	err := m.DB.QueryRow("SELECT COALESCE(MAX(seq), 0) FROM changes").Scan(&seq)
+	if err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
@@ -0,0 +1,201 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// CloneOptions selects what a clone carries over from the original.
+type CloneOptions struct {
+	// Title of the copy; defaults to "Copy of <original title>".
+	Title string `json:"title"`
+	// ProjectID is the project a task is cloned into; defaults to its own.
+	ProjectID int `json:"project_id"`
+	// KeepAssignees copies responsible users; otherwise tasks are assigned to
+	// AssigneeID, or the manager of the target project.
+	KeepAssignees bool `json:"keep_assignees"`
+	AssigneeID    int  `json:"assignee_id"`
+	// ResetStatus makes every copied task new again and clears completion dates.
+	ResetStatus bool `json:"reset_status"`
+	Comments    bool `json:"comments"`
+	// Subtasks clones the task's descendants along with it.
+	Subtasks bool `json:"subtasks"`
+	// Attachments is accepted for forward compatibility; the service does not
+	// store attachments yet, so asking for them is rejected.
+	Attachments bool `json:"attachments"`
+}
+
+func DefaultCloneOptions() *CloneOptions {
+	return &CloneOptions{KeepAssignees: true, ResetStatus: true, Subtasks: true}
+}
+
+type CloneModel interface {
+	CloneProject(id int, options *CloneOptions) (int, error)
+	CloneTask(id int, options *CloneOptions) (int, error)
+}
+
+type CloneModelImpl struct {
+	DB *sql.DB
+}
+
+func NewCloneModel(db *sql.DB) *CloneModelImpl {
+	return &CloneModelImpl{DB: db}
+}
+
+// CloneProject copies a project with all of its tasks in one transaction and
+// returns the id of the copy.
+func (m *CloneModelImpl) CloneProject(id int, options *CloneOptions) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var title, description string
+	var managerID int
+	err = tx.QueryRow("SELECT title, description, manager_id FROM projects WHERE id = $1", id).Scan(&title, &description, &managerID)
+	if err != nil {
+		return 0, err
+	}
+	if options.Title != "" {
+		title = options.Title
+	} else {
+		title = "Copy of " + title
+	}
+	var projectID int
+	err = tx.QueryRow("INSERT INTO projects (title, description, manager_id) VALUES ($1, $2, $3) RETURNING id", title, description, managerID).Scan(&projectID)
+	if err != nil {
+		return 0, err
+	}
+	tasks, err := queryTasks(tx, "SELECT "+taskColumns+" FROM tasks WHERE project_id = $1 ORDER BY id", id)
+	if err != nil {
+		return 0, err
+	}
+	assigneeID := options.AssigneeID
+	if assigneeID == 0 {
+		assigneeID = managerID
+	}
+	if _, err := cloneTasks(tx, tasks, projectID, 0, assigneeID, options); err != nil {
+		return 0, err
+	}
+	return projectID, tx.Commit()
+}
+
+// CloneTask copies a task, and its subtasks if asked to, into options.ProjectID
+// or its own project and returns the id of the copy.
+func (m *CloneModelImpl) CloneTask(id int, options *CloneOptions) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = $1"
+	if options.Subtasks {
+		query = `WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id = $1
+			UNION SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id
+		) SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY id`
+	}
+	tasks, err := queryTasks(tx, query, id)
+	if err != nil {
+		return 0, err
+	}
+	var root *Task
+	for _, task := range tasks {
+		if task.ID == id {
+			root = task
+		}
+	}
+	if root == nil {
+		return 0, sql.ErrNoRows
+	}
+	projectID, parentID := root.ProjectID, root.ParentID
+	if options.ProjectID != 0 && options.ProjectID != root.ProjectID {
+		// the parent stays behind in the original project
+		projectID, parentID = options.ProjectID, 0
+	}
+	if options.Title != "" {
+		root.Title = options.Title
+	} else {
+		root.Title = "Copy of " + root.Title
+	}
+	assigneeID := options.AssigneeID
+	if assigneeID == 0 {
+		err = tx.QueryRow("SELECT manager_id FROM projects WHERE id = $1", projectID).Scan(&assigneeID)
+		if err != nil {
+			return 0, fmt.Errorf("could not load target project %d: %w", projectID, err)
+		}
+	}
+	ids, err := cloneTasks(tx, tasks, projectID, parentID, assigneeID, options)
+	if err != nil {
+		return 0, err
+	}
+	return ids[id], tx.Commit()
+}
+
+// cloneTasks inserts copies of tasks into projectID, keeping their hierarchy.
+// Tasks whose parent is not being copied get rootParentID. Copies within the
+// same project keep their sprint, milestone and epic, which belong to the
+// project. It returns the ids of the copies by original id.
+func cloneTasks(tx *sql.Tx, tasks []*Task, projectID, rootParentID, assigneeID int, options *CloneOptions) (map[int]int, error) {
+	ids := make(map[int]int, len(tasks))
+	for _, task := range ParentsFirst(tasks) {
+		parentID, copied := ids[task.ParentID]
+		if !copied {
+			parentID = rootParentID
+		}
+		status, completionDate, remaining, boardRank := task.Status, task.CompletionDate, task.RemainingEstimateMinutes, task.BoardRank
+		if options.ResetStatus {
+			status, completionDate, remaining, boardRank = New, "", task.OriginalEstimateMinutes, ""
+		}
+		responsibleUserID := task.ResponsibleUserID
+		if !options.KeepAssignees {
+			responsibleUserID = assigneeID
+		}
+		var sprintID, milestoneID, epicID int
+		if projectID == task.ProjectID {
+			sprintID, milestoneID, epicID = task.SprintID, task.MilestoneID, task.EpicID
+		}
+		var id int
+		err := tx.QueryRow("INSERT INTO tasks (title, description, priority, status, responsible_user_id, project_id, due_date, completion_date, parent_id, labels, story_points, original_estimate_minutes, remaining_estimate_minutes, board_rank, start_date, sprint_id, milestone_id, epic_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING id",
+			task.Title, task.Description, task.Priority, status, responsibleUserID, projectID, nullableString(task.DueDate), nullableString(completionDate),
+			nullableID(parentID), labelsArray(task.Labels), task.StoryPoints, task.OriginalEstimateMinutes, remaining, nullableString(boardRank), nullableString(task.StartDate),
+			nullableID(sprintID), nullableID(milestoneID), nullableID(epicID)).Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids[task.ID] = id
+		if options.Comments {
+			_, err = tx.Exec("INSERT INTO task_comments (task_id, user_id, body, creation_date) SELECT $1, user_id, body, creation_date FROM task_comments WHERE task_id = $2 ORDER BY id", id, task.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ids, nil
+}
+
+// ParentsFirst orders tasks so that every task comes after its parent when
+// the parent is in the list. The relative order is kept otherwise.
+func ParentsFirst(tasks []*Task) []*Task {
+	byID := make(map[int]*Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	ordered := make([]*Task, 0, len(tasks))
+	visited := make(map[int]bool, len(tasks))
+	var visit func(task *Task)
+	visit = func(task *Task) {
+		if visited[task.ID] {
+			return
+		}
+		visited[task.ID] = true
+		if parent, ok := byID[task.ParentID]; ok {
+			visit(parent)
+		}
+		ordered = append(ordered, task)
+	}
+	for _, task := range tasks {
+		visit(task)
+	}
+	return ordered
+}
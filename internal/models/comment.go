@@ -0,0 +1,56 @@
+package models
+
+import "database/sql"
+
+type Comment struct {
+	ID           int    `json:"id"`
+	TaskID       int    `json:"task_id"`
+	UserID       int    `json:"user_id"`
+	Body         string `json:"body"`
+	CreationDate string `json:"creation_date"`
+}
+
+type CommentModel interface {
+	GetTaskComments(taskID int) ([]*Comment, error)
+	CreateComment(taskID, userID int, body string) (*Comment, error)
+}
+
+type CommentModelImpl struct {
+	DB *sql.DB
+}
+
+func NewCommentModel(db *sql.DB) *CommentModelImpl {
+	return &CommentModelImpl{DB: db}
+}
+
+func (m *CommentModelImpl) GetTaskComments(taskID int) ([]*Comment, error) {
+	rows, err := m.DB.Query("SELECT id, task_id, user_id, body, creation_date FROM task_comments WHERE task_id = $1 ORDER BY id", taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	comments := make([]*Comment, 0)
+	for rows.Next() {
+		comment := &Comment{}
+		err := rows.Scan(&comment.ID, &comment.TaskID, &comment.UserID, &comment.Body, &comment.CreationDate)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+func (m *CommentModelImpl) CreateComment(taskID, userID int, body string) (*Comment, error) {
+	comment := &Comment{TaskID: taskID, UserID: userID, Body: body}
+	err := m.DB.QueryRow("INSERT INTO task_comments (task_id, user_id, body) VALUES ($1, $2, $3) RETURNING id, creation_date", taskID, userID, body).Scan(&comment.ID, &comment.CreationDate)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
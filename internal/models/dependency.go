@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// Dependency is a finish-to-start link: the successor cannot start before the
+// predecessor is due, plus LagDays.
+type Dependency struct {
+	PredecessorID int `json:"predecessor_id"`
+	SuccessorID   int `json:"successor_id"`
+	LagDays       int `json:"lag_days"`
+}
+
+// TaskDates are the planned dates of a task, as written by auto-scheduling.
+type TaskDates struct {
+	TaskID    int    `json:"task_id"`
+	StartDate string `json:"start_date"`
+	DueDate   string `json:"due_date"`
+}
+
+type DependencyModel interface {
+	// GetTaskDependencies returns the links in which the task is either the
+	// predecessor or the successor.
+	GetTaskDependencies(taskID int) ([]*Dependency, error)
+	GetProjectDependencies(projectID int) ([]*Dependency, error)
+	// AddDependency creates the link, or updates its lag if it exists.
+	AddDependency(dependency *Dependency) error
+	RemoveDependency(predecessorID, successorID int) (int, error)
+	UpdateTaskDates(dates []TaskDates) error
+}
+
+type DependencyModelImpl struct {
+	DB *sql.DB
+}
+
+func NewDependencyModel(db *sql.DB) *DependencyModelImpl {
+	return &DependencyModelImpl{DB: db}
+}
+
+func (m *DependencyModelImpl) GetTaskDependencies(taskID int) ([]*Dependency, error) {
+	return m.queryDependencies("SELECT predecessor_id, successor_id, lag_days FROM task_dependencies WHERE predecessor_id = $1 OR successor_id = $1 ORDER BY predecessor_id, successor_id", taskID)
+}
+
+func (m *DependencyModelImpl) GetProjectDependencies(projectID int) ([]*Dependency, error) {
+	return m.queryDependencies(`SELECT d.predecessor_id, d.successor_id, d.lag_days FROM task_dependencies d JOIN tasks t ON t.id = d.successor_id
+		WHERE t.project_id = $1 ORDER BY d.predecessor_id, d.successor_id`, projectID)
+}
+
+func (m *DependencyModelImpl) AddDependency(dependency *Dependency) error {
+	_, err := m.DB.Exec("INSERT INTO task_dependencies (predecessor_id, successor_id, lag_days) VALUES ($1, $2, $3) ON CONFLICT (predecessor_id, successor_id) DO UPDATE SET lag_days = EXCLUDED.lag_days",
+		dependency.PredecessorID, dependency.SuccessorID, dependency.LagDays)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *DependencyModelImpl) RemoveDependency(predecessorID, successorID int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM task_dependencies WHERE predecessor_id = $1 AND successor_id = $2 RETURNING successor_id", predecessorID, successorID)
+	var removedId int
+	err := row.Scan(&removedId)
+	if err != nil {
+		return 0, err
+	}
+	return removedId, nil
+}
+
+// UpdateTaskDates writes the planned dates of several tasks at once.
+func (m *DependencyModelImpl) UpdateTaskDates(dates []TaskDates) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, task := range dates {
+		_, err = tx.Exec("UPDATE tasks SET start_date = $1, due_date = $2 WHERE id = $3", nullableString(task.StartDate), nullableString(task.DueDate), task.TaskID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (m *DependencyModelImpl) queryDependencies(query string, args ...any) ([]*Dependency, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	dependencies := make([]*Dependency, 0)
+	for rows.Next() {
+		dependency := &Dependency{}
+		err := rows.Scan(&dependency.PredecessorID, &dependency.SuccessorID, &dependency.LagDays)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, rows.Err()
+}
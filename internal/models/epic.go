@@ -0,0 +1,110 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Epic groups the tasks of a project that deliver one larger piece of work.
+type Epic struct {
+	ID           int    `json:"id"`
+	ProjectID    int    `json:"project_id"`
+	Title        string `json:"title" example:"Billing"`
+	Description  string `json:"description"`
+	CreationDate string `json:"creation_date"`
+}
+
+func (e *Epic) Validate() error {
+	if e.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	return nil
+}
+
+type EpicModel interface {
+	GetProjectEpics(projectID int) ([]*Epic, error)
+	GetEpicById(id int) (*Epic, error)
+	CreateEpic(epic *Epic) (int, error)
+	UpdateEpic(epic *Epic) error
+	DeleteEpic(id int) (int, error)
+	GetEpicTasks(id int) ([]*Task, error)
+}
+
+type EpicModelImpl struct {
+	DB *sql.DB
+}
+
+func NewEpicModel(db *sql.DB) *EpicModelImpl {
+	return &EpicModelImpl{DB: db}
+}
+
+const epicColumns = "id, project_id, title, description, creation_date"
+
+func (m *EpicModelImpl) GetProjectEpics(projectID int) ([]*Epic, error) {
+	rows, err := m.DB.Query("SELECT "+epicColumns+" FROM epics WHERE project_id = $1 ORDER BY id", projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	epics := make([]*Epic, 0)
+	for rows.Next() {
+		epic := &Epic{}
+		err := scanEpic(rows, epic)
+		if err != nil {
+			return nil, err
+		}
+		epics = append(epics, epic)
+	}
+	return epics, rows.Err()
+}
+
+func (m *EpicModelImpl) GetEpicById(id int) (*Epic, error) {
+	epic := &Epic{}
+	err := scanEpic(m.DB.QueryRow("SELECT "+epicColumns+" FROM epics WHERE id = $1", id), epic)
+	if err != nil {
+		return nil, err
+	}
+	return epic, nil
+}
+
+func (m *EpicModelImpl) CreateEpic(epic *Epic) (int, error) {
+	var id int
+	err := m.DB.QueryRow("INSERT INTO epics (project_id, title, description) VALUES ($1, $2, $3) RETURNING id",
+		epic.ProjectID, epic.Title, epic.Description).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (m *EpicModelImpl) UpdateEpic(epic *Epic) error {
+	_, err := m.DB.Exec("UPDATE epics SET title = $1, description = $2 WHERE id = $3", epic.Title, epic.Description, epic.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteEpic removes the epic; its tasks are kept and unlinked.
+func (m *EpicModelImpl) DeleteEpic(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM epics WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+func (m *EpicModelImpl) GetEpicTasks(id int) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE epic_id = $1 ORDER BY id", id)
+}
+
+func scanEpic(row rowScanner, epic *Epic) error {
+	return row.Scan(&epic.ID, &epic.ProjectID, &epic.Title, &epic.Description, &epic.CreationDate)
+}
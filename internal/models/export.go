@@ -0,0 +1,115 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// TaskFilter selects tasks on the fields the task search supports. Zero
+// values match every task.
+type TaskFilter struct {
+	Title             string
+	Status            StatusEnum
+	Priority          PriorityEnum
+	ResponsibleUserID int
+	ProjectID         int
+}
+
+// ProjectFilter selects projects as the project search does.
+type ProjectFilter struct {
+	Title     string
+	ManagerID int
+}
+
+// UserFilter selects users as the user search does.
+type UserFilter struct {
+	Email string
+	Name  string
+}
+
+// The conditions of the filters take the arguments of their args method as
+// $1, $2 and so on.
+const (
+	taskFilterCondition = `($1 = '' OR title = $1) AND ($2 = '' OR status::text = $2) AND ($3 = '' OR priority::text = $3)
+		AND ($4 = 0 OR responsible_user_id = $4) AND ($5 = 0 OR project_id = $5)`
+	projectFilterCondition = "($1 = '' OR title = $1) AND ($2 = 0 OR manager_id = $2)"
+	userFilterCondition    = "($1 = '' OR email = $1) AND ($2 = '' OR name = $2)"
+)
+
+func (f TaskFilter) args() []any {
+	return []any{f.Title, string(f.Status), string(f.Priority), f.ResponsibleUserID, f.ProjectID}
+}
+
+func (f ProjectFilter) args() []any {
+	return []any{f.Title, f.ManagerID}
+}
+
+func (f UserFilter) args() []any {
+	return []any{f.Email, f.Name}
+}
+
+// ExportModel reads rows one at a time for exports, calling fn for each
+// until it returns an error. The rows are ordered by id.
+type ExportModel interface {
+	EachTask(filter TaskFilter, fn func(task *Task) error) error
+	EachProject(filter ProjectFilter, fn func(project *Project) error) error
+	EachUser(filter UserFilter, fn func(user *User) error) error
+}
+
+type ExportModelImpl struct {
+	DB *sql.DB
+}
+
+func NewExportModel(db *sql.DB) *ExportModelImpl {
+	return &ExportModelImpl{DB: db}
+}
+
+func (m *ExportModelImpl) EachTask(filter TaskFilter, fn func(task *Task) error) error {
+	return m.each(func(rows *sql.Rows) error {
+		task := &Task{}
+		if err := scanTask(rows, task); err != nil {
+			return err
+		}
+		return fn(task)
+	}, "SELECT "+taskColumns+" FROM tasks WHERE "+taskFilterCondition+" ORDER BY id", filter.args()...)
+}
+
+func (m *ExportModelImpl) EachProject(filter ProjectFilter, fn func(project *Project) error) error {
+	return m.each(func(rows *sql.Rows) error {
+		project := &Project{}
+		var completionDate sql.NullString
+		if err := rows.Scan(&project.ID, &project.Title, &project.Description, &project.CreationDate, &completionDate, &project.ManagerID); err != nil {
+			return err
+		}
+		project.CompletionDate = completionDate.String
+		return fn(project)
+	}, "SELECT id, title, description, creation_date, completion_date, manager_id FROM projects WHERE "+projectFilterCondition+" ORDER BY id", filter.args()...)
+}
+
+func (m *ExportModelImpl) EachUser(filter UserFilter, fn func(user *User) error) error {
+	return m.each(func(rows *sql.Rows) error {
+		user := &User{}
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.RegistrationDate, &user.Role); err != nil {
+			return err
+		}
+		return fn(user)
+	}, "SELECT id, name, email, registration_date, role FROM users WHERE "+userFilterCondition+" ORDER BY id", filter.args()...)
+}
+
+func (m *ExportModelImpl) each(scan func(rows *sql.Rows) error, query string, args ...any) error {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
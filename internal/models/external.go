@@ -0,0 +1,203 @@
+package models
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+)
+
+// The kinds of entities linked to external ids.
+const (
+	ExternalUserKind    = "user"
+	ExternalProjectKind = "project"
+	ExternalTaskKind    = "task"
+	ExternalCommentKind = "comment"
+)
+
+// ExternalProject is a project of another tool, such as a Jira project or a
+// Trello board, with its id there.
+type ExternalProject struct {
+	ExternalID string
+	Project    *Project
+	Tasks      []*ExternalTask
+}
+
+type ExternalTask struct {
+	ExternalID string
+	Task       *Task
+	Comments   []*ExternalComment
+}
+
+type ExternalComment struct {
+	ExternalID string
+	Comment    *Comment
+}
+
+type SyncCount struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	// Unchanged counts the linked entities that already matched the export.
+	Unchanged int `json:"unchanged"`
+}
+
+type ExternalSync struct {
+	Projects SyncCount `json:"projects"`
+	Tasks    SyncCount `json:"tasks"`
+	Comments SyncCount `json:"comments"`
+}
+
+type ExternalModel interface {
+	// GetExternalIDs returns the ids of the entities of kind linked to the
+	// given ids of source, by external id.
+	GetExternalIDs(source, kind string, externalIDs []string) (map[string]int, error)
+	// SyncExternal updates the projects, tasks and comments linked to their
+	// external ids and creates and links the others, in one transaction. It
+	// sets their ids and links the external ids of users to the given users.
+	SyncExternal(source string, users map[string]int, projects []*ExternalProject) (*ExternalSync, error)
+}
+
+type ExternalModelImpl struct {
+	DB *sql.DB
+}
+
+func NewExternalModel(db *sql.DB) *ExternalModelImpl {
+	return &ExternalModelImpl{DB: db}
+}
+
+func (m *ExternalModelImpl) GetExternalIDs(source, kind string, externalIDs []string) (map[string]int, error) {
+	rows, err := m.DB.Query("SELECT external_id, entity_id FROM external_ids WHERE source = $1 AND kind = $2 AND external_id = ANY($3)", source, kind, pq.Array(externalIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	ids := make(map[string]int)
+	for rows.Next() {
+		var externalID string
+		var id int
+		if err := rows.Scan(&externalID, &id); err != nil {
+			return nil, err
+		}
+		ids[externalID] = id
+	}
+	return ids, rows.Err()
+}
+
+func (m *ExternalModelImpl) SyncExternal(source string, users map[string]int, projects []*ExternalProject) (*ExternalSync, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	for externalID, userID := range users {
+		if err := link(tx, source, ExternalUserKind, externalID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	sync := &ExternalSync{}
+	for _, external := range projects {
+		project := external.Project
+		project.ID, err = syncEntity(tx, source, ExternalProjectKind, external.ExternalID, &sync.Projects, "projects", func(id int) (sql.Result, error) {
+			return tx.Exec("UPDATE projects SET title = $1, description = $2, manager_id = $3 WHERE id = $4 AND (title, description, manager_id) IS DISTINCT FROM ($1, $2, $3)",
+				project.Title, project.Description, project.ManagerID, id)
+		}, func() (id int, err error) {
+			err = tx.QueryRow("INSERT INTO projects (title, description, manager_id, creation_date) VALUES ($1, $2, $3, COALESCE($4::date, current_date)) RETURNING id",
+				project.Title, project.Description, project.ManagerID, nullableString(project.CreationDate)).Scan(&id)
+			return id, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, externalTask := range external.Tasks {
+			task := externalTask.Task
+			task.ProjectID = project.ID
+			task.ID, err = syncEntity(tx, source, ExternalTaskKind, externalTask.ExternalID, &sync.Tasks, "tasks", func(id int) (sql.Result, error) {
+				// the fields of the other tool win; the ones it has not, like
+				// sprints and estimates, are kept
+				return tx.Exec(`UPDATE tasks SET title = $1, description = $2, priority = $3, status = $4, responsible_user_id = $5, project_id = $6, due_date = $7, labels = $8,
+					completion_date = CASE WHEN $4 = 'done' THEN COALESCE($9::date, completion_date, current_date) END
+					WHERE id = $10 AND (title, description, priority, status, responsible_user_id, project_id, due_date, labels, completion_date)
+					IS DISTINCT FROM ($1, $2, $3, $4, $5, $6, $7, $8, CASE WHEN $4 = 'done' THEN COALESCE($9::date, completion_date, current_date) END)`,
+					task.Title, task.Description, task.Priority, task.Status, task.ResponsibleUserID, task.ProjectID, nullableString(task.DueDate), labelsArray(task.Labels),
+					nullableString(task.CompletionDate), id)
+			}, func() (id int, err error) {
+				err = tx.QueryRow(`INSERT INTO tasks (title, description, priority, status, responsible_user_id, project_id, due_date, labels, creation_date, completion_date)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::date, current_date), CASE WHEN $4 = 'done' THEN COALESCE($10::date, current_date) END) RETURNING id`,
+					task.Title, task.Description, task.Priority, task.Status, task.ResponsibleUserID, task.ProjectID, nullableString(task.DueDate), labelsArray(task.Labels),
+					nullableString(task.CreationDate), nullableString(task.CompletionDate)).Scan(&id)
+				return id, err
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, externalComment := range externalTask.Comments {
+				comment := externalComment.Comment
+				comment.TaskID = task.ID
+				comment.ID, err = syncEntity(tx, source, ExternalCommentKind, externalComment.ExternalID, &sync.Comments, "task_comments", func(id int) (sql.Result, error) {
+					return tx.Exec("UPDATE task_comments SET task_id = $1, user_id = $2, body = $3 WHERE id = $4 AND (task_id, user_id, body) IS DISTINCT FROM ($1, $2, $3)",
+						comment.TaskID, comment.UserID, comment.Body, id)
+				}, func() (id int, err error) {
+					err = tx.QueryRow("INSERT INTO task_comments (task_id, user_id, body, creation_date) VALUES ($1, $2, $3, COALESCE($4::timestamp, current_timestamp)) RETURNING id",
+						comment.TaskID, comment.UserID, comment.Body, nullableString(comment.CreationDate)).Scan(&id)
+					return id, err
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return sync, tx.Commit()
+}
+
+// syncEntity updates the entity linked to externalID, or inserts and links
+// a new one when there is none or it has been deleted since. update only
+// writes rows that differ from the export, so that syncing the same export
+// again leaves the entities, their changes and their calendar entries alone.
+func syncEntity(tx *sql.Tx, source, kind, externalID string, count *SyncCount, table string, update func(id int) (sql.Result, error), insert func() (int, error)) (int, error) {
+	var id int
+	err := tx.QueryRow("SELECT entity_id FROM external_ids WHERE source = $1 AND kind = $2 AND external_id = $3", source, kind, externalID).Scan(&id)
+	if err == nil {
+		result, err := update(id)
+		if err != nil {
+			return 0, err
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if updated == 1 {
+			count.Updated++
+			return id, nil
+		}
+		var exists bool
+		err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1)", id).Scan(&exists)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			count.Unchanged++
+			return id, nil
+		}
+	} else if err != sql.ErrNoRows {
+		return 0, err
+	}
+	id, err = insert()
+	if err != nil {
+		return 0, err
+	}
+	count.Created++
+	return id, link(tx, source, kind, externalID, id)
+}
+
+func link(tx *sql.Tx, source, kind, externalID string, id int) error {
+	_, err := tx.Exec(`INSERT INTO external_ids (source, kind, external_id, entity_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (source, kind, external_id) DO UPDATE SET entity_id = excluded.entity_id`, source, kind, externalID, id)
+	return err
+}
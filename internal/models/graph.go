@@ -0,0 +1,222 @@
+package models
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+)
+
+// Page selects rows in id order: the rows after the id After, at most First
+// of them.
+type Page struct {
+	After int
+	First int
+}
+
+// UserPage, ProjectPage and TaskPage hold a page of rows, the count of all
+// rows that match the filter and whether rows follow the page.
+type UserPage struct {
+	Users       []*User
+	TotalCount  int
+	HasNextPage bool
+}
+
+type ProjectPage struct {
+	Projects    []*Project
+	TotalCount  int
+	HasNextPage bool
+}
+
+type TaskPage struct {
+	Tasks       []*Task
+	TotalCount  int
+	HasNextPage bool
+}
+
+// GraphModel loads the entities of GraphQL queries in batches, so that a
+// query needs a statement for each kind of entity it loads rather than for
+// each entity. Entities that do not exist are left out.
+type GraphModel interface {
+	GetUsersByIDs(ids []int) ([]*User, error)
+	GetProjectsByIDs(ids []int) ([]*Project, error)
+	GetTasksByIDs(ids []int) ([]*Task, error)
+	GetUserPage(filter UserFilter, page Page) (*UserPage, error)
+	GetProjectPage(filter ProjectFilter, page Page) (*ProjectPage, error)
+	GetTaskPage(filter TaskFilter, page Page) (*TaskPage, error)
+	// GetUsersTaskPages and GetProjectsTaskPages return the same page of the
+	// tasks of each of the users or projects, by their id.
+	GetUsersTaskPages(userIDs []int, filter TaskFilter, page Page) (map[int]*TaskPage, error)
+	GetProjectsTaskPages(projectIDs []int, filter TaskFilter, page Page) (map[int]*TaskPage, error)
+}
+
+type GraphModelImpl struct {
+	DB *sql.DB
+}
+
+func NewGraphModel(db *sql.DB) *GraphModelImpl {
+	return &GraphModelImpl{DB: db}
+}
+
+const (
+	userColumns    = "id, name, email, registration_date, role"
+	projectColumns = "id, title, description, creation_date, completion_date, manager_id"
+)
+
+func (m *GraphModelImpl) GetUsersByIDs(ids []int) ([]*User, error) {
+	return m.queryUsers("SELECT "+userColumns+" FROM users WHERE id = ANY($1) ORDER BY id", pq.Array(ids))
+}
+
+func (m *GraphModelImpl) GetProjectsByIDs(ids []int) ([]*Project, error) {
+	return m.queryProjects("SELECT "+projectColumns+" FROM projects WHERE id = ANY($1) ORDER BY id", pq.Array(ids))
+}
+
+func (m *GraphModelImpl) GetTasksByIDs(ids []int) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE id = ANY($1) ORDER BY id", pq.Array(ids))
+}
+
+func (m *GraphModelImpl) GetUserPage(filter UserFilter, page Page) (*UserPage, error) {
+	users, err := m.queryUsers("SELECT "+userColumns+" FROM users WHERE "+userFilterCondition+" AND id > $3 ORDER BY id LIMIT $4",
+		append(filter.args(), page.After, page.First+1)...)
+	if err != nil {
+		return nil, err
+	}
+	result := &UserPage{Users: users}
+	if len(users) > page.First {
+		result.Users, result.HasNextPage = users[:page.First], true
+	}
+	err = m.DB.QueryRow("SELECT COUNT(*) FROM users WHERE "+userFilterCondition, filter.args()...).Scan(&result.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *GraphModelImpl) GetProjectPage(filter ProjectFilter, page Page) (*ProjectPage, error) {
+	projects, err := m.queryProjects("SELECT "+projectColumns+" FROM projects WHERE "+projectFilterCondition+" AND id > $3 ORDER BY id LIMIT $4",
+		append(filter.args(), page.After, page.First+1)...)
+	if err != nil {
+		return nil, err
+	}
+	result := &ProjectPage{Projects: projects}
+	if len(projects) > page.First {
+		result.Projects, result.HasNextPage = projects[:page.First], true
+	}
+	err = m.DB.QueryRow("SELECT COUNT(*) FROM projects WHERE "+projectFilterCondition, filter.args()...).Scan(&result.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *GraphModelImpl) GetTaskPage(filter TaskFilter, page Page) (*TaskPage, error) {
+	tasks, err := queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE "+taskFilterCondition+" AND id > $6 ORDER BY id LIMIT $7",
+		append(filter.args(), page.After, page.First+1)...)
+	if err != nil {
+		return nil, err
+	}
+	result := &TaskPage{Tasks: tasks}
+	if len(tasks) > page.First {
+		result.Tasks, result.HasNextPage = tasks[:page.First], true
+	}
+	err = m.DB.QueryRow("SELECT COUNT(*) FROM tasks WHERE "+taskFilterCondition, filter.args()...).Scan(&result.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *GraphModelImpl) GetUsersTaskPages(userIDs []int, filter TaskFilter, page Page) (map[int]*TaskPage, error) {
+	return m.taskPages("responsible_user_id", func(task *Task) int { return task.ResponsibleUserID }, userIDs, filter, page)
+}
+
+func (m *GraphModelImpl) GetProjectsTaskPages(projectIDs []int, filter TaskFilter, page Page) (map[int]*TaskPage, error) {
+	return m.taskPages("project_id", func(task *Task) int { return task.ProjectID }, projectIDs, filter, page)
+}
+
+// taskPages pages the tasks of each of ids in the column, which key returns
+// for a task, numbering the tasks of each id apart.
+func (m *GraphModelImpl) taskPages(column string, key func(task *Task) int, ids []int, filter TaskFilter, page Page) (map[int]*TaskPage, error) {
+	pages := make(map[int]*TaskPage, len(ids))
+	for _, id := range ids {
+		pages[id] = &TaskPage{Tasks: []*Task{}}
+	}
+	tasks, err := queryTasks(m.DB, "SELECT "+taskColumns+" FROM (SELECT "+taskColumns+", ROW_NUMBER() OVER (PARTITION BY "+column+" ORDER BY id) AS row_number"+
+		" FROM tasks WHERE "+taskFilterCondition+" AND "+column+" = ANY($6) AND id > $7) numbered WHERE row_number <= $8 ORDER BY id",
+		append(filter.args(), pq.Array(ids), page.After, page.First+1)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		taskPage := pages[key(task)]
+		if len(taskPage.Tasks) == page.First {
+			taskPage.HasNextPage = true
+			continue
+		}
+		taskPage.Tasks = append(taskPage.Tasks, task)
+	}
+
+	rows, err := m.DB.Query("SELECT "+column+", COUNT(*) FROM tasks WHERE "+taskFilterCondition+" AND "+column+" = ANY($6) GROUP BY "+column,
+		append(filter.args(), pq.Array(ids))...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	for rows.Next() {
+		var id, count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		pages[id].TotalCount = count
+	}
+	return pages, rows.Err()
+}
+
+func (m *GraphModelImpl) queryUsers(query string, args ...any) ([]*User, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	users := make([]*User, 0)
+	for rows.Next() {
+		user := &User{}
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.RegistrationDate, &user.Role); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (m *GraphModelImpl) queryProjects(query string, args ...any) ([]*Project, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	projects := make([]*Project, 0)
+	for rows.Next() {
+		project := &Project{}
+		var completionDate sql.NullString
+		if err := rows.Scan(&project.ID, &project.Title, &project.Description, &project.CreationDate, &completionDate, &project.ManagerID); err != nil {
+			return nil, err
+		}
+		project.CompletionDate = completionDate.String
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
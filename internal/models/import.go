@@ -0,0 +1,137 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// ImportError is returned when the entity at Index of an import could not
+// be written. Nothing of the import is written then.
+type ImportError struct {
+	Index int
+	Err   error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Index+1, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+type ImportModel interface {
+	// GetUserIDsByEmail returns the ids of the users with the given emails,
+	// by lower-cased email.
+	GetUserIDsByEmail(emails []string) (map[string]int, error)
+	// GetProjectIDsByTitle returns the ids of the projects with the given
+	// titles, by title; titles are not unique.
+	GetProjectIDsByTitle(titles []string) (map[string][]int, error)
+	// ImportUsers, ImportProjects and ImportTasks create all of the given
+	// entities in one transaction and set their ids, or create none.
+	ImportUsers(users []*User) error
+	ImportProjects(projects []*Project) error
+	ImportTasks(tasks []*Task) error
+}
+
+type ImportModelImpl struct {
+	DB *sql.DB
+}
+
+func NewImportModel(db *sql.DB) *ImportModelImpl {
+	return &ImportModelImpl{DB: db}
+}
+
+func (m *ImportModelImpl) GetUserIDsByEmail(emails []string) (map[string]int, error) {
+	lowered := make([]string, 0, len(emails))
+	for _, email := range emails {
+		lowered = append(lowered, strings.ToLower(email))
+	}
+	rows, err := m.DB.Query("SELECT lower(email), id FROM users WHERE lower(email) = ANY($1) ORDER BY id", pq.Array(lowered))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	ids := make(map[string]int)
+	for rows.Next() {
+		var email string
+		var id int
+		if err := rows.Scan(&email, &id); err != nil {
+			return nil, err
+		}
+		if _, ok := ids[email]; !ok {
+			ids[email] = id
+		}
+	}
+	return ids, rows.Err()
+}
+
+func (m *ImportModelImpl) GetProjectIDsByTitle(titles []string) (map[string][]int, error) {
+	rows, err := m.DB.Query("SELECT title, id FROM projects WHERE title = ANY($1) ORDER BY id", pq.Array(titles))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	ids := make(map[string][]int)
+	for rows.Next() {
+		var title string
+		var id int
+		if err := rows.Scan(&title, &id); err != nil {
+			return nil, err
+		}
+		ids[title] = append(ids[title], id)
+	}
+	return ids, rows.Err()
+}
+
+func (m *ImportModelImpl) ImportUsers(users []*User) error {
+	return m.inTransaction(len(users), func(tx *sql.Tx, i int) error {
+		user := users[i]
+		return tx.QueryRow("INSERT INTO users (name, email, role) VALUES ($1, $2, $3) RETURNING id", user.Name, user.Email, user.Role).Scan(&user.ID)
+	})
+}
+
+func (m *ImportModelImpl) ImportProjects(projects []*Project) error {
+	return m.inTransaction(len(projects), func(tx *sql.Tx, i int) error {
+		project := projects[i]
+		return tx.QueryRow("INSERT INTO projects (title, description, manager_id) VALUES ($1, $2, $3) RETURNING id", project.Title, project.Description, project.ManagerID).Scan(&project.ID)
+	})
+}
+
+func (m *ImportModelImpl) ImportTasks(tasks []*Task) error {
+	return m.inTransaction(len(tasks), func(tx *sql.Tx, i int) error {
+		task := tasks[i]
+		return tx.QueryRow(`INSERT INTO tasks (title, description, priority, status, responsible_user_id, project_id, due_date, labels, story_points, original_estimate_minutes, remaining_estimate_minutes, start_date, completion_date)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CASE WHEN $4 = 'done' THEN COALESCE($13::date, current_date) END) RETURNING id`,
+			task.Title, task.Description, task.Priority, task.Status, task.ResponsibleUserID, task.ProjectID, nullableString(task.DueDate), labelsArray(task.Labels),
+			task.StoryPoints, task.OriginalEstimateMinutes, task.RemainingEstimateMinutes, nullableString(task.StartDate), nullableString(task.CompletionDate)).Scan(&task.ID)
+	})
+}
+
+// inTransaction calls insert for each of n entities in one transaction,
+// which is committed only if all of them are inserted.
+func (m *ImportModelImpl) inTransaction(n int, insert func(tx *sql.Tx, i int) error) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i := 0; i < n; i++ {
+		if err := insert(tx, i); err != nil {
+			return &ImportError{Index: i, Err: err}
+		}
+	}
+	return tx.Commit()
+}
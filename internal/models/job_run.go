@@ -0,0 +1,114 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	JobRunRunning   = "running"
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+)
+
+type JobRun struct {
+	ID          int    `json:"id"`
+	JobName     string `json:"job_name"`
+	ScheduledAt string `json:"scheduled_at"`
+	StartedAt   string `json:"started_at"`
+	FinishedAt  string `json:"finished_at"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+}
+
+type JobRunModel interface {
+	// StartRun records the start of a run and returns its id, or 0 when a run
+	// for the same job and scheduled time already exists.
+	StartRun(jobName string, scheduledAt time.Time) (int, error)
+	FinishRun(id int, status string, runErr string) error
+	GetLastScheduledAt(jobName string) (time.Time, error)
+	GetRuns(jobName string, limit int) ([]*JobRun, error)
+	PurgeRuns(before time.Time) (int, error)
+}
+
+type JobRunModelImpl struct {
+	DB *sql.DB
+}
+
+func NewJobRunModel(db *sql.DB) *JobRunModelImpl {
+	return &JobRunModelImpl{DB: db}
+}
+
+func (m *JobRunModelImpl) StartRun(jobName string, scheduledAt time.Time) (int, error) {
+	var id int
+	err := m.DB.QueryRow("INSERT INTO job_runs (job_name, scheduled_at, status) VALUES ($1, $2, $3) ON CONFLICT (job_name, scheduled_at) DO NOTHING RETURNING id",
+		jobName, scheduledAt.UTC(), JobRunRunning).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (m *JobRunModelImpl) FinishRun(id int, status string, runErr string) error {
+	_, err := m.DB.Exec("UPDATE job_runs SET status = $1, error = $2, finished_at = current_timestamp WHERE id = $3", status, nullableString(runErr), id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *JobRunModelImpl) GetLastScheduledAt(jobName string) (time.Time, error) {
+	var scheduledAt sql.NullTime
+	err := m.DB.QueryRow("SELECT MAX(scheduled_at) FROM job_runs WHERE job_name = $1", jobName).Scan(&scheduledAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return scheduledAt.Time, nil
+}
+
+func (m *JobRunModelImpl) GetRuns(jobName string, limit int) ([]*JobRun, error) {
+	query := "SELECT id, job_name, scheduled_at, started_at, finished_at, status, error FROM job_runs"
+	args := []any{limit}
+	if jobName != "" {
+		query += " WHERE job_name = $2"
+		args = append(args, jobName)
+	}
+	rows, err := m.DB.Query(query+" ORDER BY id DESC LIMIT $1", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	runs := make([]*JobRun, 0)
+	for rows.Next() {
+		run := &JobRun{}
+		var finishedAt, runErr sql.NullString
+		err := rows.Scan(&run.ID, &run.JobName, &run.ScheduledAt, &run.StartedAt, &finishedAt, &run.Status, &runErr)
+		if err != nil {
+			return nil, err
+		}
+		run.FinishedAt = finishedAt.String
+		run.Error = runErr.String
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+func (m *JobRunModelImpl) PurgeRuns(before time.Time) (int, error) {
+	result, err := m.DB.Exec("DELETE FROM job_runs WHERE scheduled_at < $1", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(deleted), nil
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Milestone is a target date within a project that a set of tasks has to meet.
+type Milestone struct {
+	ID           int    `json:"id"`
+	ProjectID    int    `json:"project_id"`
+	Title        string `json:"title" example:"Public beta"`
+	Description  string `json:"description"`
+	TargetDate   string `json:"target_date" example:"2021-11-30"`
+	CreationDate string `json:"creation_date"`
+}
+
+func (m *Milestone) Validate() error {
+	if m.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if _, err := ParseDate(m.TargetDate); err != nil {
+		return fmt.Errorf("invalid target_date, expected YYYY-MM-DD")
+	}
+	return nil
+}
+
+type MilestoneModel interface {
+	GetProjectMilestones(projectID int) ([]*Milestone, error)
+	GetMilestoneById(id int) (*Milestone, error)
+	CreateMilestone(milestone *Milestone) (int, error)
+	UpdateMilestone(milestone *Milestone) error
+	DeleteMilestone(id int) (int, error)
+	GetMilestoneTasks(id int) ([]*Task, error)
+}
+
+type MilestoneModelImpl struct {
+	DB *sql.DB
+}
+
+func NewMilestoneModel(db *sql.DB) *MilestoneModelImpl {
+	return &MilestoneModelImpl{DB: db}
+}
+
+const milestoneColumns = "id, project_id, title, description, target_date, creation_date"
+
+func (m *MilestoneModelImpl) GetProjectMilestones(projectID int) ([]*Milestone, error) {
+	rows, err := m.DB.Query("SELECT "+milestoneColumns+" FROM milestones WHERE project_id = $1 ORDER BY target_date, id", projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	milestones := make([]*Milestone, 0)
+	for rows.Next() {
+		milestone := &Milestone{}
+		err := scanMilestone(rows, milestone)
+		if err != nil {
+			return nil, err
+		}
+		milestones = append(milestones, milestone)
+	}
+	return milestones, rows.Err()
+}
+
+func (m *MilestoneModelImpl) GetMilestoneById(id int) (*Milestone, error) {
+	milestone := &Milestone{}
+	err := scanMilestone(m.DB.QueryRow("SELECT "+milestoneColumns+" FROM milestones WHERE id = $1", id), milestone)
+	if err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+func (m *MilestoneModelImpl) CreateMilestone(milestone *Milestone) (int, error) {
+	var id int
+	err := m.DB.QueryRow("INSERT INTO milestones (project_id, title, description, target_date) VALUES ($1, $2, $3, $4) RETURNING id",
+		milestone.ProjectID, milestone.Title, milestone.Description, milestone.TargetDate).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (m *MilestoneModelImpl) UpdateMilestone(milestone *Milestone) error {
+	_, err := m.DB.Exec("UPDATE milestones SET title = $1, description = $2, target_date = $3 WHERE id = $4",
+		milestone.Title, milestone.Description, milestone.TargetDate, milestone.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteMilestone removes the milestone; its tasks are kept and unlinked.
+func (m *MilestoneModelImpl) DeleteMilestone(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM milestones WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+func (m *MilestoneModelImpl) GetMilestoneTasks(id int) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE milestone_id = $1 ORDER BY id", id)
+}
+
+func scanMilestone(row rowScanner, milestone *Milestone) error {
+	return row.Scan(&milestone.ID, &milestone.ProjectID, &milestone.Title, &milestone.Description, &milestone.TargetDate, &milestone.CreationDate)
+}
@@ -0,0 +1,44 @@
+package models
+
+type MockBaselineModel struct {
+	MockGetProjectBaselines func(projectID int) ([]*Baseline, error)
+	MockGetBaselineById     func(id int) (*Baseline, error)
+	MockCreateBaseline      func(baseline *Baseline) (int, error)
+	MockDeleteBaseline      func(id int) (int, error)
+	MockGetBaselineTasks    func(id int) ([]*BaselineTask, error)
+}
+
+func (m *MockBaselineModel) GetProjectBaselines(projectID int) ([]*Baseline, error) {
+	if m.MockGetProjectBaselines != nil {
+		return m.MockGetProjectBaselines(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockBaselineModel) GetBaselineById(id int) (*Baseline, error) {
+	if m.MockGetBaselineById != nil {
+		return m.MockGetBaselineById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockBaselineModel) CreateBaseline(baseline *Baseline) (int, error) {
+	if m.MockCreateBaseline != nil {
+		return m.MockCreateBaseline(baseline)
+	}
+	return 0, nil
+}
+
+func (m *MockBaselineModel) DeleteBaseline(id int) (int, error) {
+	if m.MockDeleteBaseline != nil {
+		return m.MockDeleteBaseline(id)
+	}
+	return 0, nil
+}
+
+func (m *MockBaselineModel) GetBaselineTasks(id int) ([]*BaselineTask, error) {
+	if m.MockGetBaselineTasks != nil {
+		return m.MockGetBaselineTasks(id)
+	}
+	return nil, nil
+}
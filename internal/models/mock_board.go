@@ -0,0 +1,28 @@
+package models
+
+type MockBoardModel struct {
+	MockGetBoard     func(projectID int) (*Board, error)
+	MockSetWIPLimits func(projectID int, limits map[StatusEnum]int) error
+	MockMoveTask     func(taskID int, status StatusEnum, position int) (*Task, error)
+}
+
+func (m *MockBoardModel) GetBoard(projectID int) (*Board, error) {
+	if m.MockGetBoard != nil {
+		return m.MockGetBoard(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockBoardModel) SetWIPLimits(projectID int, limits map[StatusEnum]int) error {
+	if m.MockSetWIPLimits != nil {
+		return m.MockSetWIPLimits(projectID, limits)
+	}
+	return nil
+}
+
+func (m *MockBoardModel) MoveTask(taskID int, status StatusEnum, position int) (*Task, error) {
+	if m.MockMoveTask != nil {
+		return m.MockMoveTask(taskID, status, position)
+	}
+	return nil, nil
+}
@@ -0,0 +1,92 @@
+package models
+
+type MockCalendarModel struct {
+	MockGetFeedByToken        func(token string) (*CalendarFeed, error)
+	MockGetUserFeed           func(userID int) (*CalendarFeed, error)
+	MockGetProjectFeed        func(projectID int) (*CalendarFeed, error)
+	MockSetUserFeed           func(userID int, token string) (*CalendarFeed, error)
+	MockSetProjectFeed        func(projectID int, token string) (*CalendarFeed, error)
+	MockDeleteUserFeed        func(userID int) (int, error)
+	MockDeleteProjectFeed     func(projectID int) (int, error)
+	MockGetFeedTasks          func(feed *CalendarFeed) ([]*Task, error)
+	MockGetFeedMilestones     func(feed *CalendarFeed) ([]*Milestone, error)
+	MockGetTaskRevisions      func(taskIDs []int) (map[int]*Revision, error)
+	MockGetMilestoneRevisions func(milestoneIDs []int) (map[int]*Revision, error)
+}
+
+func (m *MockCalendarModel) GetFeedByToken(token string) (*CalendarFeed, error) {
+	if m.MockGetFeedByToken != nil {
+		return m.MockGetFeedByToken(token)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarModel) GetUserFeed(userID int) (*CalendarFeed, error) {
+	if m.MockGetUserFeed != nil {
+		return m.MockGetUserFeed(userID)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarModel) GetProjectFeed(projectID int) (*CalendarFeed, error) {
+	if m.MockGetProjectFeed != nil {
+		return m.MockGetProjectFeed(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarModel) SetUserFeed(userID int, token string) (*CalendarFeed, error) {
+	if m.MockSetUserFeed != nil {
+		return m.MockSetUserFeed(userID, token)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarModel) SetProjectFeed(projectID int, token string) (*CalendarFeed, error) {
+	if m.MockSetProjectFeed != nil {
+		return m.MockSetProjectFeed(projectID, token)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarModel) DeleteUserFeed(userID int) (int, error) {
+	if m.MockDeleteUserFeed != nil {
+		return m.MockDeleteUserFeed(userID)
+	}
+	return 0, nil
+}
+
+func (m *MockCalendarModel) DeleteProjectFeed(projectID int) (int, error) {
+	if m.MockDeleteProjectFeed != nil {
+		return m.MockDeleteProjectFeed(projectID)
+	}
+	return 0, nil
+}
+
+func (m *MockCalendarModel) GetFeedTasks(feed *CalendarFeed) ([]*Task, error) {
+	if m.MockGetFeedTasks != nil {
+		return m.MockGetFeedTasks(feed)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarModel) GetFeedMilestones(feed *CalendarFeed) ([]*Milestone, error) {
+	if m.MockGetFeedMilestones != nil {
+		return m.MockGetFeedMilestones(feed)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarModel) GetTaskRevisions(taskIDs []int) (map[int]*Revision, error) {
+	if m.MockGetTaskRevisions != nil {
+		return m.MockGetTaskRevisions(taskIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockCalendarModel) GetMilestoneRevisions(milestoneIDs []int) (map[int]*Revision, error) {
+	if m.MockGetMilestoneRevisions != nil {
+		return m.MockGetMilestoneRevisions(milestoneIDs)
+	}
+	return nil, nil
+}
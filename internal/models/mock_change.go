@@ -0,0 +1,20 @@
+package models
+
+type MockChangeModel struct {
+	MockGetChangesSince func(since int64, limit int) ([]*Change, error)
+	MockGetLatestSeq    func() (int64, error)
+}
+
+func (m *MockChangeModel) GetChangesSince(since int64, limit int) ([]*Change, error) {
+	if m.MockGetChangesSince != nil {
+		return m.MockGetChangesSince(since, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockChangeModel) GetLatestSeq() (int64, error) {
+	if m.MockGetLatestSeq != nil {
+		return m.MockGetLatestSeq()
+	}
+	return 0, nil
+}
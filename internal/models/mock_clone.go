@@ -0,0 +1,20 @@
+package models
+
+type MockCloneModel struct {
+	MockCloneProject func(id int, options *CloneOptions) (int, error)
+	MockCloneTask    func(id int, options *CloneOptions) (int, error)
+}
+
+func (m *MockCloneModel) CloneProject(id int, options *CloneOptions) (int, error) {
+	if m.MockCloneProject != nil {
+		return m.MockCloneProject(id, options)
+	}
+	return 0, nil
+}
+
+func (m *MockCloneModel) CloneTask(id int, options *CloneOptions) (int, error) {
+	if m.MockCloneTask != nil {
+		return m.MockCloneTask(id, options)
+	}
+	return 0, nil
+}
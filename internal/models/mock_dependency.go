@@ -0,0 +1,44 @@
+package models
+
+type MockDependencyModel struct {
+	MockGetTaskDependencies    func(taskID int) ([]*Dependency, error)
+	MockGetProjectDependencies func(projectID int) ([]*Dependency, error)
+	MockAddDependency          func(dependency *Dependency) error
+	MockRemoveDependency       func(predecessorID, successorID int) (int, error)
+	MockUpdateTaskDates        func(dates []TaskDates) error
+}
+
+func (m *MockDependencyModel) GetTaskDependencies(taskID int) ([]*Dependency, error) {
+	if m.MockGetTaskDependencies != nil {
+		return m.MockGetTaskDependencies(taskID)
+	}
+	return nil, nil
+}
+
+func (m *MockDependencyModel) GetProjectDependencies(projectID int) ([]*Dependency, error) {
+	if m.MockGetProjectDependencies != nil {
+		return m.MockGetProjectDependencies(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockDependencyModel) AddDependency(dependency *Dependency) error {
+	if m.MockAddDependency != nil {
+		return m.MockAddDependency(dependency)
+	}
+	return nil
+}
+
+func (m *MockDependencyModel) RemoveDependency(predecessorID, successorID int) (int, error) {
+	if m.MockRemoveDependency != nil {
+		return m.MockRemoveDependency(predecessorID, successorID)
+	}
+	return 0, nil
+}
+
+func (m *MockDependencyModel) UpdateTaskDates(dates []TaskDates) error {
+	if m.MockUpdateTaskDates != nil {
+		return m.MockUpdateTaskDates(dates)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package models
+
+type MockEpicModel struct {
+	MockGetProjectEpics func(projectID int) ([]*Epic, error)
+	MockGetEpicById     func(id int) (*Epic, error)
+	MockCreateEpic      func(epic *Epic) (int, error)
+	MockUpdateEpic      func(epic *Epic) error
+	MockDeleteEpic      func(id int) (int, error)
+	MockGetEpicTasks    func(id int) ([]*Task, error)
+}
+
+func (m *MockEpicModel) GetProjectEpics(projectID int) ([]*Epic, error) {
+	if m.MockGetProjectEpics != nil {
+		return m.MockGetProjectEpics(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockEpicModel) GetEpicById(id int) (*Epic, error) {
+	if m.MockGetEpicById != nil {
+		return m.MockGetEpicById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockEpicModel) CreateEpic(epic *Epic) (int, error) {
+	if m.MockCreateEpic != nil {
+		return m.MockCreateEpic(epic)
+	}
+	return 0, nil
+}
+
+func (m *MockEpicModel) UpdateEpic(epic *Epic) error {
+	if m.MockUpdateEpic != nil {
+		return m.MockUpdateEpic(epic)
+	}
+	return nil
+}
+
+func (m *MockEpicModel) DeleteEpic(id int) (int, error) {
+	if m.MockDeleteEpic != nil {
+		return m.MockDeleteEpic(id)
+	}
+	return 0, nil
+}
+
+func (m *MockEpicModel) GetEpicTasks(id int) ([]*Task, error) {
+	if m.MockGetEpicTasks != nil {
+		return m.MockGetEpicTasks(id)
+	}
+	return nil, nil
+}
@@ -0,0 +1,28 @@
+package models
+
+type MockExportModel struct {
+	MockEachTask    func(filter TaskFilter, fn func(task *Task) error) error
+	MockEachProject func(filter ProjectFilter, fn func(project *Project) error) error
+	MockEachUser    func(filter UserFilter, fn func(user *User) error) error
+}
+
+func (m *MockExportModel) EachTask(filter TaskFilter, fn func(task *Task) error) error {
+	if m.MockEachTask != nil {
+		return m.MockEachTask(filter, fn)
+	}
+	return nil
+}
+
+func (m *MockExportModel) EachProject(filter ProjectFilter, fn func(project *Project) error) error {
+	if m.MockEachProject != nil {
+		return m.MockEachProject(filter, fn)
+	}
+	return nil
+}
+
+func (m *MockExportModel) EachUser(filter UserFilter, fn func(user *User) error) error {
+	if m.MockEachUser != nil {
+		return m.MockEachUser(filter, fn)
+	}
+	return nil
+}
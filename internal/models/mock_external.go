@@ -0,0 +1,20 @@
+package models
+
+type MockExternalModel struct {
+	MockGetExternalIDs func(source, kind string, externalIDs []string) (map[string]int, error)
+	MockSyncExternal   func(source string, users map[string]int, projects []*ExternalProject) (*ExternalSync, error)
+}
+
+func (m *MockExternalModel) GetExternalIDs(source, kind string, externalIDs []string) (map[string]int, error) {
+	if m.MockGetExternalIDs != nil {
+		return m.MockGetExternalIDs(source, kind, externalIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockExternalModel) SyncExternal(source string, users map[string]int, projects []*ExternalProject) (*ExternalSync, error) {
+	if m.MockSyncExternal != nil {
+		return m.MockSyncExternal(source, users, projects)
+	}
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package models
+
+type MockGraphModel struct {
+	MockGetUsersByIDs        func(ids []int) ([]*User, error)
+	MockGetProjectsByIDs     func(ids []int) ([]*Project, error)
+	MockGetTasksByIDs        func(ids []int) ([]*Task, error)
+	MockGetUserPage          func(filter UserFilter, page Page) (*UserPage, error)
+	MockGetProjectPage       func(filter ProjectFilter, page Page) (*ProjectPage, error)
+	MockGetTaskPage          func(filter TaskFilter, page Page) (*TaskPage, error)
+	MockGetUsersTaskPages    func(userIDs []int, filter TaskFilter, page Page) (map[int]*TaskPage, error)
+	MockGetProjectsTaskPages func(projectIDs []int, filter TaskFilter, page Page) (map[int]*TaskPage, error)
+}
+
+func (m *MockGraphModel) GetUsersByIDs(ids []int) ([]*User, error) {
+	if m.MockGetUsersByIDs != nil {
+		return m.MockGetUsersByIDs(ids)
+	}
+	return nil, nil
+}
+
+func (m *MockGraphModel) GetProjectsByIDs(ids []int) ([]*Project, error) {
+	if m.MockGetProjectsByIDs != nil {
+		return m.MockGetProjectsByIDs(ids)
+	}
+	return nil, nil
+}
+
+func (m *MockGraphModel) GetTasksByIDs(ids []int) ([]*Task, error) {
+	if m.MockGetTasksByIDs != nil {
+		return m.MockGetTasksByIDs(ids)
+	}
+	return nil, nil
+}
+
+func (m *MockGraphModel) GetUserPage(filter UserFilter, page Page) (*UserPage, error) {
+	if m.MockGetUserPage != nil {
+		return m.MockGetUserPage(filter, page)
+	}
+	return nil, nil
+}
+
+func (m *MockGraphModel) GetProjectPage(filter ProjectFilter, page Page) (*ProjectPage, error) {
+	if m.MockGetProjectPage != nil {
+		return m.MockGetProjectPage(filter, page)
+	}
+	return nil, nil
+}
+
+func (m *MockGraphModel) GetTaskPage(filter TaskFilter, page Page) (*TaskPage, error) {
+	if m.MockGetTaskPage != nil {
+		return m.MockGetTaskPage(filter, page)
+	}
+	return nil, nil
+}
+
+func (m *MockGraphModel) GetUsersTaskPages(userIDs []int, filter TaskFilter, page Page) (map[int]*TaskPage, error) {
+	if m.MockGetUsersTaskPages != nil {
+		return m.MockGetUsersTaskPages(userIDs, filter, page)
+	}
+	return nil, nil
+}
+
+func (m *MockGraphModel) GetProjectsTaskPages(projectIDs []int, filter TaskFilter, page Page) (map[int]*TaskPage, error) {
+	if m.MockGetProjectsTaskPages != nil {
+		return m.MockGetProjectsTaskPages(projectIDs, filter, page)
+	}
+	return nil, nil
+}
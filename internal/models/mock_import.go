@@ -0,0 +1,44 @@
+package models
+
+type MockImportModel struct {
+	MockGetUserIDsByEmail    func(emails []string) (map[string]int, error)
+	MockGetProjectIDsByTitle func(titles []string) (map[string][]int, error)
+	MockImportUsers          func(users []*User) error
+	MockImportProjects       func(projects []*Project) error
+	MockImportTasks          func(tasks []*Task) error
+}
+
+func (m *MockImportModel) GetUserIDsByEmail(emails []string) (map[string]int, error) {
+	if m.MockGetUserIDsByEmail != nil {
+		return m.MockGetUserIDsByEmail(emails)
+	}
+	return nil, nil
+}
+
+func (m *MockImportModel) GetProjectIDsByTitle(titles []string) (map[string][]int, error) {
+	if m.MockGetProjectIDsByTitle != nil {
+		return m.MockGetProjectIDsByTitle(titles)
+	}
+	return nil, nil
+}
+
+func (m *MockImportModel) ImportUsers(users []*User) error {
+	if m.MockImportUsers != nil {
+		return m.MockImportUsers(users)
+	}
+	return nil
+}
+
+func (m *MockImportModel) ImportProjects(projects []*Project) error {
+	if m.MockImportProjects != nil {
+		return m.MockImportProjects(projects)
+	}
+	return nil
+}
+
+func (m *MockImportModel) ImportTasks(tasks []*Task) error {
+	if m.MockImportTasks != nil {
+		return m.MockImportTasks(tasks)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+type MockJobRunModel struct {
+	MockStartRun           func(jobName string, scheduledAt time.Time) (int, error)
+	MockFinishRun          func(id int, status string, runErr string) error
+	MockGetLastScheduledAt func(jobName string) (time.Time, error)
+	MockGetRuns            func(jobName string, limit int) ([]*JobRun, error)
+	MockPurgeRuns          func(before time.Time) (int, error)
+}
+
+func (m *MockJobRunModel) StartRun(jobName string, scheduledAt time.Time) (int, error) {
+	if m.MockStartRun != nil {
+		return m.MockStartRun(jobName, scheduledAt)
+	}
+	return 0, nil
+}
+
+func (m *MockJobRunModel) FinishRun(id int, status string, runErr string) error {
+	if m.MockFinishRun != nil {
+		return m.MockFinishRun(id, status, runErr)
+	}
+	return nil
+}
+
+func (m *MockJobRunModel) GetLastScheduledAt(jobName string) (time.Time, error) {
+	if m.MockGetLastScheduledAt != nil {
+		return m.MockGetLastScheduledAt(jobName)
+	}
+	return time.Time{}, nil
+}
+
+func (m *MockJobRunModel) GetRuns(jobName string, limit int) ([]*JobRun, error) {
+	if m.MockGetRuns != nil {
+		return m.MockGetRuns(jobName, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockJobRunModel) PurgeRuns(before time.Time) (int, error) {
+	if m.MockPurgeRuns != nil {
+		return m.MockPurgeRuns(before)
+	}
+	return 0, nil
+}
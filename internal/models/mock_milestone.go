@@ -0,0 +1,52 @@
+package models
+
+type MockMilestoneModel struct {
+	MockGetProjectMilestones func(projectID int) ([]*Milestone, error)
+	MockGetMilestoneById     func(id int) (*Milestone, error)
+	MockCreateMilestone      func(milestone *Milestone) (int, error)
+	MockUpdateMilestone      func(milestone *Milestone) error
+	MockDeleteMilestone      func(id int) (int, error)
+	MockGetMilestoneTasks    func(id int) ([]*Task, error)
+}
+
+func (m *MockMilestoneModel) GetProjectMilestones(projectID int) ([]*Milestone, error) {
+	if m.MockGetProjectMilestones != nil {
+		return m.MockGetProjectMilestones(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockMilestoneModel) GetMilestoneById(id int) (*Milestone, error) {
+	if m.MockGetMilestoneById != nil {
+		return m.MockGetMilestoneById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockMilestoneModel) CreateMilestone(milestone *Milestone) (int, error) {
+	if m.MockCreateMilestone != nil {
+		return m.MockCreateMilestone(milestone)
+	}
+	return 0, nil
+}
+
+func (m *MockMilestoneModel) UpdateMilestone(milestone *Milestone) error {
+	if m.MockUpdateMilestone != nil {
+		return m.MockUpdateMilestone(milestone)
+	}
+	return nil
+}
+
+func (m *MockMilestoneModel) DeleteMilestone(id int) (int, error) {
+	if m.MockDeleteMilestone != nil {
+		return m.MockDeleteMilestone(id)
+	}
+	return 0, nil
+}
+
+func (m *MockMilestoneModel) GetMilestoneTasks(id int) ([]*Task, error) {
+	if m.MockGetMilestoneTasks != nil {
+		return m.MockGetMilestoneTasks(id)
+	}
+	return nil, nil
+}
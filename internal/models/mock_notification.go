@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+type MockNotificationModel struct {
+	MockCreateNotification   func(userID int, notificationType NotificationType, message string, taskID, projectID int) error
+	MockGetUserNotifications func(userID int, unreadOnly bool) ([]*Notification, error)
+	MockGetUnreadCount       func(userID int) (int, error)
+	MockMarkRead             func(id int) (int, error)
+	MockMarkAllRead          func(userID int) (int, error)
+	MockGetPreferences       func(userID int) (*NotificationPreferences, error)
+	MockUpdatePreferences    func(preferences *NotificationPreferences) error
+	MockGetDigestSubscribers func() ([]int, error)
+	MockPurgeRead            func(before time.Time) (int, error)
+	MockGetTaskWatchers      func(taskID int) ([]int, error)
+	MockAddTaskWatcher       func(taskID, userID int) error
+	MockRemoveTaskWatcher    func(taskID, userID int) (int, error)
+}
+
+func (m *MockNotificationModel) CreateNotification(userID int, notificationType NotificationType, message string, taskID, projectID int) error {
+	if m.MockCreateNotification != nil {
+		return m.MockCreateNotification(userID, notificationType, message, taskID, projectID)
+	}
+	return nil
+}
+
+func (m *MockNotificationModel) GetUserNotifications(userID int, unreadOnly bool) ([]*Notification, error) {
+	if m.MockGetUserNotifications != nil {
+		return m.MockGetUserNotifications(userID, unreadOnly)
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationModel) GetUnreadCount(userID int) (int, error) {
+	if m.MockGetUnreadCount != nil {
+		return m.MockGetUnreadCount(userID)
+	}
+	return 0, nil
+}
+
+func (m *MockNotificationModel) MarkRead(id int) (int, error) {
+	if m.MockMarkRead != nil {
+		return m.MockMarkRead(id)
+	}
+	return 0, nil
+}
+
+func (m *MockNotificationModel) MarkAllRead(userID int) (int, error) {
+	if m.MockMarkAllRead != nil {
+		return m.MockMarkAllRead(userID)
+	}
+	return 0, nil
+}
+
+func (m *MockNotificationModel) GetPreferences(userID int) (*NotificationPreferences, error) {
+	if m.MockGetPreferences != nil {
+		return m.MockGetPreferences(userID)
+	}
+	return DefaultNotificationPreferences(userID), nil
+}
+
+func (m *MockNotificationModel) UpdatePreferences(preferences *NotificationPreferences) error {
+	if m.MockUpdatePreferences != nil {
+		return m.MockUpdatePreferences(preferences)
+	}
+	return nil
+}
+
+func (m *MockNotificationModel) GetDigestSubscribers() ([]int, error) {
+	if m.MockGetDigestSubscribers != nil {
+		return m.MockGetDigestSubscribers()
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationModel) PurgeRead(before time.Time) (int, error) {
+	if m.MockPurgeRead != nil {
+		return m.MockPurgeRead(before)
+	}
+	return 0, nil
+}
+
+func (m *MockNotificationModel) GetTaskWatchers(taskID int) ([]int, error) {
+	if m.MockGetTaskWatchers != nil {
+		return m.MockGetTaskWatchers(taskID)
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationModel) AddTaskWatcher(taskID, userID int) error {
+	if m.MockAddTaskWatcher != nil {
+		return m.MockAddTaskWatcher(taskID, userID)
+	}
+	return nil
+}
+
+func (m *MockNotificationModel) RemoveTaskWatcher(taskID, userID int) (int, error) {
+	if m.MockRemoveTaskWatcher != nil {
+		return m.MockRemoveTaskWatcher(taskID, userID)
+	}
+	return 0, nil
+}
@@ -0,0 +1,76 @@
+package models
+
+type MockProjectModel struct {
+	MockGetProjects               func() ([]Project, error)
+	MockCreateProject             func(title, description string, managerID int) (int, error)
+	MockGetProjectByID            func(id int) (*Project, error)
+	MockUpdateProject             func(id int, title, description string, managerID int) error
+	MockCompleteProject           func(id int, completionDate string) error
+	MockDeleteProject             func(id int) (int, error)
+	MockGetProjectTasks           func(id int) ([]Task, error)
+	MockSearchProjectsByTitle     func(title string) ([]Project, error)
+	MockSearchProjectsByManagerID func(managerID int) ([]Project, error)
+}
+
+func (m *MockProjectModel) GetProjects() ([]Project, error) {
+	if m.MockGetProjects != nil {
+		return m.MockGetProjects()
+	}
+	return nil, nil
+}
+
+func (m *MockProjectModel) CreateProject(title, description string, managerID int) (int, error) {
+	if m.MockCreateProject != nil {
+		return m.MockCreateProject(title, description, managerID)
+	}
+	return 0, nil
+}
+
+func (m *MockProjectModel) GetProjectByID(id int) (*Project, error) {
+	if m.MockGetProjectByID != nil {
+		return m.MockGetProjectByID(id)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectModel) UpdateProject(id int, title, description string, managerID int) error {
+	if m.MockUpdateProject != nil {
+		return m.MockUpdateProject(id, title, description, managerID)
+	}
+	return nil
+}
+
+func (m *MockProjectModel) CompleteProject(id int, completionDate string) error {
+	if m.MockCompleteProject != nil {
+		return m.MockCompleteProject(id, completionDate)
+	}
+	return nil
+}
+
+func (m *MockProjectModel) DeleteProject(id int) (int, error) {
+	if m.MockDeleteProject != nil {
+		return m.MockDeleteProject(id)
+	}
+	return 0, nil
+}
+
+func (m *MockProjectModel) GetProjectTasks(id int) ([]Task, error) {
+	if m.MockGetProjectTasks != nil {
+		return m.MockGetProjectTasks(id)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectModel) SearchProjectsByTitle(title string) ([]Project, error) {
+	if m.MockSearchProjectsByTitle != nil {
+		return m.MockSearchProjectsByTitle(title)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectModel) SearchProjectsByManagerID(managerID int) ([]Project, error) {
+	if m.MockSearchProjectsByManagerID != nil {
+		return m.MockSearchProjectsByManagerID(managerID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,12 @@
+package models
+
+type MockReportModel struct {
+	MockGetProjectReports func(managerID int) ([]*ProjectReport, error)
+}
+
+func (m *MockReportModel) GetProjectReports(managerID int) ([]*ProjectReport, error) {
+	if m.MockGetProjectReports != nil {
+		return m.MockGetProjectReports(managerID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+type MockRuleModel struct {
+	MockGetRules        func() ([]*Rule, error)
+	MockGetRuleById     func(id int) (*Rule, error)
+	MockCreateRule      func(rule *Rule) (int, error)
+	MockUpdateRule      func(rule *Rule) error
+	MockDeleteRule      func(id int) (int, error)
+	MockGetEnabledRules func(trigger RuleTrigger, projectID int) ([]*Rule, error)
+	MockLogRun          func(ruleID, taskID, projectID int, status, message string) error
+	MockGetRuleRuns     func(ruleID int, limit int) ([]*RuleRun, error)
+	MockPurgeRuns       func(before time.Time) (int, error)
+}
+
+func (m *MockRuleModel) GetRules() ([]*Rule, error) {
+	if m.MockGetRules != nil {
+		return m.MockGetRules()
+	}
+	return nil, nil
+}
+
+func (m *MockRuleModel) GetRuleById(id int) (*Rule, error) {
+	if m.MockGetRuleById != nil {
+		return m.MockGetRuleById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockRuleModel) CreateRule(rule *Rule) (int, error) {
+	if m.MockCreateRule != nil {
+		return m.MockCreateRule(rule)
+	}
+	return 0, nil
+}
+
+func (m *MockRuleModel) UpdateRule(rule *Rule) error {
+	if m.MockUpdateRule != nil {
+		return m.MockUpdateRule(rule)
+	}
+	return nil
+}
+
+func (m *MockRuleModel) DeleteRule(id int) (int, error) {
+	if m.MockDeleteRule != nil {
+		return m.MockDeleteRule(id)
+	}
+	return 0, nil
+}
+
+func (m *MockRuleModel) GetEnabledRules(trigger RuleTrigger, projectID int) ([]*Rule, error) {
+	if m.MockGetEnabledRules != nil {
+		return m.MockGetEnabledRules(trigger, projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockRuleModel) LogRun(ruleID, taskID, projectID int, status, message string) error {
+	if m.MockLogRun != nil {
+		return m.MockLogRun(ruleID, taskID, projectID, status, message)
+	}
+	return nil
+}
+
+func (m *MockRuleModel) GetRuleRuns(ruleID int, limit int) ([]*RuleRun, error) {
+	if m.MockGetRuleRuns != nil {
+		return m.MockGetRuleRuns(ruleID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockRuleModel) PurgeRuns(before time.Time) (int, error) {
+	if m.MockPurgeRuns != nil {
+		return m.MockPurgeRuns(before)
+	}
+	return 0, nil
+}
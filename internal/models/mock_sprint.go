@@ -0,0 +1,108 @@
+package models
+
+type MockSprintModel struct {
+	MockGetProjectSprints    func(projectID int) ([]*Sprint, error)
+	MockGetSprintById        func(id int) (*Sprint, error)
+	MockCreateSprint         func(sprint *Sprint) (int, error)
+	MockUpdateSprint         func(sprint *Sprint) error
+	MockDeleteSprint         func(id int) (int, error)
+	MockGetActiveSprint      func(projectID int) (*Sprint, error)
+	MockGetNextPlannedSprint func(projectID int) (*Sprint, error)
+	MockGetSprintTasks       func(id int) ([]*Task, error)
+	MockGetBurndownTasks     func(id int) ([]*Task, error)
+	MockAddTasks             func(id int, taskIDs []int) error
+	MockRemoveTask           func(id, taskID int) (int, error)
+	MockStartSprint          func(id int) error
+	MockCompleteSprint       func(id, nextSprintID int, date string) (int, error)
+}
+
+func (m *MockSprintModel) GetProjectSprints(projectID int) ([]*Sprint, error) {
+	if m.MockGetProjectSprints != nil {
+		return m.MockGetProjectSprints(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockSprintModel) GetSprintById(id int) (*Sprint, error) {
+	if m.MockGetSprintById != nil {
+		return m.MockGetSprintById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockSprintModel) CreateSprint(sprint *Sprint) (int, error) {
+	if m.MockCreateSprint != nil {
+		return m.MockCreateSprint(sprint)
+	}
+	return 0, nil
+}
+
+func (m *MockSprintModel) UpdateSprint(sprint *Sprint) error {
+	if m.MockUpdateSprint != nil {
+		return m.MockUpdateSprint(sprint)
+	}
+	return nil
+}
+
+func (m *MockSprintModel) DeleteSprint(id int) (int, error) {
+	if m.MockDeleteSprint != nil {
+		return m.MockDeleteSprint(id)
+	}
+	return 0, nil
+}
+
+func (m *MockSprintModel) GetActiveSprint(projectID int) (*Sprint, error) {
+	if m.MockGetActiveSprint != nil {
+		return m.MockGetActiveSprint(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockSprintModel) GetNextPlannedSprint(projectID int) (*Sprint, error) {
+	if m.MockGetNextPlannedSprint != nil {
+		return m.MockGetNextPlannedSprint(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockSprintModel) GetSprintTasks(id int) ([]*Task, error) {
+	if m.MockGetSprintTasks != nil {
+		return m.MockGetSprintTasks(id)
+	}
+	return nil, nil
+}
+
+func (m *MockSprintModel) GetBurndownTasks(id int) ([]*Task, error) {
+	if m.MockGetBurndownTasks != nil {
+		return m.MockGetBurndownTasks(id)
+	}
+	return nil, nil
+}
+
+func (m *MockSprintModel) AddTasks(id int, taskIDs []int) error {
+	if m.MockAddTasks != nil {
+		return m.MockAddTasks(id, taskIDs)
+	}
+	return nil
+}
+
+func (m *MockSprintModel) RemoveTask(id, taskID int) (int, error) {
+	if m.MockRemoveTask != nil {
+		return m.MockRemoveTask(id, taskID)
+	}
+	return 0, nil
+}
+
+func (m *MockSprintModel) StartSprint(id int) error {
+	if m.MockStartSprint != nil {
+		return m.MockStartSprint(id)
+	}
+	return nil
+}
+
+func (m *MockSprintModel) CompleteSprint(id, nextSprintID int, date string) (int, error) {
+	if m.MockCompleteSprint != nil {
+		return m.MockCompleteSprint(id, nextSprintID, date)
+	}
+	return 0, nil
+}
@@ -0,0 +1,12 @@
+package models
+
+type MockStatusHistoryModel struct {
+	MockGetStatusHistory func(taskIDs []int) ([]*StatusChange, error)
+}
+
+func (m *MockStatusHistoryModel) GetStatusHistory(taskIDs []int) ([]*StatusChange, error) {
+	if m.MockGetStatusHistory != nil {
+		return m.MockGetStatusHistory(taskIDs)
+	}
+	return nil, nil
+}
@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+type MockTaskModel struct {
+	MockGetTasks                      func() ([]*Task, error)
+	MockCreateTask                    func(task *Task) (int, error)
+	MockGetTaskById                   func(id int) (*Task, error)
+	MockUpdateTask                    func(task *Task) error
+	MockDeleteTask                    func(id int) (int, error)
+	MockSearchTaskByTitle             func(title string) ([]*Task, error)
+	MockSearchTaskByStatus            func(status StatusEnum) ([]*Task, error)
+	MockSearchTaskByPriority          func(priority PriorityEnum) ([]*Task, error)
+	MockSearchTaskByResponsibleUserID func(responsibleUserID int) ([]*Task, error)
+	MockSearchTaskByProjectID         func(projectID int) ([]*Task, error)
+	MockGetOpenTasksDueBetween        func(from, to string) ([]*Task, error)
+	MockGetStaleTasks                 func(before time.Time) ([]*Task, error)
+}
+
+func (m *MockTaskModel) GetTasks() ([]*Task, error) {
+	if m.MockGetTasks != nil {
+		return m.MockGetTasks()
+	}
+	return nil, nil
+}
+
+func (m *MockTaskModel) CreateTask(task *Task) (int, error) {
+	if m.MockCreateTask != nil {
+		return m.MockCreateTask(task)
+	}
+	return 0, nil
+}
+
+func (m *MockTaskModel) GetTaskById(id int) (*Task, error) {
+	if m.MockGetTaskById != nil {
+		return m.MockGetTaskById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskModel) UpdateTask(task *Task) error {
+	if m.MockUpdateTask != nil {
+		return m.MockUpdateTask(task)
+	}
+	return nil
+}
+
+func (m *MockTaskModel) DeleteTask(id int) (int, error) {
+	if m.MockDeleteTask != nil {
+		return m.MockDeleteTask(id)
+	}
+	return 0, nil
+}
+
+func (m *MockTaskModel) SearchTaskByTitle(title string) ([]*Task, error) {
+	if m.MockSearchTaskByTitle != nil {
+		return m.MockSearchTaskByTitle(title)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskModel) SearchTaskByStatus(status StatusEnum) ([]*Task, error) {
+	if m.MockSearchTaskByStatus != nil {
+		return m.MockSearchTaskByStatus(status)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskModel) SearchTaskByPriority(priority PriorityEnum) ([]*Task, error) {
+	if m.MockSearchTaskByPriority != nil {
+		return m.MockSearchTaskByPriority(priority)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskModel) SearchTaskByResponsibleUserID(responsibleUserID int) ([]*Task, error) {
+	if m.MockSearchTaskByResponsibleUserID != nil {
+		return m.MockSearchTaskByResponsibleUserID(responsibleUserID)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskModel) SearchTaskByProjectID(projectID int) ([]*Task, error) {
+	if m.MockSearchTaskByProjectID != nil {
+		return m.MockSearchTaskByProjectID(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskModel) GetOpenTasksDueBetween(from, to string) ([]*Task, error) {
+	if m.MockGetOpenTasksDueBetween != nil {
+		return m.MockGetOpenTasksDueBetween(from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockTaskModel) GetStaleTasks(before time.Time) ([]*Task, error) {
+	if m.MockGetStaleTasks != nil {
+		return m.MockGetStaleTasks(before)
+	}
+	return nil, nil
+}
@@ -0,0 +1,44 @@
+package models
+
+type MockTemplateModel struct {
+	MockGetTemplates        func() ([]*Template, error)
+	MockGetTemplateById     func(id int) (*Template, error)
+	MockCreateTemplate      func(template *Template) (int, error)
+	MockDeleteTemplate      func(id int) (int, error)
+	MockInstantiateTemplate func(template *Template, instance *ProjectInstance) (int, error)
+}
+
+func (m *MockTemplateModel) GetTemplates() ([]*Template, error) {
+	if m.MockGetTemplates != nil {
+		return m.MockGetTemplates()
+	}
+	return nil, nil
+}
+
+func (m *MockTemplateModel) GetTemplateById(id int) (*Template, error) {
+	if m.MockGetTemplateById != nil {
+		return m.MockGetTemplateById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockTemplateModel) CreateTemplate(template *Template) (int, error) {
+	if m.MockCreateTemplate != nil {
+		return m.MockCreateTemplate(template)
+	}
+	return 0, nil
+}
+
+func (m *MockTemplateModel) DeleteTemplate(id int) (int, error) {
+	if m.MockDeleteTemplate != nil {
+		return m.MockDeleteTemplate(id)
+	}
+	return 0, nil
+}
+
+func (m *MockTemplateModel) InstantiateTemplate(template *Template, instance *ProjectInstance) (int, error) {
+	if m.MockInstantiateTemplate != nil {
+		return m.MockInstantiateTemplate(template, instance)
+	}
+	return 0, nil
+}
@@ -0,0 +1,68 @@
+package models
+
+type MockTimesheetModel struct {
+	MockGetUserTimesheets    func(userID int) ([]*Timesheet, error)
+	MockGetTimesheetById     func(id int) (*Timesheet, error)
+	MockGetOrCreateTimesheet func(userID int, weekStart string) (*Timesheet, bool, error)
+	MockGetTimesheetWorklogs func(timesheet *Timesheet) ([]*Worklog, error)
+	MockGetTransitions       func(id int) ([]*TimesheetTransition, error)
+	MockGetTimesheetManagers func(timesheet *Timesheet) ([]int, error)
+	MockTransition           func(timesheet *Timesheet, next TimesheetStatus, userID int, comment string) error
+	MockGetApprovedEntries   func(projectID int, from, to string) ([]*BillableEntry, error)
+}
+
+func (m *MockTimesheetModel) GetUserTimesheets(userID int) ([]*Timesheet, error) {
+	if m.MockGetUserTimesheets != nil {
+		return m.MockGetUserTimesheets(userID)
+	}
+	return nil, nil
+}
+
+func (m *MockTimesheetModel) GetTimesheetById(id int) (*Timesheet, error) {
+	if m.MockGetTimesheetById != nil {
+		return m.MockGetTimesheetById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockTimesheetModel) GetOrCreateTimesheet(userID int, weekStart string) (*Timesheet, bool, error) {
+	if m.MockGetOrCreateTimesheet != nil {
+		return m.MockGetOrCreateTimesheet(userID, weekStart)
+	}
+	return nil, false, nil
+}
+
+func (m *MockTimesheetModel) GetTimesheetWorklogs(timesheet *Timesheet) ([]*Worklog, error) {
+	if m.MockGetTimesheetWorklogs != nil {
+		return m.MockGetTimesheetWorklogs(timesheet)
+	}
+	return nil, nil
+}
+
+func (m *MockTimesheetModel) GetTransitions(id int) ([]*TimesheetTransition, error) {
+	if m.MockGetTransitions != nil {
+		return m.MockGetTransitions(id)
+	}
+	return nil, nil
+}
+
+func (m *MockTimesheetModel) GetTimesheetManagers(timesheet *Timesheet) ([]int, error) {
+	if m.MockGetTimesheetManagers != nil {
+		return m.MockGetTimesheetManagers(timesheet)
+	}
+	return nil, nil
+}
+
+func (m *MockTimesheetModel) Transition(timesheet *Timesheet, next TimesheetStatus, userID int, comment string) error {
+	if m.MockTransition != nil {
+		return m.MockTransition(timesheet, next, userID, comment)
+	}
+	return nil
+}
+
+func (m *MockTimesheetModel) GetApprovedEntries(projectID int, from, to string) ([]*BillableEntry, error) {
+	if m.MockGetApprovedEntries != nil {
+		return m.MockGetApprovedEntries(projectID, from, to)
+	}
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package models
+
+type MockWorkloadModel struct {
+	MockGetCapacity          func(userID int) (*Capacity, error)
+	MockSetCapacity          func(capacity *Capacity) error
+	MockGetCapacities        func(projectID int, team string) ([]*Capacity, error)
+	MockGetUserTimeOff       func(userID int) ([]*TimeOff, error)
+	MockGetTimeOffBetween    func(from, to string) ([]*TimeOff, error)
+	MockCreateTimeOff        func(timeOff *TimeOff) (int, error)
+	MockDeleteTimeOff        func(id int) (int, error)
+	MockGetOpenAssignedTasks func(projectID int) ([]*Task, error)
+}
+
+func (m *MockWorkloadModel) GetCapacity(userID int) (*Capacity, error) {
+	if m.MockGetCapacity != nil {
+		return m.MockGetCapacity(userID)
+	}
+	return nil, nil
+}
+
+func (m *MockWorkloadModel) SetCapacity(capacity *Capacity) error {
+	if m.MockSetCapacity != nil {
+		return m.MockSetCapacity(capacity)
+	}
+	return nil
+}
+
+func (m *MockWorkloadModel) GetCapacities(projectID int, team string) ([]*Capacity, error) {
+	if m.MockGetCapacities != nil {
+		return m.MockGetCapacities(projectID, team)
+	}
+	return nil, nil
+}
+
+func (m *MockWorkloadModel) GetUserTimeOff(userID int) ([]*TimeOff, error) {
+	if m.MockGetUserTimeOff != nil {
+		return m.MockGetUserTimeOff(userID)
+	}
+	return nil, nil
+}
+
+func (m *MockWorkloadModel) GetTimeOffBetween(from, to string) ([]*TimeOff, error) {
+	if m.MockGetTimeOffBetween != nil {
+		return m.MockGetTimeOffBetween(from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockWorkloadModel) CreateTimeOff(timeOff *TimeOff) (int, error) {
+	if m.MockCreateTimeOff != nil {
+		return m.MockCreateTimeOff(timeOff)
+	}
+	return 0, nil
+}
+
+func (m *MockWorkloadModel) DeleteTimeOff(id int) (int, error) {
+	if m.MockDeleteTimeOff != nil {
+		return m.MockDeleteTimeOff(id)
+	}
+	return 0, nil
+}
+
+func (m *MockWorkloadModel) GetOpenAssignedTasks(projectID int) ([]*Task, error) {
+	if m.MockGetOpenAssignedTasks != nil {
+		return m.MockGetOpenAssignedTasks(projectID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,84 @@
+package models
+
+type MockWorklogModel struct {
+	MockGetTaskWorklogs       func(taskID int) ([]*Worklog, error)
+	MockGetUserWorklogs       func(userID int, from, to string) ([]*Worklog, error)
+	MockGetWorklogById        func(id int) (*Worklog, error)
+	MockCreateWorklog         func(worklog *Worklog) (int, error)
+	MockUpdateWorklog         func(worklog *Worklog) error
+	MockDeleteWorklog         func(id int) (int, error)
+	MockGetTaskTimeSummary    func(taskID int) (*TimeSummary, error)
+	MockGetUserTimeSummary    func(userID int, from, to string) (*TimeSummary, error)
+	MockGetProjectTimeSummary func(projectID int) (*TimeSummary, error)
+	MockIsLocked              func(userID int, date string) (bool, error)
+}
+
+func (m *MockWorklogModel) GetTaskWorklogs(taskID int) ([]*Worklog, error) {
+	if m.MockGetTaskWorklogs != nil {
+		return m.MockGetTaskWorklogs(taskID)
+	}
+	return nil, nil
+}
+
+func (m *MockWorklogModel) GetUserWorklogs(userID int, from, to string) ([]*Worklog, error) {
+	if m.MockGetUserWorklogs != nil {
+		return m.MockGetUserWorklogs(userID, from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockWorklogModel) GetWorklogById(id int) (*Worklog, error) {
+	if m.MockGetWorklogById != nil {
+		return m.MockGetWorklogById(id)
+	}
+	return nil, nil
+}
+
+func (m *MockWorklogModel) CreateWorklog(worklog *Worklog) (int, error) {
+	if m.MockCreateWorklog != nil {
+		return m.MockCreateWorklog(worklog)
+	}
+	return 0, nil
+}
+
+func (m *MockWorklogModel) UpdateWorklog(worklog *Worklog) error {
+	if m.MockUpdateWorklog != nil {
+		return m.MockUpdateWorklog(worklog)
+	}
+	return nil
+}
+
+func (m *MockWorklogModel) DeleteWorklog(id int) (int, error) {
+	if m.MockDeleteWorklog != nil {
+		return m.MockDeleteWorklog(id)
+	}
+	return 0, nil
+}
+
+func (m *MockWorklogModel) GetTaskTimeSummary(taskID int) (*TimeSummary, error) {
+	if m.MockGetTaskTimeSummary != nil {
+		return m.MockGetTaskTimeSummary(taskID)
+	}
+	return nil, nil
+}
+
+func (m *MockWorklogModel) GetUserTimeSummary(userID int, from, to string) (*TimeSummary, error) {
+	if m.MockGetUserTimeSummary != nil {
+		return m.MockGetUserTimeSummary(userID, from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockWorklogModel) GetProjectTimeSummary(projectID int) (*TimeSummary, error) {
+	if m.MockGetProjectTimeSummary != nil {
+		return m.MockGetProjectTimeSummary(projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockWorklogModel) IsLocked(userID int, date string) (bool, error) {
+	if m.MockIsLocked != nil {
+		return m.MockIsLocked(userID, date)
+	}
+	return false, nil
+}
@@ -0,0 +1,256 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type NotificationType string
+
+const (
+	NotificationAssigned      NotificationType = "assigned"
+	NotificationStatusChanged NotificationType = "status_changed"
+	NotificationComment       NotificationType = "comment"
+	NotificationMention       NotificationType = "mention"
+	NotificationDueSoon       NotificationType = "due_soon"
+	NotificationDueDateChange NotificationType = "due_date_change"
+	NotificationStaleTask     NotificationType = "stale_task"
+	NotificationAutomation    NotificationType = "automation"
+)
+
+type Notification struct {
+	ID           int              `json:"id"`
+	UserID       int              `json:"user_id"`
+	Type         NotificationType `json:"type"`
+	Message      string           `json:"message"`
+	TaskID       int              `json:"task_id"`
+	ProjectID    int              `json:"project_id"`
+	IsRead       bool             `json:"is_read"`
+	CreationDate string           `json:"creation_date"`
+}
+
+// NotificationPreferences controls which notification types a user receives
+// and whether they are also sent by email. Users without a stored row get
+// everything except the daily digest, which is opt-in.
+type NotificationPreferences struct {
+	UserID        int  `json:"user_id"`
+	Assignment    bool `json:"assignment"`
+	StatusChange  bool `json:"status_change"`
+	Comment       bool `json:"comment"`
+	Mention       bool `json:"mention"`
+	DueDateChange bool `json:"due_date_change"`
+	Email         bool `json:"email"`
+	DailyDigest   bool `json:"daily_digest"`
+}
+
+func (p *NotificationPreferences) Allows(notificationType NotificationType) bool {
+	switch notificationType {
+	case NotificationAssigned:
+		return p.Assignment
+	case NotificationStatusChanged:
+		return p.StatusChange
+	case NotificationComment:
+		return p.Comment
+	case NotificationMention:
+		return p.Mention
+	case NotificationDueDateChange:
+		return p.DueDateChange
+	}
+	return true
+}
+
+func DefaultNotificationPreferences(userID int) *NotificationPreferences {
+	return &NotificationPreferences{UserID: userID, Assignment: true, StatusChange: true, Comment: true, Mention: true, DueDateChange: true, Email: true}
+}
+
+type NotificationModel interface {
+	CreateNotification(userID int, notificationType NotificationType, message string, taskID, projectID int) error
+	GetUserNotifications(userID int, unreadOnly bool) ([]*Notification, error)
+	GetUnreadCount(userID int) (int, error)
+	MarkRead(id int) (int, error)
+	MarkAllRead(userID int) (int, error)
+	GetPreferences(userID int) (*NotificationPreferences, error)
+	UpdatePreferences(preferences *NotificationPreferences) error
+	GetDigestSubscribers() ([]int, error)
+	PurgeRead(before time.Time) (int, error)
+	GetTaskWatchers(taskID int) ([]int, error)
+	AddTaskWatcher(taskID, userID int) error
+	RemoveTaskWatcher(taskID, userID int) (int, error)
+}
+
+type NotificationModelImpl struct {
+	DB *sql.DB
+}
+
+func NewNotificationModel(db *sql.DB) *NotificationModelImpl {
+	return &NotificationModelImpl{DB: db}
+}
+
+func (m *NotificationModelImpl) CreateNotification(userID int, notificationType NotificationType, message string, taskID, projectID int) error {
+	_, err := m.DB.Exec("INSERT INTO notifications (user_id, type, message, task_id, project_id) VALUES ($1, $2, $3, $4, $5)",
+		userID, notificationType, message, nullableID(taskID), nullableID(projectID))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *NotificationModelImpl) GetUserNotifications(userID int, unreadOnly bool) ([]*Notification, error) {
+	query := "SELECT id, user_id, type, message, task_id, project_id, is_read, creation_date FROM notifications WHERE user_id = $1"
+	if unreadOnly {
+		query += " AND NOT is_read"
+	}
+	rows, err := m.DB.Query(query+" ORDER BY id DESC", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	notifications := make([]*Notification, 0)
+	for rows.Next() {
+		notification := &Notification{}
+		var taskID, projectID sql.NullInt64
+		err := rows.Scan(&notification.ID, &notification.UserID, &notification.Type, &notification.Message, &taskID, &projectID, &notification.IsRead, &notification.CreationDate)
+		if err != nil {
+			return nil, err
+		}
+		notification.TaskID = int(taskID.Int64)
+		notification.ProjectID = int(projectID.Int64)
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+func (m *NotificationModelImpl) GetUnreadCount(userID int) (int, error) {
+	var count int
+	err := m.DB.QueryRow("SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND NOT is_read", userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (m *NotificationModelImpl) MarkRead(id int) (int, error) {
+	row := m.DB.QueryRow("UPDATE notifications SET is_read = true WHERE id = $1 RETURNING id", id)
+	var updatedId int
+	err := row.Scan(&updatedId)
+	if err != nil {
+		return 0, err
+	}
+	return updatedId, nil
+}
+
+func (m *NotificationModelImpl) MarkAllRead(userID int) (int, error) {
+	result, err := m.DB.Exec("UPDATE notifications SET is_read = true WHERE user_id = $1 AND NOT is_read", userID)
+	if err != nil {
+		return 0, err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(updated), nil
+}
+
+func (m *NotificationModelImpl) GetPreferences(userID int) (*NotificationPreferences, error) {
+	preferences := &NotificationPreferences{UserID: userID}
+	err := m.DB.QueryRow("SELECT assignment, status_change, comment, mention, due_date_change, email, daily_digest FROM notification_preferences WHERE user_id = $1", userID).
+		Scan(&preferences.Assignment, &preferences.StatusChange, &preferences.Comment, &preferences.Mention, &preferences.DueDateChange, &preferences.Email, &preferences.DailyDigest)
+	if err == sql.ErrNoRows {
+		return DefaultNotificationPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (m *NotificationModelImpl) UpdatePreferences(preferences *NotificationPreferences) error {
+	_, err := m.DB.Exec(`INSERT INTO notification_preferences (user_id, assignment, status_change, comment, mention, due_date_change, email, daily_digest) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET assignment = $2, status_change = $3, comment = $4, mention = $5, due_date_change = $6, email = $7, daily_digest = $8`,
+		preferences.UserID, preferences.Assignment, preferences.StatusChange, preferences.Comment, preferences.Mention, preferences.DueDateChange, preferences.Email, preferences.DailyDigest)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *NotificationModelImpl) GetDigestSubscribers() ([]int, error) {
+	rows, err := m.DB.Query("SELECT user_id FROM notification_preferences WHERE daily_digest AND email ORDER BY user_id")
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	subscribers := make([]int, 0)
+	for rows.Next() {
+		var userID int
+		err := rows.Scan(&userID)
+		if err != nil {
+			return nil, err
+		}
+		subscribers = append(subscribers, userID)
+	}
+	return subscribers, nil
+}
+
+func (m *NotificationModelImpl) PurgeRead(before time.Time) (int, error) {
+	result, err := m.DB.Exec("DELETE FROM notifications WHERE is_read AND creation_date < $1", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(deleted), nil
+}
+
+func (m *NotificationModelImpl) GetTaskWatchers(taskID int) ([]int, error) {
+	rows, err := m.DB.Query("SELECT user_id FROM task_watchers WHERE task_id = $1 ORDER BY user_id", taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	watchers := make([]int, 0)
+	for rows.Next() {
+		var userID int
+		err := rows.Scan(&userID)
+		if err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, userID)
+	}
+	return watchers, nil
+}
+
+func (m *NotificationModelImpl) AddTaskWatcher(taskID, userID int) error {
+	_, err := m.DB.Exec("INSERT INTO task_watchers (task_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", taskID, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *NotificationModelImpl) RemoveTaskWatcher(taskID, userID int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM task_watchers WHERE task_id = $1 AND user_id = $2 RETURNING user_id", taskID, userID)
+	var removedId int
+	err := row.Scan(&removedId)
+	if err != nil {
+		return 0, err
+	}
+	return removedId, nil
+}
@@ -13,9 +13,10 @@ type Project struct {
 
 type ProjectModel interface {
 	GetProjects() ([]Project, error)
-	CreateProject(title, description string, managerID int) error
+	CreateProject(title, description string, managerID int) (int, error)
 	GetProjectByID(id int) (*Project, error)
 	UpdateProject(id int, title, description string, managerID int) error
+	CompleteProject(id int, completionDate string) error
 	DeleteProject(id int) (int, error)
 	GetProjectTasks(id int) ([]Task, error)
 	SearchProjectsByTitle(title string) ([]Project, error)
@@ -57,13 +58,13 @@ func (pm *ProjectModelImpl) GetProjects() ([]Project, error) {
 	return projects, nil
 }
 
-func (pm *ProjectModelImpl) CreateProject(title, description string, managerID int) error {
+func (pm *ProjectModelImpl) CreateProject(title, description string, managerID int) (int, error) {
 	var id int
 	err := pm.DB.QueryRow("INSERT INTO projects (title, description, manager_id) VALUES ($1, $2, $3) RETURNING id", title, description, managerID).Scan(&id)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return id, nil
 }
 
 func (pm *ProjectModelImpl) GetProjectByID(id int) (*Project, error) {
@@ -87,6 +88,14 @@ func (pm *ProjectModelImpl) UpdateProject(id int, title, description string, man
 	return nil
 }
 
+func (pm *ProjectModelImpl) CompleteProject(id int, completionDate string) error {
+	_, err := pm.DB.Exec("UPDATE projects SET completion_date = $1 WHERE id = $2", completionDate, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (pm *ProjectModelImpl) DeleteProject(id int) (int, error) {
 	row := pm.DB.QueryRow("DELETE FROM projects WHERE id = $1", id)
 	var deletedId int
@@ -98,28 +107,13 @@ func (pm *ProjectModelImpl) DeleteProject(id int) (int, error) {
 }
 
 func (pm *ProjectModelImpl) GetProjectTasks(id int) ([]Task, error) {
-	rows, err := pm.DB.Query("SELECT * FROM tasks WHERE project_id = $1", id)
+	rows, err := queryTasks(pm.DB, "SELECT "+taskColumns+" FROM tasks WHERE project_id = $1", id)
 	if err != nil {
 		return nil, err
 	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			return
-		}
-	}(rows)
-	tasks := make([]Task, 0)
-	for rows.Next() {
-		task := Task{}
-		var completionDate sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
-		if err != nil {
-			return nil, err
-		}
-		if completionDate.Valid {
-			task.CompletionDate = completionDate.String
-		}
-		tasks = append(tasks, task)
+	tasks := make([]Task, 0, len(rows))
+	for _, task := range rows {
+		tasks = append(tasks, *task)
 	}
 	return tasks, nil
 }
@@ -0,0 +1,165 @@
+package models
+
+import "database/sql"
+
+// Recurrence is a series of tasks generated from an RFC 5545 rule. Every
+// occurrence is a regular task linked through its recurrence_id; the latest
+// one is the template for the next. NextDate is empty once the series ended.
+type Recurrence struct {
+	ID           int    `json:"id"`
+	RRule        string `json:"rrule"`
+	StartDate    string `json:"start_date"`
+	NextDate     string `json:"next_date"`
+	Occurrences  int    `json:"occurrences"`
+	CreationDate string `json:"creation_date"`
+}
+
+type RecurrenceModel interface {
+	GetRecurrenceById(id int) (*Recurrence, error)
+	GetRecurrenceByTaskID(taskID int) (*Recurrence, error)
+	CreateRecurrence(taskID int, recurrence *Recurrence) (int, error)
+	UpdateRecurrence(recurrence *Recurrence) error
+	DeleteRecurrence(id int) (int, error)
+	GetDueRecurrences(date string) ([]*Recurrence, error)
+	GetLatestOccurrence(id int) (*Task, error)
+	CreateOccurrence(recurrence *Recurrence, template *Task, nextDate string) (int, error)
+}
+
+type RecurrenceModelImpl struct {
+	DB *sql.DB
+}
+
+func NewRecurrenceModel(db *sql.DB) *RecurrenceModelImpl {
+	return &RecurrenceModelImpl{DB: db}
+}
+
+const recurrenceColumns = "id, rrule, start_date, next_date, occurrences, creation_date"
+
+func (m *RecurrenceModelImpl) GetRecurrenceById(id int) (*Recurrence, error) {
+	recurrence := &Recurrence{}
+	err := scanRecurrence(m.DB.QueryRow("SELECT "+recurrenceColumns+" FROM task_recurrences WHERE id = $1", id), recurrence)
+	if err != nil {
+		return nil, err
+	}
+	return recurrence, nil
+}
+
+func (m *RecurrenceModelImpl) GetRecurrenceByTaskID(taskID int) (*Recurrence, error) {
+	recurrence := &Recurrence{}
+	err := scanRecurrence(m.DB.QueryRow("SELECT "+recurrenceColumns+" FROM task_recurrences WHERE id = (SELECT recurrence_id FROM tasks WHERE id = $1)", taskID), recurrence)
+	if err != nil {
+		return nil, err
+	}
+	return recurrence, nil
+}
+
+// CreateRecurrence starts a series with the task as its first occurrence.
+func (m *RecurrenceModelImpl) CreateRecurrence(taskID int, recurrence *Recurrence) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var id int
+	err = tx.QueryRow("INSERT INTO task_recurrences (rrule, start_date, next_date, occurrences) VALUES ($1, $2, $3, 1) RETURNING id",
+		recurrence.RRule, recurrence.StartDate, nullableString(recurrence.NextDate)).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec("UPDATE tasks SET recurrence_id = $1 WHERE id = $2", id, taskID)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func (m *RecurrenceModelImpl) UpdateRecurrence(recurrence *Recurrence) error {
+	_, err := m.DB.Exec("UPDATE task_recurrences SET rrule = $1, start_date = $2, next_date = $3 WHERE id = $4",
+		recurrence.RRule, recurrence.StartDate, nullableString(recurrence.NextDate), recurrence.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteRecurrence stops a series. Its tasks are kept and unlinked.
+func (m *RecurrenceModelImpl) DeleteRecurrence(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM task_recurrences WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+func (m *RecurrenceModelImpl) GetDueRecurrences(date string) ([]*Recurrence, error) {
+	rows, err := m.DB.Query("SELECT "+recurrenceColumns+" FROM task_recurrences WHERE next_date <= $1 ORDER BY id", date)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	recurrences := make([]*Recurrence, 0)
+	for rows.Next() {
+		recurrence := &Recurrence{}
+		err := scanRecurrence(rows, recurrence)
+		if err != nil {
+			return nil, err
+		}
+		recurrences = append(recurrences, recurrence)
+	}
+	return recurrences, rows.Err()
+}
+
+func (m *RecurrenceModelImpl) GetLatestOccurrence(id int) (*Task, error) {
+	task := &Task{}
+	err := scanTask(m.DB.QueryRow("SELECT "+taskColumns+" FROM tasks WHERE recurrence_id = $1 ORDER BY id DESC LIMIT 1", id), task)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// CreateOccurrence copies template into a new task due on the recurrence's
+// next date and advances the series to nextDate ("" ends it). It returns 0
+// without creating anything when the series was already advanced by someone
+// else, e.g. a completion racing with the scheduled job.
+func (m *RecurrenceModelImpl) CreateOccurrence(recurrence *Recurrence, template *Task, nextDate string) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	result, err := tx.Exec("UPDATE task_recurrences SET next_date = $1, occurrences = occurrences + 1 WHERE id = $2 AND next_date = $3",
+		nullableString(nextDate), recurrence.ID, recurrence.NextDate)
+	if err != nil {
+		return 0, err
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return 0, err
+	}
+	var id int
+	err = tx.QueryRow("INSERT INTO tasks (title, description, priority, status, responsible_user_id, project_id, due_date, recurrence_id, labels) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id",
+		template.Title, template.Description, template.Priority, New, template.ResponsibleUserID, template.ProjectID, recurrence.NextDate, recurrence.ID, labelsArray(template.Labels)).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func scanRecurrence(row rowScanner, recurrence *Recurrence) error {
+	var nextDate sql.NullString
+	err := row.Scan(&recurrence.ID, &recurrence.RRule, &recurrence.StartDate, &nextDate, &recurrence.Occurrences, &recurrence.CreationDate)
+	if err != nil {
+		return err
+	}
+	if nextDate.Valid {
+		recurrence.NextDate = nextDate.String
+	}
+	return nil
+}
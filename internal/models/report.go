@@ -0,0 +1,89 @@
+package models
+
+import (
+	"database/sql"
+	"math"
+)
+
+// ProjectReport sums up the tasks of a project. Health and HealthReasons are
+// filled in by the caller from its thresholds.
+type ProjectReport struct {
+	ProjectID          int                  `json:"project_id"`
+	Title              string               `json:"title"`
+	ManagerID          int                  `json:"manager_id"`
+	CompletionDate     string               `json:"completion_date"`
+	Total              int                  `json:"total"`
+	ByStatus           map[StatusEnum]int   `json:"by_status"`
+	ByPriority         map[PriorityEnum]int `json:"by_priority"`
+	PercentComplete    float64              `json:"percent_complete"`
+	Open               int                  `json:"open"`
+	Overdue            int                  `json:"overdue"`
+	AverageOpenAgeDays float64              `json:"average_open_age_days"`
+	Health             string               `json:"health"`
+	HealthReasons      []string             `json:"health_reasons"`
+}
+
+type ReportModel interface {
+	// GetProjectReports reports on every project, or on the projects of one
+	// manager when managerID is not 0.
+	GetProjectReports(managerID int) ([]*ProjectReport, error)
+}
+
+type ReportModelImpl struct {
+	DB *sql.DB
+}
+
+func NewReportModel(db *sql.DB) *ReportModelImpl {
+	return &ReportModelImpl{DB: db}
+}
+
+// GetProjectReports counts in the database, one row per project, so the
+// cost does not depend on the number of tasks sent over the wire.
+func (m *ReportModelImpl) GetProjectReports(managerID int) ([]*ProjectReport, error) {
+	rows, err := m.DB.Query(`SELECT p.id, COALESCE(p.title, ''), p.manager_id, p.completion_date,
+			COUNT(t.id),
+			COUNT(t.id) FILTER (WHERE t.status = 'new'),
+			COUNT(t.id) FILTER (WHERE t.status = 'in_progress'),
+			COUNT(t.id) FILTER (WHERE t.status = 'done'),
+			COUNT(t.id) FILTER (WHERE t.priority = 'high'),
+			COUNT(t.id) FILTER (WHERE t.priority = 'medium'),
+			COUNT(t.id) FILTER (WHERE t.priority = 'low'),
+			COUNT(t.id) FILTER (WHERE t.status <> 'done' AND t.due_date < CURRENT_DATE),
+			COALESCE(AVG(CURRENT_DATE - t.creation_date) FILTER (WHERE t.status <> 'done'), 0)
+		FROM projects p LEFT JOIN tasks t ON t.project_id = p.id
+		WHERE ($1 = 0 OR p.manager_id = $1)
+		GROUP BY p.id ORDER BY p.id`, managerID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	reports := make([]*ProjectReport, 0)
+	for rows.Next() {
+		report := &ProjectReport{}
+		var projectManagerID sql.NullInt64
+		var completionDate sql.NullString
+		var statusNew, statusInProgress, statusDone, priorityHigh, priorityMedium, priorityLow int
+		err := rows.Scan(&report.ProjectID, &report.Title, &projectManagerID, &completionDate, &report.Total,
+			&statusNew, &statusInProgress, &statusDone, &priorityHigh, &priorityMedium, &priorityLow,
+			&report.Overdue, &report.AverageOpenAgeDays)
+		if err != nil {
+			return nil, err
+		}
+		report.ManagerID = int(projectManagerID.Int64)
+		report.CompletionDate = completionDate.String
+		report.ByStatus = map[StatusEnum]int{New: statusNew, InProgress: statusInProgress, Done: statusDone}
+		report.ByPriority = map[PriorityEnum]int{High: priorityHigh, Medium: priorityMedium, Low: priorityLow}
+		report.Open = report.Total - statusDone
+		if report.Total > 0 {
+			report.PercentComplete = math.Round(float64(statusDone)*1000/float64(report.Total)) / 10
+		}
+		report.AverageOpenAgeDays = math.Round(report.AverageOpenAgeDays*10) / 10
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
@@ -0,0 +1,306 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type RuleTrigger string
+
+const (
+	TriggerTaskCreated         RuleTrigger = "task_created"
+	TriggerTaskUpdated         RuleTrigger = "task_updated"
+	TriggerTaskStatusChanged   RuleTrigger = "task_status_changed"
+	TriggerTaskPriorityChanged RuleTrigger = "task_priority_changed"
+	TriggerTaskAssigneeChanged RuleTrigger = "task_assignee_changed"
+	TriggerProjectTasksDone    RuleTrigger = "project_tasks_done"
+)
+
+type RuleActionType string
+
+const (
+	ActionSetStatus         RuleActionType = "set_status"
+	ActionSetPriority       RuleActionType = "set_priority"
+	ActionAssignUser        RuleActionType = "assign_user"
+	ActionSetCompletionDate RuleActionType = "set_completion_date"
+	ActionNotifyManager     RuleActionType = "notify_manager"
+	ActionNotifyAssignee    RuleActionType = "notify_assignee"
+	ActionNotifyUser        RuleActionType = "notify_user"
+	ActionCloseProject      RuleActionType = "close_project"
+)
+
+const (
+	RuleRunSucceeded = "succeeded"
+	RuleRunFailed    = "failed"
+	RuleRunSkipped   = "skipped"
+)
+
+// RuleCondition compares a field of the task that triggered the rule with
+// Value. Fields: status, priority, previous_status, previous_priority,
+// responsible_user_id, project_id, title. Operators: eq, neq, contains.
+type RuleCondition struct {
+	Field    string `json:"field" example:"status"`
+	Operator string `json:"operator" example:"eq"`
+	Value    string `json:"value" example:"done"`
+}
+
+// RuleAction is one step of a rule. Value carries the status or priority for
+// set_* actions, UserID the user for assign_user and notify_user.
+type RuleAction struct {
+	Type    RuleActionType `json:"type" example:"notify_manager"`
+	Value   string         `json:"value,omitempty"`
+	UserID  int            `json:"user_id,omitempty"`
+	Message string         `json:"message,omitempty"`
+}
+
+type Rule struct {
+	ID           int             `json:"id"`
+	Name         string          `json:"name"`
+	ProjectID    int             `json:"project_id"`
+	Trigger      RuleTrigger     `json:"trigger"`
+	Conditions   []RuleCondition `json:"conditions"`
+	Actions      []RuleAction    `json:"actions"`
+	Enabled      bool            `json:"enabled"`
+	CreationDate string          `json:"creation_date"`
+}
+
+type RuleRun struct {
+	ID           int    `json:"id"`
+	RuleID       int    `json:"rule_id"`
+	TaskID       int    `json:"task_id"`
+	ProjectID    int    `json:"project_id"`
+	Status       string `json:"status"`
+	Message      string `json:"message"`
+	CreationDate string `json:"creation_date"`
+}
+
+var conditionFields = map[string]bool{
+	"status": true, "priority": true, "previous_status": true, "previous_priority": true,
+	"responsible_user_id": true, "project_id": true, "title": true,
+}
+
+// Validate checks that the trigger, conditions and actions are known.
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch r.Trigger {
+	case TriggerTaskCreated, TriggerTaskUpdated, TriggerTaskStatusChanged, TriggerTaskPriorityChanged,
+		TriggerTaskAssigneeChanged, TriggerProjectTasksDone:
+	default:
+		return fmt.Errorf("unknown trigger %q", r.Trigger)
+	}
+	for _, condition := range r.Conditions {
+		if !conditionFields[condition.Field] {
+			return fmt.Errorf("unknown condition field %q", condition.Field)
+		}
+		switch condition.Operator {
+		case "eq", "neq", "contains":
+		default:
+			return fmt.Errorf("unknown condition operator %q", condition.Operator)
+		}
+	}
+	if len(r.Actions) == 0 {
+		return fmt.Errorf("at least one action is required")
+	}
+	for _, action := range r.Actions {
+		switch action.Type {
+		case ActionSetStatus:
+			if !StatusEnum(action.Value).Valid() {
+				return fmt.Errorf("invalid status %q", action.Value)
+			}
+		case ActionSetPriority:
+			if !PriorityEnum(action.Value).Valid() {
+				return fmt.Errorf("invalid priority %q", action.Value)
+			}
+		case ActionAssignUser, ActionNotifyUser:
+			if action.UserID == 0 {
+				return fmt.Errorf("%s requires user_id", action.Type)
+			}
+		case ActionSetCompletionDate, ActionNotifyManager, ActionNotifyAssignee, ActionCloseProject:
+		default:
+			return fmt.Errorf("unknown action %q", action.Type)
+		}
+	}
+	return nil
+}
+
+type RuleModel interface {
+	GetRules() ([]*Rule, error)
+	GetRuleById(id int) (*Rule, error)
+	CreateRule(rule *Rule) (int, error)
+	UpdateRule(rule *Rule) error
+	DeleteRule(id int) (int, error)
+	GetEnabledRules(trigger RuleTrigger, projectID int) ([]*Rule, error)
+	LogRun(ruleID, taskID, projectID int, status, message string) error
+	GetRuleRuns(ruleID int, limit int) ([]*RuleRun, error)
+	PurgeRuns(before time.Time) (int, error)
+}
+
+type RuleModelImpl struct {
+	DB *sql.DB
+}
+
+func NewRuleModel(db *sql.DB) *RuleModelImpl {
+	return &RuleModelImpl{DB: db}
+}
+
+const ruleColumns = "id, name, project_id, trigger, conditions, actions, enabled, creation_date"
+
+func (m *RuleModelImpl) GetRules() ([]*Rule, error) {
+	return m.queryRules("SELECT " + ruleColumns + " FROM automation_rules ORDER BY id")
+}
+
+func (m *RuleModelImpl) GetRuleById(id int) (*Rule, error) {
+	rule := &Rule{}
+	err := scanRule(m.DB.QueryRow("SELECT "+ruleColumns+" FROM automation_rules WHERE id = $1", id), rule)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (m *RuleModelImpl) CreateRule(rule *Rule) (int, error) {
+	conditions, actions, err := marshalRule(rule)
+	if err != nil {
+		return 0, err
+	}
+	var id int
+	err = m.DB.QueryRow("INSERT INTO automation_rules (name, project_id, trigger, conditions, actions, enabled) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
+		rule.Name, nullableID(rule.ProjectID), rule.Trigger, conditions, actions, rule.Enabled).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (m *RuleModelImpl) UpdateRule(rule *Rule) error {
+	conditions, actions, err := marshalRule(rule)
+	if err != nil {
+		return err
+	}
+	_, err = m.DB.Exec("UPDATE automation_rules SET name = $1, project_id = $2, trigger = $3, conditions = $4, actions = $5, enabled = $6 WHERE id = $7",
+		rule.Name, nullableID(rule.ProjectID), rule.Trigger, conditions, actions, rule.Enabled, rule.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RuleModelImpl) DeleteRule(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM automation_rules WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+// GetEnabledRules returns the enabled rules for trigger that apply to the
+// project, including rules without a project which apply everywhere.
+func (m *RuleModelImpl) GetEnabledRules(trigger RuleTrigger, projectID int) ([]*Rule, error) {
+	return m.queryRules("SELECT "+ruleColumns+" FROM automation_rules WHERE enabled AND trigger = $1 AND (project_id IS NULL OR project_id = $2) ORDER BY id", trigger, projectID)
+}
+
+func (m *RuleModelImpl) LogRun(ruleID, taskID, projectID int, status, message string) error {
+	_, err := m.DB.Exec("INSERT INTO automation_rule_runs (rule_id, task_id, project_id, status, message) VALUES ($1, $2, $3, $4, $5)",
+		ruleID, nullableID(taskID), nullableID(projectID), status, message)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RuleModelImpl) GetRuleRuns(ruleID int, limit int) ([]*RuleRun, error) {
+	rows, err := m.DB.Query("SELECT id, rule_id, task_id, project_id, status, message, creation_date FROM automation_rule_runs WHERE rule_id = $1 ORDER BY id DESC LIMIT $2", ruleID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	runs := make([]*RuleRun, 0)
+	for rows.Next() {
+		run := &RuleRun{}
+		var taskID, projectID sql.NullInt64
+		err := rows.Scan(&run.ID, &run.RuleID, &taskID, &projectID, &run.Status, &run.Message, &run.CreationDate)
+		if err != nil {
+			return nil, err
+		}
+		run.TaskID = int(taskID.Int64)
+		run.ProjectID = int(projectID.Int64)
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (m *RuleModelImpl) PurgeRuns(before time.Time) (int, error) {
+	result, err := m.DB.Exec("DELETE FROM automation_rule_runs WHERE creation_date < $1", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(deleted), nil
+}
+
+func (m *RuleModelImpl) queryRules(query string, args ...any) ([]*Rule, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	rules := make([]*Rule, 0)
+	for rows.Next() {
+		rule := &Rule{}
+		err := scanRule(rows, rule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func scanRule(row rowScanner, rule *Rule) error {
+	var projectID sql.NullInt64
+	var conditions, actions []byte
+	err := row.Scan(&rule.ID, &rule.Name, &projectID, &rule.Trigger, &conditions, &actions, &rule.Enabled, &rule.CreationDate)
+	if err != nil {
+		return err
+	}
+	rule.ProjectID = int(projectID.Int64)
+	if err := json.Unmarshal(conditions, &rule.Conditions); err != nil {
+		return err
+	}
+	return json.Unmarshal(actions, &rule.Actions)
+}
+
+func marshalRule(rule *Rule) ([]byte, []byte, error) {
+	conditions := rule.Conditions
+	if conditions == nil {
+		conditions = []RuleCondition{}
+	}
+	conditionsJSON, err := json.Marshal(conditions)
+	if err != nil {
+		return nil, nil, err
+	}
+	actionsJSON, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conditionsJSON, actionsJSON, nil
+}
@@ -0,0 +1,243 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+)
+
+type SprintStatus string
+
+const (
+	SprintPlanned   SprintStatus = "planned"
+	SprintActive    SprintStatus = "active"
+	SprintCompleted SprintStatus = "completed"
+)
+
+type Sprint struct {
+	ID             int          `json:"id"`
+	ProjectID      int          `json:"project_id"`
+	Name           string       `json:"name" example:"Sprint 12"`
+	Goal           string       `json:"goal"`
+	StartDate      string       `json:"start_date" example:"2021-09-06"`
+	EndDate        string       `json:"end_date" example:"2021-09-17"`
+	Status         SprintStatus `json:"status"`
+	CreationDate   string       `json:"creation_date"`
+	CompletionDate string       `json:"completion_date"`
+}
+
+func (s *Sprint) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	start, err := ParseDate(s.StartDate)
+	if err != nil {
+		return fmt.Errorf("invalid start_date, expected YYYY-MM-DD")
+	}
+	end, err := ParseDate(s.EndDate)
+	if err != nil {
+		return fmt.Errorf("invalid end_date, expected YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return fmt.Errorf("end_date is before start_date")
+	}
+	return nil
+}
+
+type SprintModel interface {
+	GetProjectSprints(projectID int) ([]*Sprint, error)
+	GetSprintById(id int) (*Sprint, error)
+	CreateSprint(sprint *Sprint) (int, error)
+	UpdateSprint(sprint *Sprint) error
+	DeleteSprint(id int) (int, error)
+	GetActiveSprint(projectID int) (*Sprint, error)
+	GetNextPlannedSprint(projectID int) (*Sprint, error)
+	GetSprintTasks(id int) ([]*Task, error)
+	GetBurndownTasks(id int) ([]*Task, error)
+	AddTasks(id int, taskIDs []int) error
+	RemoveTask(id, taskID int) (int, error)
+	StartSprint(id int) error
+	CompleteSprint(id, nextSprintID int, date string) (int, error)
+}
+
+type SprintModelImpl struct {
+	DB *sql.DB
+}
+
+func NewSprintModel(db *sql.DB) *SprintModelImpl {
+	return &SprintModelImpl{DB: db}
+}
+
+const sprintColumns = "id, project_id, name, goal, start_date, end_date, status, creation_date, completion_date"
+
+func (m *SprintModelImpl) GetProjectSprints(projectID int) ([]*Sprint, error) {
+	return m.querySprints("SELECT "+sprintColumns+" FROM sprints WHERE project_id = $1 ORDER BY start_date, id", projectID)
+}
+
+func (m *SprintModelImpl) GetSprintById(id int) (*Sprint, error) {
+	sprint := &Sprint{}
+	err := scanSprint(m.DB.QueryRow("SELECT "+sprintColumns+" FROM sprints WHERE id = $1", id), sprint)
+	if err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+func (m *SprintModelImpl) CreateSprint(sprint *Sprint) (int, error) {
+	var id int
+	err := m.DB.QueryRow("INSERT INTO sprints (project_id, name, goal, start_date, end_date, status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
+		sprint.ProjectID, sprint.Name, sprint.Goal, sprint.StartDate, sprint.EndDate, SprintPlanned).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// UpdateSprint changes the name, goal and dates; the status only moves
+// through StartSprint and CompleteSprint.
+func (m *SprintModelImpl) UpdateSprint(sprint *Sprint) error {
+	_, err := m.DB.Exec("UPDATE sprints SET name = $1, goal = $2, start_date = $3, end_date = $4 WHERE id = $5",
+		sprint.Name, sprint.Goal, sprint.StartDate, sprint.EndDate, sprint.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteSprint removes the sprint; its tasks go back to the backlog.
+func (m *SprintModelImpl) DeleteSprint(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM sprints WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+func (m *SprintModelImpl) GetActiveSprint(projectID int) (*Sprint, error) {
+	sprint := &Sprint{}
+	err := scanSprint(m.DB.QueryRow("SELECT "+sprintColumns+" FROM sprints WHERE project_id = $1 AND status = $2", projectID, SprintActive), sprint)
+	if err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+// GetNextPlannedSprint returns the planned sprint of the project that starts first.
+func (m *SprintModelImpl) GetNextPlannedSprint(projectID int) (*Sprint, error) {
+	sprint := &Sprint{}
+	err := scanSprint(m.DB.QueryRow("SELECT "+sprintColumns+" FROM sprints WHERE project_id = $1 AND status = $2 ORDER BY start_date, id LIMIT 1", projectID, SprintPlanned), sprint)
+	if err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+func (m *SprintModelImpl) GetSprintTasks(id int) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE sprint_id = $1 ORDER BY id", id)
+}
+
+// GetBurndownTasks returns the tasks of the sprint together with the
+// unfinished ones that were carried over to another sprint when it completed.
+func (m *SprintModelImpl) GetBurndownTasks(id int) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE sprint_id = $1 OR id IN (SELECT task_id FROM sprint_carryover WHERE sprint_id = $1) ORDER BY id", id)
+}
+
+func (m *SprintModelImpl) AddTasks(id int, taskIDs []int) error {
+	_, err := m.DB.Exec("UPDATE tasks SET sprint_id = $1 WHERE id = ANY($2)", id, pq.Array(taskIDs))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveTask moves a task of the sprint back to the backlog.
+func (m *SprintModelImpl) RemoveTask(id, taskID int) (int, error) {
+	row := m.DB.QueryRow("UPDATE tasks SET sprint_id = NULL WHERE id = $1 AND sprint_id = $2 RETURNING id", taskID, id)
+	var removedId int
+	err := row.Scan(&removedId)
+	if err != nil {
+		return 0, err
+	}
+	return removedId, nil
+}
+
+// StartSprint activates a planned sprint. The database refuses a second
+// active sprint in the same project.
+func (m *SprintModelImpl) StartSprint(id int) error {
+	result, err := m.DB.Exec("UPDATE sprints SET status = $1 WHERE id = $2 AND status = $3", SprintActive, id, SprintPlanned)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return fmt.Errorf("sprint %d is not planned", id)
+	}
+	return nil
+}
+
+// CompleteSprint closes an active sprint and moves its unfinished tasks to
+// nextSprintID, or to the backlog when it is 0. It returns how many tasks
+// were moved.
+func (m *SprintModelImpl) CompleteSprint(id, nextSprintID int, date string) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	result, err := tx.Exec("UPDATE sprints SET status = $1, completion_date = $2 WHERE id = $3 AND status = $4", SprintCompleted, date, id, SprintActive)
+	if err != nil {
+		return 0, err
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return 0, fmt.Errorf("sprint %d is not active", id)
+	}
+	_, err = tx.Exec("INSERT INTO sprint_carryover (sprint_id, task_id) SELECT sprint_id, id FROM tasks WHERE sprint_id = $1 AND status <> 'done' ON CONFLICT DO NOTHING", id)
+	if err != nil {
+		return 0, err
+	}
+	result, err = tx.Exec("UPDATE tasks SET sprint_id = $1 WHERE sprint_id = $2 AND status <> 'done'", nullableID(nextSprintID), id)
+	if err != nil {
+		return 0, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), tx.Commit()
+}
+
+func (m *SprintModelImpl) querySprints(query string, args ...any) ([]*Sprint, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	sprints := make([]*Sprint, 0)
+	for rows.Next() {
+		sprint := &Sprint{}
+		err := scanSprint(rows, sprint)
+		if err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, sprint)
+	}
+	return sprints, rows.Err()
+}
+
+func scanSprint(row rowScanner, sprint *Sprint) error {
+	var completionDate sql.NullString
+	err := row.Scan(&sprint.ID, &sprint.ProjectID, &sprint.Name, &sprint.Goal, &sprint.StartDate, &sprint.EndDate, &sprint.Status, &sprint.CreationDate, &completionDate)
+	if err != nil {
+		return err
+	}
+	if completionDate.Valid {
+		sprint.CompletionDate = completionDate.String
+	}
+	return nil
+}
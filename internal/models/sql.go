@@ -0,0 +1,56 @@
+package models
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"time"
+)
+
+// DateLayout is the format accepted for date-only fields such as due dates.
+const DateLayout = "2006-01-02"
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// nullableID maps the zero id used by the models to SQL NULL.
+func nullableID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
+
+// nullableString maps the empty string used by the models to SQL NULL.
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullableMinutes maps a nullable duration column to an optional value.
+func nullableMinutes(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	minutes := int(value.Int64)
+	return &minutes
+}
+
+// labelsArray maps a nil label list to an empty array, labels columns are not null.
+func labelsArray(labels []string) pq.StringArray {
+	if labels == nil {
+		return pq.StringArray{}
+	}
+	return labels
+}
+
+// ParseDate parses a date column as returned by the driver ("2021-09-01T00:00:00Z")
+// or as entered by clients ("2021-09-01").
+func ParseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(DateLayout, value)
+}
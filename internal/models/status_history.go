@@ -0,0 +1,52 @@
+package models
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"time"
+)
+
+// StatusChange is one entry of a task's status history. The history is
+// written by a trigger whenever a task is created or its status changes.
+type StatusChange struct {
+	TaskID    int        `json:"task_id"`
+	Status    StatusEnum `json:"status"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+type StatusHistoryModel interface {
+	GetStatusHistory(taskIDs []int) ([]*StatusChange, error)
+}
+
+type StatusHistoryModelImpl struct {
+	DB *sql.DB
+}
+
+func NewStatusHistoryModel(db *sql.DB) *StatusHistoryModelImpl {
+	return &StatusHistoryModelImpl{DB: db}
+}
+
+// GetStatusHistory returns the status changes of the given tasks ordered by
+// task and time.
+func (m *StatusHistoryModelImpl) GetStatusHistory(taskIDs []int) ([]*StatusChange, error) {
+	rows, err := m.DB.Query("SELECT task_id, status, changed_at FROM task_status_history WHERE task_id = ANY($1) ORDER BY task_id, changed_at, id", pq.Array(taskIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	changes := make([]*StatusChange, 0)
+	for rows.Next() {
+		change := &StatusChange{}
+		err := rows.Scan(&change.TaskID, &change.Status, &change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
@@ -1,6 +1,10 @@
 package models
 
-import "database/sql"
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"time"
+)
 
 type PriorityEnum string
 type StatusEnum string
@@ -17,6 +21,22 @@ const (
 	Done       StatusEnum = "done"
 )
 
+func (p PriorityEnum) Valid() bool {
+	switch p {
+	case Low, Medium, High:
+		return true
+	}
+	return false
+}
+
+func (s StatusEnum) Valid() bool {
+	switch s {
+	case New, InProgress, Done:
+		return true
+	}
+	return false
+}
+
 type Task struct {
 	ID                int          `json:"id"`
 	Title             string       `json:"title"`
@@ -27,19 +47,41 @@ type Task struct {
 	ProjectID         int          `json:"project_id"`
 	CreationDate      string       `json:"creation_date"`
 	CompletionDate    string       `json:"completion_date"`
+	StartDate         string       `json:"start_date"`
+	DueDate           string       `json:"due_date"`
+	RecurrenceID      int          `json:"recurrence_id"`
+	ParentID          int          `json:"parent_id"`
+	Labels            []string     `json:"labels"`
+	// SprintID is managed through the sprint endpoints.
+	SprintID    int `json:"sprint_id"`
+	MilestoneID int `json:"milestone_id"`
+	EpicID      int `json:"epic_id"`
+	// Estimates are optional; time is in minutes. Logging work lowers the
+	// remaining estimate.
+	StoryPoints              *float64 `json:"story_points"`
+	OriginalEstimateMinutes  *int     `json:"original_estimate_minutes"`
+	RemainingEstimateMinutes *int     `json:"remaining_estimate_minutes"`
+	// BoardRank orders the task in its board column and is managed through
+	// the board endpoints.
+	BoardRank string `json:"board_rank"`
 }
 
+// taskColumns lists the tasks columns in the order scanTask reads them.
+const taskColumns = "id, title, description, priority, status, responsible_user_id, project_id, creation_date, completion_date, due_date, recurrence_id, parent_id, labels, sprint_id, milestone_id, epic_id, story_points, original_estimate_minutes, remaining_estimate_minutes, board_rank, start_date"
+
 type TaskModel interface {
 	GetTasks() ([]*Task, error)
-	CreateTask(title, description string, priority PriorityEnum, status StatusEnum, responsibleUserID, projectID int) error
+	CreateTask(task *Task) (int, error)
 	GetTaskById(id int) (*Task, error)
-	UpdateTask(id int, title, description string, priority PriorityEnum, status StatusEnum, responsibleUserID, projectID int) error
+	UpdateTask(task *Task) error
 	DeleteTask(id int) (int, error)
 	SearchTaskByTitle(title string) ([]*Task, error)
 	SearchTaskByStatus(status StatusEnum) ([]*Task, error)
 	SearchTaskByPriority(priority PriorityEnum) ([]*Task, error)
 	SearchTaskByResponsibleUserID(responsibleUserID int) ([]*Task, error)
 	SearchTaskByProjectID(projectID int) ([]*Task, error)
+	GetOpenTasksDueBetween(from, to string) ([]*Task, error)
+	GetStaleTasks(before time.Time) ([]*Task, error)
 }
 
 type TaskModelImpl struct {
@@ -51,59 +93,39 @@ func NewTaskModel(db *sql.DB) *TaskModelImpl {
 }
 
 func (m *TaskModelImpl) GetTasks() ([]*Task, error) {
-	rows, err := m.DB.Query("SELECT * FROM tasks")
-	if err != nil {
-		return nil, err
-	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			return
-		}
-	}(rows)
-	tasks := make([]*Task, 0)
-	for rows.Next() {
-		task := &Task{}
-		var completionDate sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
-		if err != nil {
-			return nil, err
-		}
-		if completionDate.Valid {
-			task.CompletionDate = completionDate.String
-		}
-		tasks = append(tasks, task)
-	}
-	return tasks, nil
-
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks")
 }
 
-func (m *TaskModelImpl) CreateTask(title, description string, priority PriorityEnum, status StatusEnum, responsibleUserID, projectID int) error {
-	_, err := m.DB.Exec("INSERT INTO tasks (title, description, priority, status, responsible_user_id, project_id) VALUES ($1, $2, $3, $4, $5, $6)", title, description, priority, status, responsibleUserID, projectID)
+func (m *TaskModelImpl) CreateTask(task *Task) (int, error) {
+	var id int
+	err := m.DB.QueryRow("INSERT INTO tasks (title, description, priority, status, responsible_user_id, project_id, due_date, parent_id, labels, milestone_id, epic_id, story_points, original_estimate_minutes, remaining_estimate_minutes, start_date) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id",
+		task.Title, task.Description, task.Priority, task.Status, task.ResponsibleUserID, task.ProjectID, nullableString(task.DueDate), nullableID(task.ParentID), labelsArray(task.Labels),
+		nullableID(task.MilestoneID), nullableID(task.EpicID), task.StoryPoints, task.OriginalEstimateMinutes, task.RemainingEstimateMinutes, nullableString(task.StartDate)).Scan(&id)
 	if err != nil {
-		return err
+		return 0, wipLimitError(err)
 	}
-	return nil
+	return id, nil
 }
 
 func (m *TaskModelImpl) GetTaskById(id int) (*Task, error) {
-	row := m.DB.QueryRow("SELECT * FROM tasks WHERE id = $1", id)
+	row := m.DB.QueryRow("SELECT "+taskColumns+" FROM tasks WHERE id = $1", id)
 	task := &Task{}
-	var completionDate sql.NullString
-	err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
+	err := scanTask(row, task)
 	if err != nil {
 		return nil, err
 	}
-	if completionDate.Valid {
-		task.CompletionDate = completionDate.String
-	}
 	return task, nil
 }
 
-func (m *TaskModelImpl) UpdateTask(id int, title, description string, priority PriorityEnum, status StatusEnum, responsibleUserID, projectID int) error {
-	_, err := m.DB.Exec("UPDATE tasks SET title = $1, description = $2, priority = $3, status = $4, responsible_user_id = $5, project_id = $6 WHERE id = $7", title, description, priority, status, responsibleUserID, projectID, id)
+// UpdateTask writes the fields of the task; a task moved to another project
+// leaves its sprint, which belongs to the old one.
+func (m *TaskModelImpl) UpdateTask(task *Task) error {
+	_, err := m.DB.Exec("UPDATE tasks SET title = $1, description = $2, priority = $3, status = $4, responsible_user_id = $5, project_id = $6, due_date = $7, completion_date = $8, parent_id = $9, labels = $10, milestone_id = $11, epic_id = $12, story_points = $13, original_estimate_minutes = $14, remaining_estimate_minutes = $15, start_date = $16, sprint_id = CASE WHEN project_id = $6 THEN sprint_id END WHERE id = $17",
+		task.Title, task.Description, task.Priority, task.Status, task.ResponsibleUserID, task.ProjectID, nullableString(task.DueDate), nullableString(task.CompletionDate),
+		nullableID(task.ParentID), labelsArray(task.Labels), nullableID(task.MilestoneID), nullableID(task.EpicID), task.StoryPoints, task.OriginalEstimateMinutes, task.RemainingEstimateMinutes,
+		nullableString(task.StartDate), task.ID)
 	if err != nil {
-		return err
+		return wipLimitError(err)
 	}
 	return nil
 }
@@ -113,94 +135,42 @@ func (m *TaskModelImpl) DeleteTask(id int) (int, error) {
 	var deletedId int
 	err := row.Scan(&deletedId)
 	if err != nil {
-		return 0, err
+		return 0, timesheetLockError(err)
 	}
 	return deletedId, nil
 }
 
 func (m *TaskModelImpl) SearchTaskByTitle(title string) ([]*Task, error) {
-	rows, err := m.DB.Query("SELECT * FROM tasks WHERE title = $1", title)
-	if err != nil {
-		return nil, err
-	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			return
-		}
-	}(rows)
-	tasks := make([]*Task, 0)
-	for rows.Next() {
-		task := &Task{}
-		var completionDate sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
-		if err != nil {
-			return nil, err
-		}
-		if completionDate.Valid {
-			task.CompletionDate = completionDate.String
-		}
-		tasks = append(tasks, task)
-	}
-	return tasks, nil
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE title = $1", title)
 }
 
 func (m *TaskModelImpl) SearchTaskByStatus(status StatusEnum) ([]*Task, error) {
-	rows, err := m.DB.Query("SELECT * FROM tasks WHERE status = $1", status)
-	if err != nil {
-		return nil, err
-	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			return
-		}
-	}(rows)
-	tasks := make([]*Task, 0)
-	for rows.Next() {
-		task := &Task{}
-		var completionDate sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
-		if err != nil {
-			return nil, err
-		}
-		if completionDate.Valid {
-			task.CompletionDate = completionDate.String
-		}
-		tasks = append(tasks, task)
-	}
-	return tasks, nil
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE status = $1", status)
 }
 
 func (m *TaskModelImpl) SearchTaskByPriority(priority PriorityEnum) ([]*Task, error) {
-	rows, err := m.DB.Query("SELECT * FROM tasks WHERE priority = $1", priority)
-	if err != nil {
-		return nil, err
-	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			return
-		}
-	}(rows)
-	tasks := make([]*Task, 0)
-	for rows.Next() {
-		task := &Task{}
-		var completionDate sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
-		if err != nil {
-			return nil, err
-		}
-		if completionDate.Valid {
-			task.CompletionDate = completionDate.String
-		}
-		tasks = append(tasks, task)
-	}
-	return tasks, nil
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE priority = $1", priority)
 }
 
 func (m *TaskModelImpl) SearchTaskByResponsibleUserID(responsibleUserID int) ([]*Task, error) {
-	rows, err := m.DB.Query("SELECT * FROM tasks WHERE responsible_user_id = $1", responsibleUserID)
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE responsible_user_id = $1", responsibleUserID)
+}
+
+func (m *TaskModelImpl) SearchTaskByProjectID(projectID int) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE project_id = $1", projectID)
+}
+
+func (m *TaskModelImpl) GetOpenTasksDueBetween(from, to string) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE status <> 'done' AND due_date BETWEEN $1 AND $2 ORDER BY due_date, id", from, to)
+}
+
+// GetStaleTasks returns open tasks whose last recorded change is older than before.
+func (m *TaskModelImpl) GetStaleTasks(before time.Time) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks t WHERE status <> 'done' AND COALESCE((SELECT MAX(changed_at) FROM changes c WHERE c.entity = 'tasks' AND c.entity_id = t.id), creation_date) < $1 ORDER BY id", before.UTC())
+}
+
+func queryTasks(db queryer, query string, args ...any) ([]*Task, error) {
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -213,42 +183,47 @@ func (m *TaskModelImpl) SearchTaskByResponsibleUserID(responsibleUserID int) ([]
 	tasks := make([]*Task, 0)
 	for rows.Next() {
 		task := &Task{}
-		var completionDate sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
+		err := scanTask(rows, task)
 		if err != nil {
 			return nil, err
 		}
-		if completionDate.Valid {
-			task.CompletionDate = completionDate.String
-		}
 		tasks = append(tasks, task)
 	}
-	return tasks, nil
+	return tasks, rows.Err()
 }
 
-func (m *TaskModelImpl) SearchTaskByProjectID(projectID int) ([]*Task, error) {
-	rows, err := m.DB.Query("SELECT * FROM tasks WHERE project_id = $1", projectID)
+func scanTask(row rowScanner, task *Task) error {
+	var completionDate, dueDate, boardRank, startDate sql.NullString
+	var recurrenceID, parentID, sprintID, milestoneID, epicID, originalEstimate, remainingEstimate sql.NullInt64
+	var storyPoints sql.NullFloat64
+	var labels pq.StringArray
+	err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate, &dueDate,
+		&recurrenceID, &parentID, &labels, &sprintID, &milestoneID, &epicID,
+		&storyPoints, &originalEstimate, &remainingEstimate, &boardRank, &startDate)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			return
-		}
-	}(rows)
-	tasks := make([]*Task, 0)
-	for rows.Next() {
-		task := &Task{}
-		var completionDate sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
-		if err != nil {
-			return nil, err
-		}
-		if completionDate.Valid {
-			task.CompletionDate = completionDate.String
-		}
-		tasks = append(tasks, task)
+	if completionDate.Valid {
+		task.CompletionDate = completionDate.String
+	}
+	task.StartDate = startDate.String
+	if dueDate.Valid {
+		task.DueDate = dueDate.String
+	}
+	task.RecurrenceID = int(recurrenceID.Int64)
+	task.ParentID = int(parentID.Int64)
+	task.SprintID = int(sprintID.Int64)
+	task.MilestoneID = int(milestoneID.Int64)
+	task.EpicID = int(epicID.Int64)
+	if storyPoints.Valid {
+		task.StoryPoints = &storyPoints.Float64
+	}
+	task.OriginalEstimateMinutes = nullableMinutes(originalEstimate)
+	task.RemainingEstimateMinutes = nullableMinutes(remainingEstimate)
+	task.BoardRank = boardRank.String
+	task.Labels = []string(labels)
+	if task.Labels == nil {
+		task.Labels = []string{}
 	}
-	return tasks, nil
+	return nil
 }
@@ -0,0 +1,291 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"time"
+)
+
+// TemplateTask is one task of a template. ParentID refers to another task of
+// the same template; when a template is created from JSON, IDs only need to be
+// unique within the request. DueOffsetDays is relative to the project start.
+type TemplateTask struct {
+	ID            int          `json:"id"`
+	ParentID      int          `json:"parent_id"`
+	Title         string       `json:"title"`
+	Description   string       `json:"description"`
+	Priority      PriorityEnum `json:"priority"`
+	Labels        []string     `json:"labels"`
+	DueOffsetDays *int         `json:"due_offset_days"`
+}
+
+type Template struct {
+	ID                 int            `json:"id"`
+	Name               string         `json:"name"`
+	Description        string         `json:"description"`
+	ProjectTitle       string         `json:"project_title"`
+	ProjectDescription string         `json:"project_description"`
+	CreationDate       string         `json:"creation_date"`
+	Tasks              []TemplateTask `json:"tasks"`
+}
+
+// ProjectInstance holds what varies between projects created from the same template.
+type ProjectInstance struct {
+	Title       string
+	Description string
+	ManagerID   int
+	AssigneeID  int
+	StartDate   time.Time
+}
+
+type TemplateModel interface {
+	GetTemplates() ([]*Template, error)
+	GetTemplateById(id int) (*Template, error)
+	CreateTemplate(template *Template) (int, error)
+	DeleteTemplate(id int) (int, error)
+	InstantiateTemplate(template *Template, instance *ProjectInstance) (int, error)
+}
+
+type TemplateModelImpl struct {
+	DB *sql.DB
+}
+
+func NewTemplateModel(db *sql.DB) *TemplateModelImpl {
+	return &TemplateModelImpl{DB: db}
+}
+
+// NewTemplateFromProject captures a project and its task tree. Due dates become
+// offsets from the project's creation date.
+func NewTemplateFromProject(name, description string, project *Project, tasks []Task) *Template {
+	template := &Template{
+		Name:               name,
+		Description:        description,
+		ProjectTitle:       project.Title,
+		ProjectDescription: project.Description,
+		Tasks:              make([]TemplateTask, 0, len(tasks)),
+	}
+	start, startErr := ParseDate(project.CreationDate)
+	inProject := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		inProject[task.ID] = true
+	}
+	for _, task := range tasks {
+		templateTask := TemplateTask{
+			ID:          task.ID,
+			Title:       task.Title,
+			Description: task.Description,
+			Priority:    task.Priority,
+			Labels:      task.Labels,
+		}
+		if inProject[task.ParentID] {
+			templateTask.ParentID = task.ParentID
+		}
+		if due, err := ParseDate(task.DueDate); err == nil && startErr == nil {
+			offset := int(due.Sub(start).Hours() / 24)
+			templateTask.DueOffsetDays = &offset
+		}
+		template.Tasks = append(template.Tasks, templateTask)
+	}
+	return template
+}
+
+// Validate checks the template and orders its tasks so that parents come
+// before their children.
+func (t *Template) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if t.ProjectTitle == "" {
+		return fmt.Errorf("project_title is required")
+	}
+	byID := make(map[int]TemplateTask, len(t.Tasks))
+	for i, task := range t.Tasks {
+		if task.Title == "" {
+			return fmt.Errorf("task %d: title is required", i+1)
+		}
+		if task.Priority == "" {
+			task.Priority = Medium
+			t.Tasks[i].Priority = Medium
+		}
+		if !task.Priority.Valid() {
+			return fmt.Errorf("task %d: invalid priority %q", i+1, task.Priority)
+		}
+		if task.ID == 0 {
+			continue
+		}
+		if _, duplicate := byID[task.ID]; duplicate {
+			return fmt.Errorf("task id %d is used twice", task.ID)
+		}
+		byID[task.ID] = task
+	}
+	ordered := make([]TemplateTask, 0, len(t.Tasks))
+	state := make(map[int]int) // 1 while visiting, 2 once ordered
+	var visit func(task TemplateTask) error
+	visit = func(task TemplateTask) error {
+		if task.ID != 0 {
+			switch state[task.ID] {
+			case 1:
+				return fmt.Errorf("task %d is its own ancestor", task.ID)
+			case 2:
+				return nil
+			}
+			state[task.ID] = 1
+		}
+		if task.ParentID != 0 {
+			parent, ok := byID[task.ParentID]
+			if !ok {
+				return fmt.Errorf("task %q refers to unknown parent %d", task.Title, task.ParentID)
+			}
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		if task.ID != 0 {
+			state[task.ID] = 2
+		}
+		ordered = append(ordered, task)
+		return nil
+	}
+	for _, task := range t.Tasks {
+		if err := visit(task); err != nil {
+			return err
+		}
+	}
+	t.Tasks = ordered
+	return nil
+}
+
+func (m *TemplateModelImpl) GetTemplates() ([]*Template, error) {
+	rows, err := m.DB.Query("SELECT id, name, description, project_title, project_description, creation_date FROM project_templates ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	templates := make([]*Template, 0)
+	for rows.Next() {
+		template := &Template{Tasks: []TemplateTask{}}
+		err := rows.Scan(&template.ID, &template.Name, &template.Description, &template.ProjectTitle, &template.ProjectDescription, &template.CreationDate)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+func (m *TemplateModelImpl) GetTemplateById(id int) (*Template, error) {
+	template := &Template{}
+	err := m.DB.QueryRow("SELECT id, name, description, project_title, project_description, creation_date FROM project_templates WHERE id = $1", id).
+		Scan(&template.ID, &template.Name, &template.Description, &template.ProjectTitle, &template.ProjectDescription, &template.CreationDate)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := m.DB.Query("SELECT id, parent_id, title, description, priority, labels, due_offset_days FROM template_tasks WHERE template_id = $1 ORDER BY position", id)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	template.Tasks = make([]TemplateTask, 0)
+	for rows.Next() {
+		task := TemplateTask{}
+		var parentID, offset sql.NullInt64
+		var labels pq.StringArray
+		err := rows.Scan(&task.ID, &parentID, &task.Title, &task.Description, &task.Priority, &labels, &offset)
+		if err != nil {
+			return nil, err
+		}
+		task.ParentID = int(parentID.Int64)
+		task.Labels = []string(labels)
+		if task.Labels == nil {
+			task.Labels = []string{}
+		}
+		if offset.Valid {
+			days := int(offset.Int64)
+			task.DueOffsetDays = &days
+		}
+		template.Tasks = append(template.Tasks, task)
+	}
+	return template, rows.Err()
+}
+
+// CreateTemplate stores a validated template; tasks must be ordered parents first.
+func (m *TemplateModelImpl) CreateTemplate(template *Template) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var id int
+	err = tx.QueryRow("INSERT INTO project_templates (name, description, project_title, project_description) VALUES ($1, $2, $3, $4) RETURNING id",
+		template.Name, template.Description, template.ProjectTitle, template.ProjectDescription).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	ids := make(map[int]int, len(template.Tasks))
+	for position, task := range template.Tasks {
+		var taskID int
+		err = tx.QueryRow("INSERT INTO template_tasks (template_id, parent_id, position, title, description, priority, labels, due_offset_days) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id",
+			id, nullableID(ids[task.ParentID]), position, task.Title, task.Description, task.Priority, labelsArray(task.Labels), task.DueOffsetDays).Scan(&taskID)
+		if err != nil {
+			return 0, err
+		}
+		if task.ID != 0 {
+			ids[task.ID] = taskID
+		}
+	}
+	return id, tx.Commit()
+}
+
+func (m *TemplateModelImpl) DeleteTemplate(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM project_templates WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+// InstantiateTemplate creates the project and all of its tasks in one
+// transaction and returns the id of the new project.
+func (m *TemplateModelImpl) InstantiateTemplate(template *Template, instance *ProjectInstance) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var projectID int
+	err = tx.QueryRow("INSERT INTO projects (title, description, manager_id, creation_date) VALUES ($1, $2, $3, $4) RETURNING id",
+		instance.Title, instance.Description, instance.ManagerID, instance.StartDate.Format(DateLayout)).Scan(&projectID)
+	if err != nil {
+		return 0, err
+	}
+	ids := make(map[int]int, len(template.Tasks))
+	for _, task := range template.Tasks {
+		dueDate := ""
+		if task.DueOffsetDays != nil {
+			dueDate = instance.StartDate.AddDate(0, 0, *task.DueOffsetDays).Format(DateLayout)
+		}
+		var taskID int
+		err = tx.QueryRow("INSERT INTO tasks (title, description, priority, status, responsible_user_id, project_id, due_date, parent_id, labels) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id",
+			task.Title, task.Description, task.Priority, New, instance.AssigneeID, projectID, nullableString(dueDate), nullableID(ids[task.ParentID]), labelsArray(task.Labels)).Scan(&taskID)
+		if err != nil {
+			return 0, err
+		}
+		if task.ID != 0 {
+			ids[task.ID] = taskID
+		}
+	}
+	return projectID, tx.Commit()
+}
@@ -0,0 +1,299 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"time"
+)
+
+// ErrTimesheetLocked is returned when a change would add, change or delete
+// worklogs of a week whose timesheet was submitted or approved, such as
+// deleting a task or user that has time logged in it.
+var ErrTimesheetLocked = errors.New("worklogs of a submitted or approved timesheet cannot change")
+
+// timesheetLockError maps the error raised by the check_worklog_period
+// trigger to ErrTimesheetLocked.
+func timesheetLockError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23514" && pqErr.Constraint == "timesheets_locked" {
+		return ErrTimesheetLocked
+	}
+	return err
+}
+
+type TimesheetStatus string
+
+const (
+	TimesheetOpen      TimesheetStatus = "open"
+	TimesheetSubmitted TimesheetStatus = "submitted"
+	TimesheetApproved  TimesheetStatus = "approved"
+	TimesheetRejected  TimesheetStatus = "rejected"
+)
+
+// CanBecome reports whether a timesheet may go from s to next. Rejecting an
+// approved timesheet reopens it for corrections.
+func (s TimesheetStatus) CanBecome(next TimesheetStatus) bool {
+	switch next {
+	case TimesheetSubmitted:
+		return s == TimesheetOpen || s == TimesheetRejected
+	case TimesheetApproved:
+		return s == TimesheetSubmitted
+	case TimesheetRejected:
+		return s == TimesheetSubmitted || s == TimesheetApproved
+	}
+	return false
+}
+
+// Locked reports whether worklogs in the period can no longer change.
+func (s TimesheetStatus) Locked() bool {
+	return s == TimesheetSubmitted || s == TimesheetApproved
+}
+
+// Timesheet is the time a user logged in one week, from Monday to Sunday.
+type Timesheet struct {
+	ID           int                    `json:"id"`
+	UserID       int                    `json:"user_id"`
+	WeekStart    string                 `json:"week_start" example:"2021-09-06"`
+	Status       TimesheetStatus        `json:"status"`
+	TotalMinutes int                    `json:"total_minutes"`
+	CreationDate string                 `json:"creation_date"`
+	Worklogs     []*Worklog             `json:"worklogs,omitempty"`
+	Transitions  []*TimesheetTransition `json:"transitions,omitempty"`
+}
+
+type TimesheetTransition struct {
+	ID           int             `json:"id"`
+	FromStatus   TimesheetStatus `json:"from_status"`
+	ToStatus     TimesheetStatus `json:"to_status"`
+	UserID       int             `json:"user_id"`
+	Comment      string          `json:"comment"`
+	CreationDate string          `json:"creation_date"`
+}
+
+// BillableEntry is a worklog of an approved timesheet.
+type BillableEntry struct {
+	Date      string
+	WeekStart string
+	UserID    int
+	UserName  string
+	TaskID    int
+	TaskTitle string
+	Minutes   int
+	Note      string
+}
+
+// WeekStart returns the Monday of the week of t.
+func WeekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+type TimesheetModel interface {
+	GetUserTimesheets(userID int) ([]*Timesheet, error)
+	GetTimesheetById(id int) (*Timesheet, error)
+	// GetOrCreateTimesheet returns the user's timesheet for the week starting
+	// on weekStart and whether it was just created.
+	GetOrCreateTimesheet(userID int, weekStart string) (*Timesheet, bool, error)
+	GetTimesheetWorklogs(timesheet *Timesheet) ([]*Worklog, error)
+	GetTransitions(id int) ([]*TimesheetTransition, error)
+	GetTimesheetManagers(timesheet *Timesheet) ([]int, error)
+	Transition(timesheet *Timesheet, next TimesheetStatus, userID int, comment string) error
+	GetApprovedEntries(projectID int, from, to string) ([]*BillableEntry, error)
+}
+
+type TimesheetModelImpl struct {
+	DB *sql.DB
+}
+
+func NewTimesheetModel(db *sql.DB) *TimesheetModelImpl {
+	return &TimesheetModelImpl{DB: db}
+}
+
+const timesheetColumns = `id, user_id, week_start, status,
+	(SELECT COALESCE(SUM(w.minutes), 0) FROM worklogs w WHERE w.user_id = timesheets.user_id AND w.date BETWEEN timesheets.week_start AND timesheets.week_start + 6),
+	creation_date`
+
+func (m *TimesheetModelImpl) GetUserTimesheets(userID int) ([]*Timesheet, error) {
+	rows, err := m.DB.Query("SELECT "+timesheetColumns+" FROM timesheets WHERE user_id = $1 ORDER BY week_start DESC", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	timesheets := make([]*Timesheet, 0)
+	for rows.Next() {
+		timesheet := &Timesheet{}
+		err := scanTimesheet(rows, timesheet)
+		if err != nil {
+			return nil, err
+		}
+		timesheets = append(timesheets, timesheet)
+	}
+	return timesheets, rows.Err()
+}
+
+func (m *TimesheetModelImpl) GetTimesheetById(id int) (*Timesheet, error) {
+	timesheet := &Timesheet{}
+	err := scanTimesheet(m.DB.QueryRow("SELECT "+timesheetColumns+" FROM timesheets WHERE id = $1", id), timesheet)
+	if err != nil {
+		return nil, err
+	}
+	return timesheet, nil
+}
+
+func (m *TimesheetModelImpl) GetOrCreateTimesheet(userID int, weekStart string) (*Timesheet, bool, error) {
+	result, err := m.DB.Exec("INSERT INTO timesheets (user_id, week_start, status) VALUES ($1, $2, $3) ON CONFLICT (user_id, week_start) DO NOTHING",
+		userID, weekStart, TimesheetOpen)
+	if err != nil {
+		return nil, false, err
+	}
+	created, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+	timesheet := &Timesheet{}
+	err = scanTimesheet(m.DB.QueryRow("SELECT "+timesheetColumns+" FROM timesheets WHERE user_id = $1 AND week_start = $2", userID, weekStart), timesheet)
+	if err != nil {
+		return nil, false, err
+	}
+	return timesheet, created == 1, nil
+}
+
+func (m *TimesheetModelImpl) GetTimesheetWorklogs(timesheet *Timesheet) ([]*Worklog, error) {
+	rows, err := m.DB.Query("SELECT "+worklogColumns+" FROM worklogs WHERE user_id = $1 AND date BETWEEN $2::date AND $2::date + 6 ORDER BY date, id",
+		timesheet.UserID, timesheet.WeekStart)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	worklogs := make([]*Worklog, 0)
+	for rows.Next() {
+		worklog := &Worklog{}
+		err := scanWorklog(rows, worklog)
+		if err != nil {
+			return nil, err
+		}
+		worklogs = append(worklogs, worklog)
+	}
+	return worklogs, rows.Err()
+}
+
+func (m *TimesheetModelImpl) GetTransitions(id int) ([]*TimesheetTransition, error) {
+	rows, err := m.DB.Query("SELECT id, from_status, to_status, user_id, comment, creation_date FROM timesheet_transitions WHERE timesheet_id = $1 ORDER BY id", id)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	transitions := make([]*TimesheetTransition, 0)
+	for rows.Next() {
+		transition := &TimesheetTransition{}
+		var userID sql.NullInt64
+		err := rows.Scan(&transition.ID, &transition.FromStatus, &transition.ToStatus, &userID, &transition.Comment, &transition.CreationDate)
+		if err != nil {
+			return nil, err
+		}
+		transition.UserID = int(userID.Int64)
+		transitions = append(transitions, transition)
+	}
+	return transitions, rows.Err()
+}
+
+// GetTimesheetManagers returns the managers of the projects the user logged
+// time in during the week of the timesheet.
+func (m *TimesheetModelImpl) GetTimesheetManagers(timesheet *Timesheet) ([]int, error) {
+	rows, err := m.DB.Query(`SELECT DISTINCT p.manager_id FROM worklogs w JOIN tasks t ON t.id = w.task_id JOIN projects p ON p.id = t.project_id
+		WHERE w.user_id = $1 AND w.date BETWEEN $2::date AND $2::date + 6 AND p.manager_id IS NOT NULL ORDER BY p.manager_id`,
+		timesheet.UserID, timesheet.WeekStart)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	managers := make([]int, 0)
+	for rows.Next() {
+		var managerID int
+		err := rows.Scan(&managerID)
+		if err != nil {
+			return nil, err
+		}
+		managers = append(managers, managerID)
+	}
+	return managers, rows.Err()
+}
+
+// Transition moves the timesheet to next and records who did it and why. It
+// fails when the timesheet changed status in the meantime.
+func (m *TimesheetModelImpl) Transition(timesheet *Timesheet, next TimesheetStatus, userID int, comment string) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	result, err := tx.Exec("UPDATE timesheets SET status = $1 WHERE id = $2 AND status = $3", next, timesheet.ID, timesheet.Status)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return sql.ErrNoRows
+	}
+	_, err = tx.Exec("INSERT INTO timesheet_transitions (timesheet_id, from_status, to_status, user_id, comment) VALUES ($1, $2, $3, $4, $5)",
+		timesheet.ID, timesheet.Status, next, nullableID(userID), comment)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetApprovedEntries returns the worklogs on tasks of the project that belong
+// to approved timesheets, between from and to inclusive; either may be empty.
+func (m *TimesheetModelImpl) GetApprovedEntries(projectID int, from, to string) ([]*BillableEntry, error) {
+	rows, err := m.DB.Query(`SELECT w.date, ts.week_start, u.id, COALESCE(u.name, ''), t.id, COALESCE(t.title, ''), w.minutes, w.note
+		FROM worklogs w
+		JOIN tasks t ON t.id = w.task_id
+		JOIN users u ON u.id = w.user_id
+		JOIN timesheets ts ON ts.user_id = w.user_id AND ts.week_start = date_trunc('week', w.date)::date
+		WHERE ts.status = $1 AND t.project_id = $2 AND ($3::date IS NULL OR w.date >= $3) AND ($4::date IS NULL OR w.date <= $4)
+		ORDER BY w.date, u.id, w.id`, TimesheetApproved, projectID, nullableString(from), nullableString(to))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	entries := make([]*BillableEntry, 0)
+	for rows.Next() {
+		entry := &BillableEntry{}
+		err := rows.Scan(&entry.Date, &entry.WeekStart, &entry.UserID, &entry.UserName, &entry.TaskID, &entry.TaskTitle, &entry.Minutes, &entry.Note)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func scanTimesheet(row rowScanner, timesheet *Timesheet) error {
+	return row.Scan(&timesheet.ID, &timesheet.UserID, &timesheet.WeekStart, &timesheet.Status, &timesheet.TotalMinutes, &timesheet.CreationDate)
+}
@@ -97,7 +97,7 @@ func (m *UserModelImpl) DeleteUser(id int) (int, error) {
 	var deletedId int
 	err := row.Scan(&deletedId)
 	if err != nil {
-		return 0, err
+		return 0, timesheetLockError(err)
 	}
 	return id, nil
 }
@@ -149,30 +149,5 @@ func (m *UserModelImpl) SearchUserByName(name string) ([]*User, error) {
 }
 
 func (m *UserModelImpl) GetUserTasks(id int) ([]*Task, error) {
-	rows, err := m.DB.Query("SELECT * FROM tasks WHERE responsible_user_id = $1", id)
-	if err != nil {
-		return nil, err
-	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-
-		}
-	}(rows)
-	tasks := make([]*Task, 0)
-	for rows.Next() {
-		task := &Task{}
-		var completionDate sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.ResponsibleUserID, &task.ProjectID, &task.CreationDate, &completionDate)
-		if err != nil {
-			return nil, err
-		}
-		if completionDate.Valid {
-			task.CompletionDate = completionDate.String
-		} else {
-			task.CompletionDate = ""
-		}
-		tasks = append(tasks, task)
-	}
-	return tasks, nil
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE responsible_user_id = $1", id)
 }
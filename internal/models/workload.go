@@ -0,0 +1,177 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Capacity is how many hours a week a user can work, and the team they
+// belong to; users without one set work 40 hours in no team. Name is
+// read-only.
+type Capacity struct {
+	UserID      int     `json:"user_id"`
+	Name        string  `json:"name"`
+	WeeklyHours float64 `json:"weekly_hours" example:"32"`
+	Team        string  `json:"team" example:"Platform"`
+}
+
+func (c *Capacity) Validate() error {
+	if c.WeeklyHours < 0 || c.WeeklyHours > 168 {
+		return fmt.Errorf("weekly_hours must be between 0 and 168")
+	}
+	return nil
+}
+
+// TimeOff is a period, both days included, in which a user does not work.
+type TimeOff struct {
+	ID           int    `json:"id"`
+	UserID       int    `json:"user_id"`
+	StartDate    string `json:"start_date" example:"2021-09-13"`
+	EndDate      string `json:"end_date" example:"2021-09-17"`
+	Reason       string `json:"reason" example:"Vacation"`
+	CreationDate string `json:"creation_date"`
+}
+
+func (t *TimeOff) Validate() error {
+	start, err := ParseDate(t.StartDate)
+	if err != nil {
+		return fmt.Errorf("invalid start_date, expected YYYY-MM-DD")
+	}
+	end, err := ParseDate(t.EndDate)
+	if err != nil {
+		return fmt.Errorf("invalid end_date, expected YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return fmt.Errorf("end_date cannot be before start_date")
+	}
+	return nil
+}
+
+type WorkloadModel interface {
+	// GetCapacity returns the capacity of the user, the default one if none
+	// was set, or nil if the user does not exist.
+	GetCapacity(userID int) (*Capacity, error)
+	SetCapacity(capacity *Capacity) error
+	// GetCapacities returns the capacity of the users of team, or of every
+	// user when team is empty. With a projectID, only the users responsible
+	// for open tasks of that project are returned.
+	GetCapacities(projectID int, team string) ([]*Capacity, error)
+	GetUserTimeOff(userID int) ([]*TimeOff, error)
+	// GetTimeOffBetween returns the time off overlapping from to to.
+	GetTimeOffBetween(from, to string) ([]*TimeOff, error)
+	CreateTimeOff(timeOff *TimeOff) (int, error)
+	DeleteTimeOff(id int) (int, error)
+	// GetOpenAssignedTasks returns the tasks that are not done and have a
+	// responsible user, in one project or in all when projectID is 0.
+	GetOpenAssignedTasks(projectID int) ([]*Task, error)
+}
+
+type WorkloadModelImpl struct {
+	DB *sql.DB
+}
+
+func NewWorkloadModel(db *sql.DB) *WorkloadModelImpl {
+	return &WorkloadModelImpl{DB: db}
+}
+
+const capacityQuery = `SELECT u.id, COALESCE(u.name, ''), COALESCE(c.weekly_hours, 40), COALESCE(c.team, '')
+	FROM users u LEFT JOIN user_capacity c ON c.user_id = u.id`
+
+func (m *WorkloadModelImpl) GetCapacity(userID int) (*Capacity, error) {
+	capacity := &Capacity{}
+	err := m.DB.QueryRow(capacityQuery+" WHERE u.id = $1", userID).Scan(&capacity.UserID, &capacity.Name, &capacity.WeeklyHours, &capacity.Team)
+	if err != nil {
+		return nil, err
+	}
+	return capacity, nil
+}
+
+func (m *WorkloadModelImpl) SetCapacity(capacity *Capacity) error {
+	_, err := m.DB.Exec("INSERT INTO user_capacity (user_id, weekly_hours, team) VALUES ($1, $2, $3) ON CONFLICT (user_id) DO UPDATE SET weekly_hours = EXCLUDED.weekly_hours, team = EXCLUDED.team",
+		capacity.UserID, capacity.WeeklyHours, capacity.Team)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *WorkloadModelImpl) GetCapacities(projectID int, team string) ([]*Capacity, error) {
+	rows, err := m.DB.Query(capacityQuery+`
+		WHERE ($1 = '' OR c.team = $1)
+		AND ($2 = 0 OR EXISTS (SELECT 1 FROM tasks t WHERE t.responsible_user_id = u.id AND t.project_id = $2 AND t.status <> 'done'))
+		ORDER BY u.id`, team, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	capacities := make([]*Capacity, 0)
+	for rows.Next() {
+		capacity := &Capacity{}
+		err := rows.Scan(&capacity.UserID, &capacity.Name, &capacity.WeeklyHours, &capacity.Team)
+		if err != nil {
+			return nil, err
+		}
+		capacities = append(capacities, capacity)
+	}
+	return capacities, rows.Err()
+}
+
+func (m *WorkloadModelImpl) GetUserTimeOff(userID int) ([]*TimeOff, error) {
+	return m.queryTimeOff("SELECT id, user_id, start_date, end_date, reason, creation_date FROM time_off WHERE user_id = $1 ORDER BY start_date, id", userID)
+}
+
+func (m *WorkloadModelImpl) GetTimeOffBetween(from, to string) ([]*TimeOff, error) {
+	return m.queryTimeOff("SELECT id, user_id, start_date, end_date, reason, creation_date FROM time_off WHERE start_date <= $2 AND end_date >= $1 ORDER BY user_id, start_date", from, to)
+}
+
+func (m *WorkloadModelImpl) CreateTimeOff(timeOff *TimeOff) (int, error) {
+	var id int
+	err := m.DB.QueryRow("INSERT INTO time_off (user_id, start_date, end_date, reason) VALUES ($1, $2, $3, $4) RETURNING id",
+		timeOff.UserID, timeOff.StartDate, timeOff.EndDate, timeOff.Reason).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (m *WorkloadModelImpl) DeleteTimeOff(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM time_off WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+func (m *WorkloadModelImpl) GetOpenAssignedTasks(projectID int) ([]*Task, error) {
+	return queryTasks(m.DB, "SELECT "+taskColumns+" FROM tasks WHERE status <> 'done' AND responsible_user_id IS NOT NULL AND ($1 = 0 OR project_id = $1) ORDER BY id", projectID)
+}
+
+func (m *WorkloadModelImpl) queryTimeOff(query string, args ...any) ([]*TimeOff, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	entries := make([]*TimeOff, 0)
+	for rows.Next() {
+		timeOff := &TimeOff{}
+		err := rows.Scan(&timeOff.ID, &timeOff.UserID, &timeOff.StartDate, &timeOff.EndDate, &timeOff.Reason, &timeOff.CreationDate)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, timeOff)
+	}
+	return entries, rows.Err()
+}
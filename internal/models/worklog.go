@@ -0,0 +1,242 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Worklog is time a user spent on a task on a given day.
+type Worklog struct {
+	ID           int    `json:"id"`
+	TaskID       int    `json:"task_id"`
+	UserID       int    `json:"user_id"`
+	Date         string `json:"date" example:"2021-09-06"`
+	Minutes      int    `json:"minutes" example:"90"`
+	Note         string `json:"note"`
+	CreationDate string `json:"creation_date"`
+}
+
+func (w *Worklog) Validate() error {
+	if w.UserID == 0 {
+		return fmt.Errorf("user_id is required")
+	}
+	if _, err := ParseDate(w.Date); err != nil {
+		return fmt.Errorf("invalid date, expected YYYY-MM-DD")
+	}
+	if w.Minutes <= 0 {
+		return fmt.Errorf("minutes must be positive")
+	}
+	return nil
+}
+
+// UserTime is the time logged by one user.
+type UserTime struct {
+	UserID        int `json:"user_id"`
+	LoggedMinutes int `json:"logged_minutes"`
+}
+
+// TimeSummary compares the estimates of a set of tasks with the time logged.
+// VarianceMinutes is logged plus remaining minus original: positive when the
+// work takes longer than estimated.
+type TimeSummary struct {
+	Tasks                    int        `json:"tasks"`
+	StoryPoints              float64    `json:"story_points"`
+	OriginalEstimateMinutes  int        `json:"original_estimate_minutes"`
+	RemainingEstimateMinutes int        `json:"remaining_estimate_minutes"`
+	LoggedMinutes            int        `json:"logged_minutes"`
+	VarianceMinutes          int        `json:"variance_minutes"`
+	ByUser                   []UserTime `json:"by_user,omitempty"`
+}
+
+type WorklogModel interface {
+	GetTaskWorklogs(taskID int) ([]*Worklog, error)
+	GetUserWorklogs(userID int, from, to string) ([]*Worklog, error)
+	GetWorklogById(id int) (*Worklog, error)
+	CreateWorklog(worklog *Worklog) (int, error)
+	UpdateWorklog(worklog *Worklog) error
+	DeleteWorklog(id int) (int, error)
+	GetTaskTimeSummary(taskID int) (*TimeSummary, error)
+	GetUserTimeSummary(userID int, from, to string) (*TimeSummary, error)
+	GetProjectTimeSummary(projectID int) (*TimeSummary, error)
+	IsLocked(userID int, date string) (bool, error)
+}
+
+type WorklogModelImpl struct {
+	DB *sql.DB
+}
+
+func NewWorklogModel(db *sql.DB) *WorklogModelImpl {
+	return &WorklogModelImpl{DB: db}
+}
+
+const worklogColumns = "id, task_id, user_id, date, minutes, note, creation_date"
+
+func (m *WorklogModelImpl) GetTaskWorklogs(taskID int) ([]*Worklog, error) {
+	return m.queryWorklogs("SELECT "+worklogColumns+" FROM worklogs WHERE task_id = $1 ORDER BY date, id", taskID)
+}
+
+// GetUserWorklogs returns the worklogs of a user between from and to
+// inclusive; either bound may be empty.
+func (m *WorklogModelImpl) GetUserWorklogs(userID int, from, to string) ([]*Worklog, error) {
+	return m.queryWorklogs("SELECT "+worklogColumns+" FROM worklogs WHERE user_id = $1 AND ($2::date IS NULL OR date >= $2) AND ($3::date IS NULL OR date <= $3) ORDER BY date, id",
+		userID, nullableString(from), nullableString(to))
+}
+
+func (m *WorklogModelImpl) GetWorklogById(id int) (*Worklog, error) {
+	worklog := &Worklog{}
+	err := scanWorklog(m.DB.QueryRow("SELECT "+worklogColumns+" FROM worklogs WHERE id = $1", id), worklog)
+	if err != nil {
+		return nil, err
+	}
+	return worklog, nil
+}
+
+// CreateWorklog records the time and takes it off the task's remaining estimate.
+func (m *WorklogModelImpl) CreateWorklog(worklog *Worklog) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var id int
+	err = tx.QueryRow("INSERT INTO worklogs (task_id, user_id, date, minutes, note) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		worklog.TaskID, worklog.UserID, worklog.Date, worklog.Minutes, worklog.Note).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec("UPDATE tasks SET remaining_estimate_minutes = GREATEST(remaining_estimate_minutes - $1, 0) WHERE id = $2 AND remaining_estimate_minutes IS NOT NULL",
+		worklog.Minutes, worklog.TaskID)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// UpdateWorklog changes the date, time and note; the remaining estimate is
+// left as it is.
+func (m *WorklogModelImpl) UpdateWorklog(worklog *Worklog) error {
+	_, err := m.DB.Exec("UPDATE worklogs SET date = $1, minutes = $2, note = $3 WHERE id = $4", worklog.Date, worklog.Minutes, worklog.Note, worklog.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *WorklogModelImpl) DeleteWorklog(id int) (int, error) {
+	row := m.DB.QueryRow("DELETE FROM worklogs WHERE id = $1 RETURNING id", id)
+	var deletedId int
+	err := row.Scan(&deletedId)
+	if err != nil {
+		return 0, err
+	}
+	return deletedId, nil
+}
+
+// IsLocked reports whether the user's timesheet for the week of date was
+// submitted or approved, which freezes the worklogs of that week.
+func (m *WorklogModelImpl) IsLocked(userID int, date string) (bool, error) {
+	var locked bool
+	err := m.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM timesheets WHERE user_id = $1 AND week_start = date_trunc('week', $2::date)::date AND status IN ($3, $4))",
+		userID, date, TimesheetSubmitted, TimesheetApproved).Scan(&locked)
+	if err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (m *WorklogModelImpl) GetTaskTimeSummary(taskID int) (*TimeSummary, error) {
+	summary, err := m.summarize("WHERE id = $1", "WHERE w.task_id = $1", taskID)
+	if err != nil {
+		return nil, err
+	}
+	summary.ByUser, err = m.timeByUser("WHERE w.task_id = $1", taskID)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// GetUserTimeSummary compares the estimates of the tasks the user is
+// responsible for with the time the user logged between from and to.
+func (m *WorklogModelImpl) GetUserTimeSummary(userID int, from, to string) (*TimeSummary, error) {
+	return m.summarize("WHERE responsible_user_id = $1", "WHERE w.user_id = $1 AND ($2::date IS NULL OR w.date >= $2) AND ($3::date IS NULL OR w.date <= $3)",
+		userID, nullableString(from), nullableString(to))
+}
+
+func (m *WorklogModelImpl) GetProjectTimeSummary(projectID int) (*TimeSummary, error) {
+	summary, err := m.summarize("WHERE project_id = $1", "JOIN tasks t ON t.id = w.task_id WHERE t.project_id = $1", projectID)
+	if err != nil {
+		return nil, err
+	}
+	summary.ByUser, err = m.timeByUser("JOIN tasks t ON t.id = w.task_id WHERE t.project_id = $1", projectID)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// summarize adds up the estimates of the tasks matched by taskFilter and the
+// minutes of the worklogs (aliased w) matched by worklogFilter. Both filters
+// share args.
+func (m *WorklogModelImpl) summarize(taskFilter, worklogFilter string, args ...any) (*TimeSummary, error) {
+	summary := &TimeSummary{}
+	err := m.DB.QueryRow(`SELECT COUNT(*), COALESCE(SUM(story_points), 0), COALESCE(SUM(original_estimate_minutes), 0), COALESCE(SUM(remaining_estimate_minutes), 0),
+		(SELECT COALESCE(SUM(w.minutes), 0) FROM worklogs w `+worklogFilter+`)
+		FROM tasks `+taskFilter, args...).
+		Scan(&summary.Tasks, &summary.StoryPoints, &summary.OriginalEstimateMinutes, &summary.RemainingEstimateMinutes, &summary.LoggedMinutes)
+	if err != nil {
+		return nil, err
+	}
+	summary.VarianceMinutes = summary.LoggedMinutes + summary.RemainingEstimateMinutes - summary.OriginalEstimateMinutes
+	return summary, nil
+}
+
+func (m *WorklogModelImpl) timeByUser(worklogFilter string, args ...any) ([]UserTime, error) {
+	rows, err := m.DB.Query("SELECT w.user_id, SUM(w.minutes) FROM worklogs w "+worklogFilter+" GROUP BY w.user_id ORDER BY w.user_id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	times := make([]UserTime, 0)
+	for rows.Next() {
+		var userTime UserTime
+		err := rows.Scan(&userTime.UserID, &userTime.LoggedMinutes)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, userTime)
+	}
+	return times, rows.Err()
+}
+
+func (m *WorklogModelImpl) queryWorklogs(query string, args ...any) ([]*Worklog, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			return
+		}
+	}(rows)
+	worklogs := make([]*Worklog, 0)
+	for rows.Next() {
+		worklog := &Worklog{}
+		err := scanWorklog(rows, worklog)
+		if err != nil {
+			return nil, err
+		}
+		worklogs = append(worklogs, worklog)
+	}
+	return worklogs, rows.Err()
+}
+
+func scanWorklog(row rowScanner, worklog *Worklog) error {
+	return row.Scan(&worklog.ID, &worklog.TaskID, &worklog.UserID, &worklog.Date, &worklog.Minutes, &worklog.Note, &worklog.CreationDate)
+}
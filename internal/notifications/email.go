@@ -0,0 +1,211 @@
+package notifications
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/mailer"
+	"ProjectManagementService/internal/models"
+	"log"
+	"time"
+)
+
+type taskEmail struct {
+	Name            string
+	TaskID          int
+	Title           string
+	Description     string
+	Priority        models.PriorityEnum
+	Status          models.StatusEnum
+	DueDate         string
+	PreviousDueDate string
+}
+
+type digestEmail struct {
+	Name      string
+	Date      string
+	Open      []digestTask
+	Overdue   []digestTask
+	Completed []digestTask
+}
+
+type digestTask struct {
+	ID       int
+	Title    string
+	Priority models.PriorityEnum
+	DueDate  string
+}
+
+// EmailNotifier mirrors assignment and due date changes to email and sends
+// the opt-in daily digest.
+type EmailNotifier struct {
+	Mailer        mailer.Mailer
+	Notifications models.NotificationModel
+	Users         models.UserModel
+	Tasks         models.TaskModel
+}
+
+func NewEmailNotifier(m mailer.Mailer, notificationModel models.NotificationModel, userModel models.UserModel, taskModel models.TaskModel) *EmailNotifier {
+	return &EmailNotifier{
+		Mailer:        m,
+		Notifications: notificationModel,
+		Users:         userModel,
+		Tasks:         taskModel,
+	}
+}
+
+// Register subscribes to the bus. Delivery runs in the background so that a
+// slow SMTP server does not hold up the request that caused the event.
+func (e *EmailNotifier) Register(bus *events.Bus) {
+	bus.Subscribe(func(event events.Event) {
+		go e.Handle(event)
+	})
+}
+
+func (e *EmailNotifier) Handle(event events.Event) {
+	switch event.Type {
+	case events.TaskCreated:
+		task := event.Task
+		if task.ResponsibleUserID != 0 {
+			e.sendTaskEmail(task.ResponsibleUserID, "assignment", task, "")
+		}
+	case events.TaskUpdated:
+		task, previous := event.Task, event.PreviousTask
+		if task.ResponsibleUserID == 0 {
+			return
+		}
+		if task.ResponsibleUserID != previous.ResponsibleUserID {
+			e.sendTaskEmail(task.ResponsibleUserID, "assignment", task, "")
+			return
+		}
+		if formatDate(task.DueDate) != formatDate(previous.DueDate) {
+			e.sendTaskEmail(task.ResponsibleUserID, "due_date", task, previous.DueDate)
+		}
+	}
+}
+
+// SendDueDateReminders emails assignees of open tasks due today or tomorrow.
+func (e *EmailNotifier) SendDueDateReminders(now time.Time) error {
+	tasks, err := e.Tasks.GetOpenTasksDueBetween(now.Format(models.DateLayout), now.AddDate(0, 0, 1).Format(models.DateLayout))
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.ResponsibleUserID != 0 {
+			e.sendTaskEmail(task.ResponsibleUserID, "due_soon", task, "")
+		}
+	}
+	return nil
+}
+
+func (e *EmailNotifier) sendTaskEmail(userID int, template string, task *models.Task, previousDueDate string) {
+	notificationType := models.NotificationAssigned
+	switch template {
+	case "due_date":
+		notificationType = models.NotificationDueDateChange
+	case "due_soon":
+		notificationType = models.NotificationDueSoon
+	}
+	user := e.recipient(userID, notificationType)
+	if user == nil {
+		return
+	}
+	message, err := mailer.Render(template, user.Email, taskEmail{
+		Name:            user.Name,
+		TaskID:          task.ID,
+		Title:           task.Title,
+		Description:     task.Description,
+		Priority:        task.Priority,
+		Status:          task.Status,
+		DueDate:         formatDate(task.DueDate),
+		PreviousDueDate: formatDate(previousDueDate),
+	})
+	if err != nil {
+		log.Printf("could not render %s email: %v\n", template, err)
+		return
+	}
+	if err := e.Mailer.Send(message); err != nil {
+		log.Printf("could not send %s email to user %d: %v\n", template, userID, err)
+	}
+}
+
+// recipient returns the user if they accept email for the given notification type.
+func (e *EmailNotifier) recipient(userID int, notificationType models.NotificationType) *models.User {
+	preferences, err := e.Notifications.GetPreferences(userID)
+	if err != nil {
+		log.Printf("could not load notification preferences of user %d: %v\n", userID, err)
+		return nil
+	}
+	if !preferences.Email || !preferences.Allows(notificationType) {
+		return nil
+	}
+	user, err := e.Users.GetUserById(userID)
+	if err != nil || user == nil || user.Email == "" {
+		return nil
+	}
+	return user
+}
+
+// SendDailyDigests emails every subscribed user a summary of their open,
+// overdue and recently completed tasks. Users with nothing to report are skipped.
+func (e *EmailNotifier) SendDailyDigests(now time.Time) error {
+	subscribers, err := e.Notifications.GetDigestSubscribers()
+	if err != nil {
+		return err
+	}
+	for _, userID := range subscribers {
+		user, err := e.Users.GetUserById(userID)
+		if err != nil || user == nil || user.Email == "" {
+			continue
+		}
+		tasks, err := e.Users.GetUserTasks(userID)
+		if err != nil {
+			log.Printf("could not load tasks of user %d: %v\n", userID, err)
+			continue
+		}
+		digest := buildDigest(user, tasks, now)
+		if len(digest.Open)+len(digest.Overdue)+len(digest.Completed) == 0 {
+			continue
+		}
+		message, err := mailer.Render("digest", user.Email, digest)
+		if err != nil {
+			return err
+		}
+		if err := e.Mailer.Send(message); err != nil {
+			log.Printf("could not send digest to user %d: %v\n", userID, err)
+		}
+	}
+	return nil
+}
+
+// buildDigest sorts a user's tasks into overdue, other open and completed
+// within the last day.
+func buildDigest(user *models.User, tasks []*models.Task, now time.Time) *digestEmail {
+	today := now.Format(models.DateLayout)
+	yesterday := now.AddDate(0, 0, -1).Format(models.DateLayout)
+	digest := &digestEmail{Name: user.Name, Date: today}
+	for _, task := range tasks {
+		item := digestTask{ID: task.ID, Title: task.Title, Priority: task.Priority, DueDate: formatDate(task.DueDate)}
+		switch {
+		case task.Status == models.Done:
+			if completed := formatDate(task.CompletionDate); completed != "" && completed >= yesterday {
+				digest.Completed = append(digest.Completed, item)
+			}
+		case item.DueDate != "" && item.DueDate < today:
+			digest.Overdue = append(digest.Overdue, item)
+		default:
+			digest.Open = append(digest.Open, item)
+		}
+	}
+	return digest
+}
+
+// formatDate normalizes a date column to YYYY-MM-DD, returning "" for empty or invalid values.
+func formatDate(value string) string {
+	if value == "" {
+		return ""
+	}
+	date, err := models.ParseDate(value)
+	if err != nil {
+		return ""
+	}
+	return date.Format(models.DateLayout)
+}
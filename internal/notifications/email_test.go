@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/mailer"
+	"ProjectManagementService/internal/models"
+	"testing"
+	"time"
+)
+
+func TestBuildDigest(t *testing.T) {
+	now := time.Date(2021, 9, 10, 7, 0, 0, 0, time.UTC)
+	tasks := []*models.Task{
+		{ID: 1, Title: "Overdue", Status: models.InProgress, DueDate: "2021-09-09T00:00:00Z"},
+		{ID: 2, Title: "Open", Status: models.New, DueDate: "2021-09-10T00:00:00Z"},
+		{ID: 3, Title: "Open without due date", Status: models.New},
+		{ID: 4, Title: "Done yesterday", Status: models.Done, CompletionDate: "2021-09-09T00:00:00Z"},
+		{ID: 5, Title: "Done last week", Status: models.Done, CompletionDate: "2021-09-02T00:00:00Z"},
+	}
+
+	digest := buildDigest(&models.User{ID: 1, Name: "Jane"}, tasks, now)
+
+	if digest.Date != "2021-09-10" {
+		t.Errorf("unexpected date: %v", digest.Date)
+	}
+	if len(digest.Overdue) != 1 || digest.Overdue[0].ID != 1 {
+		t.Errorf("unexpected overdue tasks: %v", digest.Overdue)
+	}
+	if len(digest.Open) != 2 || digest.Open[0].ID != 2 || digest.Open[1].ID != 3 {
+		t.Errorf("unexpected open tasks: %v", digest.Open)
+	}
+	if len(digest.Completed) != 1 || digest.Completed[0].ID != 4 {
+		t.Errorf("unexpected completed tasks: %v", digest.Completed)
+	}
+}
+
+type recordingMailer struct {
+	subjects []string
+}
+
+func (m *recordingMailer) Send(message *mailer.Message) error {
+	m.subjects = append(m.subjects, message.Subject)
+	return nil
+}
+
+func TestEmailNotifierPreferences(t *testing.T) {
+	tests := []struct {
+		preferences *models.NotificationPreferences
+		previous    *models.Task
+		sent        int
+	}{
+		// turning off assignment emails keeps due date change emails
+		{&models.NotificationPreferences{UserID: 2, DueDateChange: true, Email: true}, &models.Task{ID: 1, ResponsibleUserID: 2, DueDate: "2021-09-10"}, 1},
+		{&models.NotificationPreferences{UserID: 2, DueDateChange: true, Email: true}, &models.Task{ID: 1, ResponsibleUserID: 3, DueDate: "2021-09-10"}, 0},
+		{&models.NotificationPreferences{UserID: 2, Assignment: true, Email: true}, &models.Task{ID: 1, ResponsibleUserID: 2, DueDate: "2021-09-10"}, 0},
+		{&models.NotificationPreferences{UserID: 2, Assignment: true, Email: true}, &models.Task{ID: 1, ResponsibleUserID: 3, DueDate: "2021-09-10"}, 1},
+	}
+	for i, test := range tests {
+		m := &recordingMailer{}
+		notificationModel := &models.MockNotificationModel{
+			MockGetPreferences: func(userID int) (*models.NotificationPreferences, error) {
+				return test.preferences, nil
+			},
+		}
+		userModel := &models.MockUserModel{
+			MockGetUserById: func(id int) (*models.User, error) {
+				return &models.User{ID: id, Name: "Jane", Email: "jane@example.com"}, nil
+			},
+		}
+		notifier := NewEmailNotifier(m, notificationModel, userModel, nil)
+		task := &models.Task{ID: 1, Title: "Backup", ResponsibleUserID: 2, DueDate: "2021-09-14"}
+
+		notifier.Handle(events.Event{Type: events.TaskUpdated, Task: task, PreviousTask: test.previous})
+
+		if len(m.subjects) != test.sent {
+			t.Errorf("%d: sent %v, want %d emails", i, m.subjects, test.sent)
+		}
+	}
+}
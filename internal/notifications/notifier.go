@@ -0,0 +1,177 @@
+package notifications
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+)
+
+// mentionPattern matches "@" followed by an email address, e.g. "@jane@example.com".
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+// Notifier turns task, project and comment events into in-app notifications.
+type Notifier struct {
+	Notifications models.NotificationModel
+	Users         models.UserModel
+	Tasks         models.TaskModel
+}
+
+func NewNotifier(notificationModel models.NotificationModel, userModel models.UserModel, taskModel models.TaskModel) *Notifier {
+	return &Notifier{
+		Notifications: notificationModel,
+		Users:         userModel,
+		Tasks:         taskModel,
+	}
+}
+
+func (n *Notifier) Register(bus *events.Bus) {
+	bus.Subscribe(n.Handle)
+}
+
+func (n *Notifier) Handle(event events.Event) {
+	switch event.Type {
+	case events.TaskCreated:
+		task := event.Task
+		if task.ResponsibleUserID != 0 {
+			n.notify(task.ResponsibleUserID, models.NotificationAssigned, fmt.Sprintf("You were assigned to task %q", task.Title), task.ID, task.ProjectID)
+		}
+		n.notifyMentions(MentionedEmails(task.Description), fmt.Sprintf("You were mentioned in task %q", task.Title), task.ID, task.ProjectID)
+	case events.TaskUpdated:
+		task, previous := event.Task, event.PreviousTask
+		if task.ResponsibleUserID != 0 && task.ResponsibleUserID != previous.ResponsibleUserID {
+			n.notify(task.ResponsibleUserID, models.NotificationAssigned, fmt.Sprintf("You were assigned to task %q", task.Title), task.ID, task.ProjectID)
+		}
+		if task.Status != previous.Status {
+			message := fmt.Sprintf("Task %q moved from %s to %s", task.Title, previous.Status, task.Status)
+			for _, userID := range n.watchers(task.ID) {
+				n.notify(userID, models.NotificationStatusChanged, message, task.ID, task.ProjectID)
+			}
+		}
+		n.notifyMentions(newMentions(previous.Description, task.Description), fmt.Sprintf("You were mentioned in task %q", task.Title), task.ID, task.ProjectID)
+	case events.ProjectCreated:
+		project := event.Project
+		if project.ManagerID != 0 {
+			n.notify(project.ManagerID, models.NotificationAssigned, fmt.Sprintf("You are the manager of project %q", project.Title), 0, project.ID)
+		}
+		n.notifyMentions(MentionedEmails(project.Description), fmt.Sprintf("You were mentioned in project %q", project.Title), 0, project.ID)
+	case events.ProjectUpdated:
+		project, previous := event.Project, event.PreviousProject
+		if project.ManagerID != 0 && project.ManagerID != previous.ManagerID {
+			n.notify(project.ManagerID, models.NotificationAssigned, fmt.Sprintf("You are the manager of project %q", project.Title), 0, project.ID)
+		}
+		n.notifyMentions(newMentions(previous.Description, project.Description), fmt.Sprintf("You were mentioned in project %q", project.Title), 0, project.ID)
+	case events.CommentCreated:
+		task, comment := event.Task, event.Comment
+		recipients := n.watchers(task.ID)
+		if task.ResponsibleUserID != 0 {
+			recipients = append(recipients, task.ResponsibleUserID)
+		}
+		notified := map[int]bool{comment.UserID: true}
+		for _, userID := range recipients {
+			if notified[userID] {
+				continue
+			}
+			notified[userID] = true
+			n.notify(userID, models.NotificationComment, fmt.Sprintf("New comment on task %q", task.Title), task.ID, task.ProjectID)
+		}
+		n.notifyMentions(MentionedEmails(comment.Body), fmt.Sprintf("You were mentioned in a comment on task %q", task.Title), task.ID, task.ProjectID)
+	}
+}
+
+// SendDueDateReminders notifies assignees of open tasks due today or tomorrow.
+func (n *Notifier) SendDueDateReminders(now time.Time) error {
+	tasks, err := n.Tasks.GetOpenTasksDueBetween(now.Format(models.DateLayout), now.AddDate(0, 0, 1).Format(models.DateLayout))
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.ResponsibleUserID != 0 {
+			n.notify(task.ResponsibleUserID, models.NotificationDueSoon, fmt.Sprintf("Task %q is due on %s", task.Title, formatDate(task.DueDate)), task.ID, task.ProjectID)
+		}
+	}
+	return nil
+}
+
+// NotifyStaleTasks reminds assignees of open tasks that have not changed since before.
+func (n *Notifier) NotifyStaleTasks(before time.Time) error {
+	tasks, err := n.Tasks.GetStaleTasks(before)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.ResponsibleUserID != 0 {
+			n.notify(task.ResponsibleUserID, models.NotificationStaleTask, fmt.Sprintf("Task %q has not been updated since %s", task.Title, before.Format(models.DateLayout)), task.ID, task.ProjectID)
+		}
+	}
+	return nil
+}
+
+func (n *Notifier) notify(userID int, notificationType models.NotificationType, message string, taskID, projectID int) {
+	preferences, err := n.Notifications.GetPreferences(userID)
+	if err != nil {
+		log.Printf("could not load notification preferences of user %d: %v\n", userID, err)
+		return
+	}
+	if !preferences.Allows(notificationType) {
+		return
+	}
+	err = n.Notifications.CreateNotification(userID, notificationType, message, taskID, projectID)
+	if err != nil {
+		log.Printf("could not notify user %d: %v\n", userID, err)
+	}
+}
+
+func (n *Notifier) notifyMentions(emails []string, message string, taskID, projectID int) {
+	for _, email := range emails {
+		users, err := n.Users.SearchUserByEmail(email)
+		if err != nil {
+			log.Printf("could not resolve mention of %s: %v\n", email, err)
+			continue
+		}
+		for _, user := range users {
+			n.notify(user.ID, models.NotificationMention, message, taskID, projectID)
+		}
+	}
+}
+
+func (n *Notifier) watchers(taskID int) []int {
+	watchers, err := n.Notifications.GetTaskWatchers(taskID)
+	if err != nil {
+		log.Printf("could not load watchers of task %d: %v\n", taskID, err)
+		return nil
+	}
+	return watchers
+}
+
+// MentionedEmails returns the distinct emails mentioned in text, in order of appearance.
+func MentionedEmails(text string) []string {
+	seen := make(map[string]bool)
+	emails := make([]string, 0)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		email := match[1]
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// newMentions returns mentions present in current but not in previous, so that
+// editing a description does not notify the same people again.
+func newMentions(previous, current string) []string {
+	old := make(map[string]bool)
+	for _, email := range MentionedEmails(previous) {
+		old[email] = true
+	}
+	mentions := make([]string, 0)
+	for _, email := range MentionedEmails(current) {
+		if !old[email] {
+			mentions = append(mentions, email)
+		}
+	}
+	return mentions
+}
@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"reflect"
+	"testing"
+)
+
+type sent struct {
+	userID           int
+	notificationType models.NotificationType
+}
+
+func newTestNotifier(watchers []int, preferences map[int]*models.NotificationPreferences) (*Notifier, *[]sent) {
+	notifications := make([]sent, 0)
+	notificationModel := &models.MockNotificationModel{
+		MockCreateNotification: func(userID int, notificationType models.NotificationType, message string, taskID, projectID int) error {
+			notifications = append(notifications, sent{userID, notificationType})
+			return nil
+		},
+		MockGetTaskWatchers: func(taskID int) ([]int, error) {
+			return watchers, nil
+		},
+		MockGetPreferences: func(userID int) (*models.NotificationPreferences, error) {
+			if p, ok := preferences[userID]; ok {
+				return p, nil
+			}
+			return models.DefaultNotificationPreferences(userID), nil
+		},
+	}
+	userModel := &models.MockUserModel{
+		MockSearchUserByEmail: func(email string) ([]*models.User, error) {
+			if email == "jane@example.com" {
+				return []*models.User{{ID: 9, Email: email}}, nil
+			}
+			return []*models.User{}, nil
+		},
+	}
+	return NewNotifier(notificationModel, userModel, nil), &notifications
+}
+
+func TestMentionedEmails(t *testing.T) {
+	got := MentionedEmails("ping @jane@example.com and @bob@corp.io, again @jane@example.com; not me@example.com")
+	expected := []string{"jane@example.com", "bob@corp.io"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected mentions: got %v want %v", got, expected)
+	}
+}
+
+func TestNotifierTaskUpdated(t *testing.T) {
+	notifier, notifications := newTestNotifier([]int{2, 3}, map[int]*models.NotificationPreferences{
+		3: {UserID: 3, Assignment: true},
+	})
+	previous := &models.Task{ID: 1, Title: "Backup", Status: models.New, ResponsibleUserID: 2, Description: "@jane@example.com"}
+	task := &models.Task{ID: 1, Title: "Backup", Status: models.Done, ResponsibleUserID: 4, Description: "@jane@example.com again"}
+
+	notifier.Handle(events.Event{Type: events.TaskUpdated, Task: task, PreviousTask: previous})
+
+	expected := []sent{
+		{4, models.NotificationAssigned},
+		{2, models.NotificationStatusChanged},
+	}
+	if !reflect.DeepEqual(*notifications, expected) {
+		t.Errorf("unexpected notifications: got %v want %v", *notifications, expected)
+	}
+}
+
+func TestNotifierCommentCreated(t *testing.T) {
+	notifier, notifications := newTestNotifier([]int{5, 6}, nil)
+	task := &models.Task{ID: 1, Title: "Backup", ResponsibleUserID: 6}
+	comment := &models.Comment{ID: 1, TaskID: 1, UserID: 5, Body: "cc @jane@example.com"}
+
+	notifier.Handle(events.Event{Type: events.CommentCreated, Task: task, Comment: comment})
+
+	expected := []sent{
+		{6, models.NotificationComment},
+		{9, models.NotificationMention},
+	}
+	if !reflect.DeepEqual(*notifications, expected) {
+		t.Errorf("unexpected notifications: got %v want %v", *notifications, expected)
+	}
+}
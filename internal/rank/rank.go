@@ -0,0 +1,92 @@
+// Package rank generates lexicographically ordered strings used to keep
+// items in a user-defined order, in the spirit of Jira's LexoRank. A rank is
+// read as a base-36 fraction: moving an item only rewrites its own rank,
+// picked between the ranks of its new neighbours.
+package rank
+
+import (
+	"fmt"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// MaxLength is the length above which ranks should be spread again. Each
+// insertion at the same spot adds about one character.
+const MaxLength = 32
+
+// Between returns a rank that sorts after prev and before next. An empty prev
+// means the start of the list and an empty next its end.
+func Between(prev, next string) (string, error) {
+	if !valid(prev) || !valid(next) {
+		return "", fmt.Errorf("invalid rank %q or %q", prev, next)
+	}
+	if next != "" && prev >= next {
+		return "", fmt.Errorf("rank %q does not sort before %q", prev, next)
+	}
+	var out strings.Builder
+	bounded := next != ""
+	for i := 0; ; i++ {
+		low := 0
+		if i < len(prev) {
+			low = strings.IndexByte(digits, prev[i])
+		}
+		high := base
+		if bounded {
+			high = strings.IndexByte(digits, next[i])
+		}
+		if high-low > 1 {
+			out.WriteByte(digits[(low+high)/2])
+			return out.String(), nil
+		}
+		out.WriteByte(digits[low])
+		if high-low == 1 {
+			// The result is now below next whatever follows.
+			bounded = false
+		}
+	}
+}
+
+// Spread returns n ranks evenly spaced over the whole range, in order.
+func Spread(n int) []string {
+	width := 1
+	for capacity := base; capacity <= n; capacity *= base {
+		width++
+	}
+	// One more digit leaves room for several insertions between neighbours.
+	width++
+	total := 1
+	for i := 0; i < width; i++ {
+		total *= base
+	}
+	step := total / (n + 1)
+	ranks := make([]string, n)
+	for i := range ranks {
+		ranks[i] = encode((i+1)*step, width)
+	}
+	return ranks
+}
+
+// encode writes value with width base-36 digits and drops trailing zeros,
+// which do not change the fraction.
+func encode(value, width int) string {
+	out := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		out[i] = digits[value%base]
+		value /= base
+	}
+	return strings.TrimRight(string(out), "0")
+}
+
+// valid reports whether value only uses rank digits and has no trailing
+// zero, which would leave no room before a rank it is a prefix of.
+func valid(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if strings.IndexByte(digits, value[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(value, "0")
+}
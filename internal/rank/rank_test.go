@@ -0,0 +1,74 @@
+package rank
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		prev, next string
+	}{
+		{"", ""},
+		{"", "1"},
+		{"", "05"},
+		{"a", "b"},
+		{"a", "a5"},
+		{"az", "b"},
+		{"zz", ""},
+		{"i", "i1"},
+	}
+	for _, test := range tests {
+		got, err := Between(test.prev, test.next)
+		if err != nil {
+			t.Errorf("Between(%q, %q) failed: %v", test.prev, test.next, err)
+			continue
+		}
+		if got <= test.prev || (test.next != "" && got >= test.next) || !valid(got) {
+			t.Errorf("Between(%q, %q) = %q", test.prev, test.next, got)
+		}
+	}
+}
+
+func TestBetweenRejectsBadOrder(t *testing.T) {
+	for _, pair := range [][2]string{{"b", "a"}, {"a", "a"}, {"a0", "b"}, {"A", ""}} {
+		if got, err := Between(pair[0], pair[1]); err == nil {
+			t.Errorf("Between(%q, %q) = %q, want an error", pair[0], pair[1], got)
+		}
+	}
+}
+
+func TestRepeatedInsertionKeepsOrder(t *testing.T) {
+	// Always inserting right after the first item is the worst case for rank length.
+	ranks := Spread(2)
+	for i := 0; i < 100; i++ {
+		rank, err := Between(ranks[0], ranks[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		ranks = append([]string{ranks[0], rank}, ranks[1:]...)
+	}
+	if !sort.StringsAreSorted(ranks) {
+		t.Errorf("ranks are not sorted: %v", ranks)
+	}
+	if len(ranks[1]) > MaxLength*2 {
+		t.Errorf("rank grew to %d characters", len(ranks[1]))
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 35, 36, 1000} {
+		ranks := Spread(n)
+		if len(ranks) != n {
+			t.Fatalf("Spread(%d) returned %d ranks", n, len(ranks))
+		}
+		for i, rank := range ranks {
+			if !valid(rank) || rank == "" {
+				t.Errorf("Spread(%d)[%d] = %q is not a valid rank", n, i, rank)
+			}
+			if i > 0 && ranks[i-1] >= rank {
+				t.Errorf("Spread(%d) is not increasing at %d: %q >= %q", n, i, ranks[i-1], rank)
+			}
+		}
+	}
+}
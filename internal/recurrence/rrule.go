@@ -0,0 +1,243 @@
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// WeekdayNum is a BYDAY entry such as "MO", "1MO" (first Monday) or "-1FR"
+// (last Friday). N is 0 when no ordinal is given.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RRule is the subset of RFC 5545 recurrence rules that tasks support:
+// FREQ, INTERVAL, COUNT, UNTIL, BYDAY and BYMONTHDAY, at day granularity.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// Parse parses a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH". An
+// "RRULE:" prefix is accepted.
+func Parse(value string) (*RRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	rule := &RRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(val))
+			switch rule.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q", val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(val), ",") {
+				if len(day) < 2 {
+					return nil, fmt.Errorf("invalid BYDAY %q", day)
+				}
+				weekday, ok := weekdays[day[len(day)-2:]]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", day)
+				}
+				n := 0
+				if ordinal := day[:len(day)-2]; ordinal != "" {
+					var err error
+					n, err = strconv.Atoi(ordinal)
+					if err != nil || n == 0 || n < -5 || n > 5 {
+						return nil, fmt.Errorf("invalid BYDAY %q", day)
+					}
+				}
+				rule.ByDay = append(rule.ByDay, WeekdayNum{Weekday: weekday, N: n})
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q", day)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("COUNT and UNTIL cannot be combined")
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly {
+			return nil, fmt.Errorf("BYDAY ordinals are only supported with FREQ=MONTHLY")
+		}
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != Monthly && rule.Freq != Yearly {
+		return nil, fmt.Errorf("BYMONTHDAY requires FREQ=MONTHLY or FREQ=YEARLY")
+	}
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			return truncateDay(until), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+}
+
+// Next returns the first occurrence of a series starting on start that falls
+// strictly after the day of after. ok is false when UNTIL has passed; COUNT is
+// left to the caller, which knows how many occurrences were generated.
+func (r *RRule) Next(start, after time.Time) (next time.Time, ok bool) {
+	start, day := truncateDay(start), truncateDay(after).AddDate(0, 0, 1)
+	if day.Before(start) {
+		day = start
+	}
+	// a yearly Feb 29 rule needs up to eight years to find its next match
+	limit := day.AddDate(8*r.Interval, 0, 0)
+	for ; day.Before(limit); day = day.AddDate(0, 0, 1) {
+		if !r.Until.IsZero() && day.After(r.Until) {
+			return time.Time{}, false
+		}
+		if r.matches(start, day) {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *RRule) matches(start, day time.Time) bool {
+	switch r.Freq {
+	case Daily:
+		return daysBetween(start, day)%r.Interval == 0
+	case Weekly:
+		if daysBetween(weekStart(start), weekStart(day))/7%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == start.Weekday()
+		}
+		return r.matchesByDay(day)
+	case Monthly:
+		if monthsBetween(start, day)%r.Interval != 0 {
+			return false
+		}
+		return r.matchesDayOfMonth(start, day)
+	case Yearly:
+		if (day.Year()-start.Year())%r.Interval != 0 || day.Month() != start.Month() {
+			return false
+		}
+		return r.matchesDayOfMonth(start, day)
+	}
+	return false
+}
+
+// matchesDayOfMonth applies BYMONTHDAY and BYDAY within a month, falling back
+// to the day of month of the series start.
+func (r *RRule) matchesDayOfMonth(start, day time.Time) bool {
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		return day.Day() == start.Day()
+	}
+	if len(r.ByMonthDay) > 0 {
+		last := daysInMonth(day)
+		matched := false
+		for _, n := range r.ByMonthDay {
+			if n == day.Day() || (n < 0 && last+n+1 == day.Day()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return len(r.ByDay) == 0 || r.matchesByDay(day)
+}
+
+func (r *RRule) matchesByDay(day time.Time) bool {
+	for _, weekday := range r.ByDay {
+		if weekday.Weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case weekday.N == 0:
+			return true
+		case weekday.N > 0 && (day.Day()-1)/7+1 == weekday.N:
+			return true
+		case weekday.N < 0 && (daysInMonth(day)-day.Day())/7+1 == -weekday.N:
+			return true
+		}
+	}
+	return false
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// weekStart returns the Monday of the week of day, the RFC 5545 default WKST.
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+func daysInMonth(day time.Time) int {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
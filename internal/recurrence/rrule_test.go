@@ -0,0 +1,89 @@
+package recurrence
+
+import (
+	"ProjectManagementService/internal/models"
+	"testing"
+	"time"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse(models.DateLayout, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		rule  string
+		start string
+		after string
+		next  string
+	}{
+		{"FREQ=DAILY", "2021-09-01", "2021-09-01", "2021-09-02"},
+		{"FREQ=DAILY;INTERVAL=3", "2021-09-01", "2021-09-02", "2021-09-04"},
+		{"FREQ=WEEKLY", "2021-09-01", "2021-09-01", "2021-09-08"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "2021-09-06", "2021-09-06", "2021-09-09"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "2021-09-06", "2021-09-09", "2021-09-20"},
+		{"RRULE:FREQ=MONTHLY", "2021-01-15", "2021-01-15", "2021-02-15"},
+		{"FREQ=MONTHLY;BYMONTHDAY=31", "2021-01-31", "2021-01-31", "2021-03-31"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "2021-01-31", "2021-01-31", "2021-02-28"},
+		{"FREQ=MONTHLY;BYDAY=1MO", "2021-09-06", "2021-09-06", "2021-10-04"},
+		{"FREQ=MONTHLY;BYDAY=-1FR", "2021-09-24", "2021-09-24", "2021-10-29"},
+		{"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1", "2021-01-01", "2021-01-01", "2021-04-01"},
+		{"FREQ=YEARLY", "2020-02-29", "2020-02-29", "2024-02-29"},
+		{"FREQ=DAILY", "2021-09-10", "2021-09-01", "2021-09-10"},
+	}
+	for _, test := range tests {
+		rule, err := Parse(test.rule)
+		if err != nil {
+			t.Fatalf("%s: %v", test.rule, err)
+		}
+		next, ok := rule.Next(date(test.start), date(test.after))
+		if !ok || next.Format(models.DateLayout) != test.next {
+			t.Errorf("%s from %s after %s: got %s (%v), want %s", test.rule, test.start, test.after, next.Format(models.DateLayout), ok, test.next)
+		}
+	}
+}
+
+func TestNextUntil(t *testing.T) {
+	rule, err := Parse("FREQ=WEEKLY;UNTIL=20210914")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next, ok := rule.Next(date("2021-09-01"), date("2021-09-01")); !ok || next != date("2021-09-08") {
+		t.Errorf("unexpected next occurrence %s (%v)", next, ok)
+	}
+	if next, ok := rule.Next(date("2021-09-01"), date("2021-09-08")); ok {
+		t.Errorf("expected the series to end, got %s", next)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=DAILY;BYMONTHDAY=1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20210901",
+		"FREQ=DAILY;BYSETPOS=1",
+	} {
+		if _, err := Parse(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestNextDateCount(t *testing.T) {
+	series := &models.Recurrence{RRule: "FREQ=DAILY;COUNT=3", StartDate: "2021-09-01T00:00:00Z"}
+	if next, err := NextDate(series, "2021-09-02T00:00:00Z", 2); err != nil || next != "2021-09-03" {
+		t.Errorf("unexpected next date %q (%v)", next, err)
+	}
+	if next, err := NextDate(series, "2021-09-03", 3); err != nil || next != "" {
+		t.Errorf("expected the series to end after 3 occurrences, got %q (%v)", next, err)
+	}
+}
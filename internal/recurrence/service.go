@@ -0,0 +1,136 @@
+package recurrence
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Service generates the occurrences of recurring tasks: the next one is
+// created as soon as the latest occurrence is done, or when its date arrives,
+// whichever comes first.
+type Service struct {
+	Recurrences models.RecurrenceModel
+	Tasks       models.TaskModel
+	Events      *events.Bus
+}
+
+func NewService(recurrenceModel models.RecurrenceModel, taskModel models.TaskModel) *Service {
+	return &Service{
+		Recurrences: recurrenceModel,
+		Tasks:       taskModel,
+	}
+}
+
+func (s *Service) Register(bus *events.Bus) {
+	s.Events = bus
+	bus.Subscribe(s.Handle)
+}
+
+func (s *Service) Handle(event events.Event) {
+	if event.Type != events.TaskUpdated {
+		return
+	}
+	task, previous := event.Task, event.PreviousTask
+	if task.RecurrenceID == 0 || task.Status != models.Done || previous.Status == models.Done {
+		return
+	}
+	recurrence, err := s.Recurrences.GetRecurrenceById(task.RecurrenceID)
+	if err != nil {
+		log.Printf("recurrence: could not load series %d: %v\n", task.RecurrenceID, err)
+		return
+	}
+	latest, err := s.Recurrences.GetLatestOccurrence(recurrence.ID)
+	if err != nil || latest.ID != task.ID {
+		// only completing the latest occurrence moves the series forward
+		return
+	}
+	if _, err := s.generate(recurrence, latest); err != nil {
+		log.Printf("recurrence: could not generate next occurrence of series %d: %v\n", recurrence.ID, err)
+	}
+}
+
+// GenerateDue creates every occurrence whose date is on or before now,
+// catching up on dates missed while the service was down.
+func (s *Service) GenerateDue(now time.Time) error {
+	today := now.Format(models.DateLayout)
+	recurrences, err := s.Recurrences.GetDueRecurrences(today)
+	if err != nil {
+		return err
+	}
+	for _, recurrence := range recurrences {
+		for recurrence != nil && recurrence.NextDate != "" && formatDate(recurrence.NextDate) <= today {
+			latest, err := s.Recurrences.GetLatestOccurrence(recurrence.ID)
+			if err != nil {
+				return fmt.Errorf("series %d: %w", recurrence.ID, err)
+			}
+			recurrence, err = s.generate(recurrence, latest)
+			if err != nil {
+				return fmt.Errorf("series %d: %w", recurrence.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// generate creates the occurrence due on recurrence.NextDate from template and
+// returns the advanced series, or nil if another replica got there first.
+func (s *Service) generate(recurrence *models.Recurrence, template *models.Task) (*models.Recurrence, error) {
+	if recurrence.NextDate == "" {
+		return recurrence, nil
+	}
+	next, err := NextDate(recurrence, recurrence.NextDate, recurrence.Occurrences+1)
+	if err != nil {
+		return recurrence, err
+	}
+	id, err := s.Recurrences.CreateOccurrence(recurrence, template, next)
+	if err != nil {
+		return recurrence, err
+	}
+	if id == 0 {
+		return nil, nil
+	}
+	if created, err := s.Tasks.GetTaskById(id); err == nil {
+		s.Events.Publish(events.Event{Type: events.TaskCreated, Task: created})
+	}
+	advanced := *recurrence
+	advanced.NextDate = next
+	advanced.Occurrences++
+	return &advanced, nil
+}
+
+// NextDate returns the date of the occurrence following the one on after, or
+// "" when the series ends because of COUNT (occurrences is the number of
+// occurrences that will exist) or UNTIL.
+func NextDate(recurrence *models.Recurrence, after string, occurrences int) (string, error) {
+	rule, err := Parse(recurrence.RRule)
+	if err != nil {
+		return "", err
+	}
+	if rule.Count > 0 && occurrences >= rule.Count {
+		return "", nil
+	}
+	start, err := models.ParseDate(recurrence.StartDate)
+	if err != nil {
+		return "", fmt.Errorf("invalid start date: %w", err)
+	}
+	from, err := models.ParseDate(after)
+	if err != nil {
+		return "", fmt.Errorf("invalid date: %w", err)
+	}
+	next, ok := rule.Next(start, from)
+	if !ok {
+		return "", nil
+	}
+	return next.Format(models.DateLayout), nil
+}
+
+func formatDate(value string) string {
+	date, err := models.ParseDate(value)
+	if err != nil {
+		return value
+	}
+	return date.Format(models.DateLayout)
+}
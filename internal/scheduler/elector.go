@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"log"
+)
+
+// PostgresElector elects a leader with a session-level advisory lock. The
+// lock lives as long as the dedicated connection that took it, so a replica
+// that dies or loses its connection hands leadership over automatically.
+type PostgresElector struct {
+	DB  *sql.DB
+	Key int64
+
+	conn *sql.Conn
+}
+
+func NewPostgresElector(db *sql.DB, key int64) *PostgresElector {
+	return &PostgresElector{DB: db, Key: key}
+}
+
+func (e *PostgresElector) Acquire(ctx context.Context) (bool, error) {
+	if e.conn != nil {
+		if err := e.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		log.Println("scheduler: lost connection holding the leader lock")
+		e.Release()
+	}
+	conn, err := e.DB.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var acquired bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", e.Key).Scan(&acquired)
+	if err != nil || !acquired {
+		conn.Close()
+		return false, err
+	}
+	e.conn = conn
+	return true, nil
+}
+
+func (e *PostgresElector) Release() {
+	if e.conn == nil {
+		return
+	}
+	_, _ = e.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", e.Key)
+	e.conn.Close()
+	e.conn = nil
+}
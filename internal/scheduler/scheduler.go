@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"ProjectManagementService/internal/models"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Elector decides which replica runs the jobs. Acquire is called on every
+// tick and reports whether this replica is (still) the leader.
+type Elector interface {
+	Acquire(ctx context.Context) (bool, error)
+	Release()
+}
+
+type JobFunc func(ctx context.Context, scheduledAt time.Time) error
+
+type job struct {
+	name     string
+	schedule *Schedule
+	run      JobFunc
+	next     time.Time
+}
+
+// Scheduler runs registered jobs on their cron schedules on the leader replica
+// only and records every run in the job run history.
+type Scheduler struct {
+	Elector Elector
+	Runs    models.JobRunModel
+	// Tick is how often due jobs and leadership are checked.
+	Tick time.Duration
+	// Now is the clock, replaceable in tests.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	jobs   []*job
+	leader bool
+}
+
+func New(elector Elector, runs models.JobRunModel) *Scheduler {
+	return &Scheduler{
+		Elector: elector,
+		Runs:    runs,
+		Tick:    30 * time.Second,
+		Now:     time.Now,
+	}
+}
+
+func (s *Scheduler) Add(name, spec string, run JobFunc) error {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, j := range s.jobs {
+		if j.name == name {
+			return fmt.Errorf("job %s is already registered", name)
+		}
+	}
+	s.jobs = append(s.jobs, &job{name: name, schedule: schedule, run: run})
+	return nil
+}
+
+// Run blocks until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Tick)
+	defer ticker.Stop()
+	defer s.Elector.Release()
+	for {
+		s.RunDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue checks leadership and runs every job whose next activation has passed.
+func (s *Scheduler) RunDue(ctx context.Context) {
+	leader, err := s.Elector.Acquire(ctx)
+	if err != nil {
+		log.Printf("scheduler: leader election failed: %v\n", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !leader {
+		s.leader = false
+		return
+	}
+	now := s.Now().UTC()
+	if !s.leader {
+		// a new leader resumes each job after its last recorded run so that
+		// runs missed during failover are caught up once, not repeated
+		s.leader = true
+		for _, j := range s.jobs {
+			last, err := s.Runs.GetLastScheduledAt(j.name)
+			if err != nil || last.IsZero() {
+				last = now
+			}
+			j.next = j.schedule.Next(last)
+		}
+	}
+	for _, j := range s.jobs {
+		if j.next.IsZero() || j.next.After(now) {
+			continue
+		}
+		scheduledAt := j.next
+		j.next = j.schedule.Next(now)
+		s.execute(ctx, j, scheduledAt)
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, j *job, scheduledAt time.Time) {
+	id, err := s.Runs.StartRun(j.name, scheduledAt)
+	if err != nil {
+		log.Printf("scheduler: could not record run of %s: %v\n", j.name, err)
+		return
+	}
+	if id == 0 {
+		// another replica already ran this activation
+		return
+	}
+	status, message := models.JobRunSucceeded, ""
+	if err := s.safeRun(ctx, j, scheduledAt); err != nil {
+		status, message = models.JobRunFailed, err.Error()
+		log.Printf("scheduler: job %s failed: %v\n", j.name, err)
+	}
+	if err := s.Runs.FinishRun(id, status, message); err != nil {
+		log.Printf("scheduler: could not record result of %s: %v\n", j.name, err)
+	}
+}
+
+func (s *Scheduler) safeRun(ctx context.Context, j *job, scheduledAt time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return j.run(ctx, scheduledAt)
+}
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"ProjectManagementService/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	from := time.Date(2021, 9, 10, 8, 30, 0, 0, time.UTC) // Friday
+	cases := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2021, 9, 10, 8, 45, 0, 0, time.UTC)},
+		{"0 8 * * *", time.Date(2021, 9, 11, 8, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1", time.Date(2021, 9, 13, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2021, 9, 10, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2021, 9, 13, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2021, 9, 12, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 8 29 2 *", time.Date(2024, 2, 29, 8, 30, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		schedule, err := ParseCron(c.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", c.spec, err)
+		}
+		if next := schedule.Next(from); !next.Equal(c.expected) {
+			t.Errorf("%s: got %v want %v", c.spec, next, c.expected)
+		}
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+type fakeElector struct {
+	leader bool
+}
+
+func (e *fakeElector) Acquire(ctx context.Context) (bool, error) {
+	return e.leader, nil
+}
+
+func (e *fakeElector) Release() {}
+
+func TestSchedulerRunsDueJobsOnLeaderOnly(t *testing.T) {
+	now := time.Date(2021, 9, 10, 8, 0, 30, 0, time.UTC)
+	recorded := map[string]bool{}
+	finished := map[int]string{}
+	runs := &models.MockJobRunModel{
+		MockGetLastScheduledAt: func(jobName string) (time.Time, error) {
+			// the previous leader last ran the job yesterday
+			return time.Date(2021, 9, 9, 8, 0, 0, 0, time.UTC), nil
+		},
+		MockStartRun: func(jobName string, scheduledAt time.Time) (int, error) {
+			key := jobName + scheduledAt.String()
+			if recorded[key] {
+				return 0, nil
+			}
+			recorded[key] = true
+			return len(recorded), nil
+		},
+		MockFinishRun: func(id int, status string, runErr string) error {
+			finished[id] = status
+			return nil
+		},
+	}
+	elector := &fakeElector{}
+	s := New(elector, runs)
+	s.Now = func() time.Time { return now }
+
+	calls := 0
+	if err := s.Add("reminders", "0 8 * * *", func(ctx context.Context, scheduledAt time.Time) error {
+		calls++
+		if !scheduledAt.Equal(time.Date(2021, 9, 10, 8, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected scheduled time %v", scheduledAt)
+		}
+		return errors.New("smtp down")
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.RunDue(context.Background())
+	if calls != 0 {
+		t.Fatalf("a follower must not run jobs")
+	}
+
+	elector.leader = true
+	s.RunDue(context.Background())
+	s.RunDue(context.Background())
+	if calls != 1 {
+		t.Fatalf("expected the job to run once, ran %d times", calls)
+	}
+	if finished[1] != models.JobRunFailed {
+		t.Errorf("expected the failed run to be recorded, got %v", finished)
+	}
+
+	// leadership moves away and back: the activation already recorded is not repeated
+	elector.leader = false
+	s.RunDue(context.Background())
+	elector.leader = true
+	s.RunDue(context.Background())
+	if calls != 1 {
+		t.Errorf("expected no duplicate run after failover, ran %d times", calls)
+	}
+}
@@ -0,0 +1,66 @@
+package timeline
+
+import (
+	"ProjectManagementService/internal/events"
+	"ProjectManagementService/internal/models"
+	"log"
+)
+
+// Service keeps dependent tasks after their predecessors: when the dates of a
+// task change, the tasks that would now start too early are pushed later.
+// Tasks are never pulled earlier.
+type Service struct {
+	Dependencies models.DependencyModel
+	Tasks        models.TaskModel
+}
+
+func NewService(dependencyModel models.DependencyModel, taskModel models.TaskModel) *Service {
+	return &Service{
+		Dependencies: dependencyModel,
+		Tasks:        taskModel,
+	}
+}
+
+func (s *Service) Register(bus *events.Bus) {
+	bus.Subscribe(s.Handle)
+}
+
+func (s *Service) Handle(event events.Event) {
+	if event.Type != events.TaskUpdated {
+		return
+	}
+	task, previous := event.Task, event.PreviousTask
+	if task.StartDate == previous.StartDate && task.DueDate == previous.DueDate && (task.Status == models.Done) == (previous.Status == models.Done) {
+		return
+	}
+	if _, err := s.Reschedule(task.ProjectID); err != nil {
+		log.Printf("timeline: could not reschedule project %d: %v\n", task.ProjectID, err)
+	}
+}
+
+// Reschedule moves the tasks of the project that start before their
+// predecessors allow and returns their new dates.
+func (s *Service) Reschedule(projectID int) ([]models.TaskDates, error) {
+	timeline, err := s.Load(projectID)
+	if err != nil {
+		return nil, err
+	}
+	shifts := timeline.Shifts()
+	if len(shifts) == 0 {
+		return shifts, nil
+	}
+	return shifts, s.Dependencies.UpdateTaskDates(shifts)
+}
+
+// Load builds the timeline of the project from its tasks and dependencies.
+func (s *Service) Load(projectID int) (*Timeline, error) {
+	tasks, err := s.Tasks.SearchTaskByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := s.Dependencies.GetProjectDependencies(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return New(projectID, tasks, dependencies)
+}
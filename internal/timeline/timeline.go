@@ -0,0 +1,262 @@
+// Package timeline lays out the tasks of a project on a calendar for Gantt
+// charts: it runs the critical path method over the task dependencies and
+// works out the dates that respect them.
+package timeline
+
+import (
+	"ProjectManagementService/internal/models"
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrCycle is returned when the dependencies loop back on themselves.
+var ErrCycle = errors.New("task dependencies form a cycle")
+
+// Bar is a task on the timeline. Start and End are the planned dates; the
+// early dates are the soonest the task can happen given its predecessors,
+// the late dates the latest it can happen without delaying the project.
+// Dates are inclusive days.
+type Bar struct {
+	TaskID            int               `json:"task_id"`
+	Title             string            `json:"title"`
+	Status            models.StatusEnum `json:"status"`
+	ParentID          int               `json:"parent_id"`
+	ResponsibleUserID int               `json:"responsible_user_id"`
+	Start             string            `json:"start" example:"2021-09-06"`
+	End               string            `json:"end" example:"2021-09-10"`
+	DurationDays      int               `json:"duration_days" example:"5"`
+	EarlyStart        string            `json:"early_start"`
+	EarlyFinish       string            `json:"early_finish"`
+	LateStart         string            `json:"late_start"`
+	LateFinish        string            `json:"late_finish"`
+	// SlackDays is how long the task can slip without moving the end of the
+	// project; negative when the planned dates cannot all be met.
+	SlackDays    int   `json:"slack_days"`
+	Critical     bool  `json:"critical"`
+	Predecessors []int `json:"predecessors"`
+
+	hasStart, hasEnd       bool
+	start, end, es, ef, lf int
+	links                  []*link
+	successors             []*link
+}
+
+type link struct {
+	bar *Bar
+	lag int
+}
+
+// Timeline is a project's schedule. Tasks without any date are listed in
+// Unscheduled and left out of the analysis.
+type Timeline struct {
+	ProjectID    int                  `json:"project_id"`
+	Start        string               `json:"start"`
+	Finish       string               `json:"finish"`
+	Tasks        []*Bar               `json:"tasks"`
+	Dependencies []*models.Dependency `json:"dependencies"`
+	// CriticalPath lists the tasks without slack in schedule order.
+	CriticalPath []int `json:"critical_path"`
+	Unscheduled  []int `json:"unscheduled"`
+}
+
+// New schedules tasks. A task with only a due date or only a start date
+// lasts one day. Tasks that are done keep their dates whatever their
+// predecessors; the others start no earlier than planned, and no earlier
+// than the day after each predecessor's early finish plus the lag.
+func New(projectID int, tasks []*models.Task, dependencies []*models.Dependency) (*Timeline, error) {
+	timeline := &Timeline{
+		ProjectID:    projectID,
+		Tasks:        make([]*Bar, 0),
+		Dependencies: dependencies,
+		CriticalPath: make([]int, 0),
+		Unscheduled:  make([]int, 0),
+	}
+	bars := make(map[int]*Bar)
+	for _, task := range tasks {
+		bar := newBar(task)
+		if bar == nil {
+			timeline.Unscheduled = append(timeline.Unscheduled, task.ID)
+			continue
+		}
+		bars[task.ID] = bar
+		timeline.Tasks = append(timeline.Tasks, bar)
+	}
+	for _, dependency := range dependencies {
+		predecessor, successor := bars[dependency.PredecessorID], bars[dependency.SuccessorID]
+		if predecessor == nil || successor == nil {
+			continue
+		}
+		successor.links = append(successor.links, &link{bar: predecessor, lag: dependency.LagDays})
+		successor.Predecessors = append(successor.Predecessors, predecessor.TaskID)
+		predecessor.successors = append(predecessor.successors, &link{bar: successor, lag: dependency.LagDays})
+	}
+	sort.SliceStable(timeline.Tasks, func(i, j int) bool {
+		a, b := timeline.Tasks[i], timeline.Tasks[j]
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		return a.TaskID < b.TaskID
+	})
+	order, err := topologicalOrder(timeline.Tasks)
+	if err != nil {
+		return nil, err
+	}
+	if len(order) == 0 {
+		return timeline, nil
+	}
+
+	start, finish := order[0].start, order[0].end
+	for _, bar := range order {
+		bar.es = bar.start
+		if bar.Status != models.Done {
+			for _, predecessor := range bar.links {
+				bar.es = max(bar.es, predecessor.bar.ef+1+predecessor.lag)
+			}
+		}
+		bar.ef = bar.es + bar.DurationDays - 1
+		start, finish = min(start, bar.es), max(finish, bar.ef)
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		bar := order[i]
+		bar.lf = finish
+		for _, successor := range bar.successors {
+			bar.lf = min(bar.lf, successor.bar.lf-successor.bar.DurationDays-successor.lag)
+		}
+	}
+	for _, bar := range timeline.Tasks {
+		ls := bar.lf - bar.DurationDays + 1
+		bar.SlackDays = ls - bar.es
+		bar.Critical = bar.SlackDays <= 0
+		bar.EarlyStart, bar.EarlyFinish = formatDay(bar.es), formatDay(bar.ef)
+		bar.LateStart, bar.LateFinish = formatDay(ls), formatDay(bar.lf)
+		if bar.Predecessors == nil {
+			bar.Predecessors = make([]int, 0)
+		}
+	}
+	critical := make([]*Bar, 0)
+	for _, bar := range timeline.Tasks {
+		if bar.Critical {
+			critical = append(critical, bar)
+		}
+	}
+	sort.SliceStable(critical, func(i, j int) bool { return critical[i].es < critical[j].es })
+	for _, bar := range critical {
+		timeline.CriticalPath = append(timeline.CriticalPath, bar.TaskID)
+	}
+	timeline.Start, timeline.Finish = formatDay(start), formatDay(finish)
+	return timeline, nil
+}
+
+// Shifts returns the new dates of the tasks whose early start differs from
+// their planned start, keeping their duration. Dates a task did not have are
+// left empty.
+func (t *Timeline) Shifts() []models.TaskDates {
+	shifts := make([]models.TaskDates, 0)
+	for _, bar := range t.Tasks {
+		if bar.es == bar.start {
+			continue
+		}
+		dates := models.TaskDates{TaskID: bar.TaskID}
+		if bar.hasStart {
+			dates.StartDate = formatDay(bar.es)
+		}
+		if bar.hasEnd {
+			dates.DueDate = formatDay(bar.ef)
+		}
+		shifts = append(shifts, dates)
+	}
+	return shifts
+}
+
+// CreatesCycle reports whether linking predecessorID to successorID would
+// close a loop, that is whether the predecessor already depends on the
+// successor, directly or not.
+func CreatesCycle(dependencies []*models.Dependency, predecessorID, successorID int) bool {
+	successors := make(map[int][]int)
+	for _, dependency := range dependencies {
+		successors[dependency.PredecessorID] = append(successors[dependency.PredecessorID], dependency.SuccessorID)
+	}
+	seen := make(map[int]bool)
+	pending := []int{successorID}
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if id == predecessorID {
+			return true
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		pending = append(pending, successors[id]...)
+	}
+	return false
+}
+
+func newBar(task *models.Task) *Bar {
+	bar := &Bar{
+		TaskID:            task.ID,
+		Title:             task.Title,
+		Status:            task.Status,
+		ParentID:          task.ParentID,
+		ResponsibleUserID: task.ResponsibleUserID,
+	}
+	if start, err := models.ParseDate(task.StartDate); err == nil {
+		bar.start, bar.hasStart = day(start), true
+	}
+	if end, err := models.ParseDate(task.DueDate); err == nil {
+		bar.end, bar.hasEnd = day(end), true
+	}
+	switch {
+	case !bar.hasStart && !bar.hasEnd:
+		return nil
+	case !bar.hasStart:
+		bar.start = bar.end
+	case !bar.hasEnd:
+		bar.end = bar.start
+	}
+	bar.Start, bar.End = formatDay(bar.start), formatDay(bar.end)
+	bar.DurationDays = bar.end - bar.start + 1
+	return bar
+}
+
+// topologicalOrder sorts bars so that every task comes after its
+// predecessors, keeping the given order otherwise.
+func topologicalOrder(bars []*Bar) ([]*Bar, error) {
+	pending := make(map[*Bar]int, len(bars))
+	for _, bar := range bars {
+		pending[bar] = len(bar.links)
+	}
+	order := make([]*Bar, 0, len(bars))
+	queue := make([]*Bar, 0)
+	for _, bar := range bars {
+		if pending[bar] == 0 {
+			queue = append(queue, bar)
+		}
+	}
+	for len(queue) > 0 {
+		bar := queue[0]
+		queue = queue[1:]
+		order = append(order, bar)
+		for _, successor := range bar.successors {
+			pending[successor.bar]--
+			if pending[successor.bar] == 0 {
+				queue = append(queue, successor.bar)
+			}
+		}
+	}
+	if len(order) != len(bars) {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
+
+// day numbers dates so that consecutive days differ by one.
+func day(date time.Time) int {
+	return int(date.Unix() / 86400)
+}
+
+func formatDay(day int) string {
+	return time.Unix(int64(day)*86400, 0).UTC().Format(models.DateLayout)
+}
@@ -0,0 +1,108 @@
+package timeline
+
+import (
+	"ProjectManagementService/internal/models"
+	"reflect"
+	"testing"
+)
+
+// design (3 days) is followed by build (2 days) and docs (1 day), both
+// followed by release (2 days); docs has a day of slack.
+func projectTasks() []*models.Task {
+	return []*models.Task{
+		{ID: 1, Title: "Design", Status: models.Done, StartDate: "2021-09-01T00:00:00Z", DueDate: "2021-09-03T00:00:00Z"},
+		{ID: 2, Title: "Build", Status: models.InProgress, StartDate: "2021-09-04", DueDate: "2021-09-05"},
+		{ID: 3, Title: "Docs", Status: models.New, DueDate: "2021-09-04"},
+		{ID: 4, Title: "Release", Status: models.New, StartDate: "2021-09-06", DueDate: "2021-09-07"},
+		{ID: 5, Title: "Retrospective", Status: models.New},
+	}
+}
+
+func projectDependencies() []*models.Dependency {
+	return []*models.Dependency{
+		{PredecessorID: 1, SuccessorID: 2},
+		{PredecessorID: 1, SuccessorID: 3},
+		{PredecessorID: 2, SuccessorID: 4},
+		{PredecessorID: 3, SuccessorID: 4},
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	timeline, err := New(1, projectTasks(), projectDependencies())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeline.Start != "2021-09-01" || timeline.Finish != "2021-09-07" {
+		t.Errorf("timeline spans %s to %s", timeline.Start, timeline.Finish)
+	}
+	if !reflect.DeepEqual(timeline.CriticalPath, []int{1, 2, 4}) {
+		t.Errorf("critical path is %v, want [1 2 4]", timeline.CriticalPath)
+	}
+	if !reflect.DeepEqual(timeline.Unscheduled, []int{5}) {
+		t.Errorf("unscheduled tasks are %v, want [5]", timeline.Unscheduled)
+	}
+	for _, bar := range timeline.Tasks {
+		if bar.TaskID != 3 {
+			continue
+		}
+		if bar.SlackDays != 1 || bar.LateStart != "2021-09-05" || bar.Critical {
+			t.Errorf("docs: slack %d, late start %s, critical %v", bar.SlackDays, bar.LateStart, bar.Critical)
+		}
+		if !reflect.DeepEqual(bar.Predecessors, []int{1}) {
+			t.Errorf("docs: predecessors %v", bar.Predecessors)
+		}
+	}
+	if shifts := timeline.Shifts(); len(shifts) != 0 {
+		t.Errorf("consistent plan was shifted: %v", shifts)
+	}
+}
+
+func TestShiftsFollowPredecessors(t *testing.T) {
+	tasks := projectTasks()
+	// design slips by two days and has to be reopened
+	tasks[0].Status, tasks[0].DueDate = models.InProgress, "2021-09-05"
+	dependencies := append(projectDependencies(), &models.Dependency{PredecessorID: 4, SuccessorID: 5, LagDays: 3})
+	timeline, err := New(1, tasks, dependencies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []models.TaskDates{
+		{TaskID: 2, StartDate: "2021-09-06", DueDate: "2021-09-07"},
+		{TaskID: 3, DueDate: "2021-09-06"},
+		{TaskID: 4, StartDate: "2021-09-08", DueDate: "2021-09-09"},
+	}
+	if shifts := timeline.Shifts(); !reflect.DeepEqual(shifts, want) {
+		t.Errorf("shifts are %v, want %v", shifts, want)
+	}
+	if timeline.Finish != "2021-09-09" {
+		t.Errorf("finish is %s", timeline.Finish)
+	}
+}
+
+func TestDoneTasksKeepTheirDates(t *testing.T) {
+	tasks := projectTasks()
+	tasks[1].Status, tasks[1].StartDate, tasks[1].DueDate = models.Done, "2021-09-02", "2021-09-02"
+	timeline, err := New(1, tasks, projectDependencies())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, shift := range timeline.Shifts() {
+		if shift.TaskID == 2 {
+			t.Errorf("done task was shifted to %v", shift)
+		}
+	}
+}
+
+func TestCycles(t *testing.T) {
+	dependencies := projectDependencies()
+	if !CreatesCycle(dependencies, 4, 1) {
+		t.Error("release -> design closes a loop")
+	}
+	if CreatesCycle(dependencies, 3, 2) {
+		t.Error("docs -> build does not close a loop")
+	}
+	dependencies = append(dependencies, &models.Dependency{PredecessorID: 4, SuccessorID: 1})
+	if _, err := New(1, projectTasks(), dependencies); err != ErrCycle {
+		t.Errorf("got %v, want ErrCycle", err)
+	}
+}
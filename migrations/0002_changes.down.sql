@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS tasks_changes ON tasks;
+
+DROP TRIGGER IF EXISTS projects_changes ON projects;
+
+DROP TRIGGER IF EXISTS users_changes ON users;
+
+DROP FUNCTION IF EXISTS record_change();
+
+DROP TABLE IF EXISTS changes;
@@ -0,0 +1,47 @@
+create table if not exists changes(
+    seq bigserial primary key,
+    entity varchar(64) not null,
+    entity_id int not null,
+    operation varchar(16) not null,
+    data jsonb,
+    changed_at timestamp default current_timestamp
+);
+
+create index if not exists changes_entity_idx on changes(entity, entity_id);
+
+-- Every write to a synced table appends a row to changes. The advisory lock
+-- serializes writers until commit, so sequence numbers become visible in
+-- order and a client polling with since=<seq> never skips a change.
+create or replace function record_change() returns trigger as $$
+    BEGIN
+        PERFORM pg_advisory_xact_lock(hashtext('changes'));
+        IF (TG_OP = 'DELETE') THEN
+            INSERT INTO changes (entity, entity_id, operation) VALUES (TG_TABLE_NAME, OLD.id, 'delete');
+            RETURN OLD;
+        END IF;
+        INSERT INTO changes (entity, entity_id, operation, data) VALUES (TG_TABLE_NAME, NEW.id, 'upsert', to_jsonb(NEW));
+        RETURN NEW;
+    END;
+$$ language plpgsql;
+
+drop trigger if exists users_changes on users;
+create trigger users_changes after insert or update or delete on users
+    for each row execute procedure record_change();
+
+drop trigger if exists projects_changes on projects;
+create trigger projects_changes after insert or update or delete on projects
+    for each row execute procedure record_change();
+
+drop trigger if exists tasks_changes on tasks;
+create trigger tasks_changes after insert or update or delete on tasks
+    for each row execute procedure record_change();
+
+-- seed the feed with the rows that existed before it was introduced
+insert into changes (entity, entity_id, operation, data)
+select 'users', id, 'upsert', to_jsonb(users) from users order by id;
+
+insert into changes (entity, entity_id, operation, data)
+select 'projects', id, 'upsert', to_jsonb(projects) from projects order by id;
+
+insert into changes (entity, entity_id, operation, data)
+select 'tasks', id, 'upsert', to_jsonb(tasks) from tasks order by id;
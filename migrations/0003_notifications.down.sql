@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS notification_preferences;
+
+DROP TABLE IF EXISTS notifications;
+
+DROP TABLE IF EXISTS task_watchers;
+
+DROP TABLE IF EXISTS task_comments;
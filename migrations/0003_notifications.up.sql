@@ -0,0 +1,40 @@
+create table if not exists task_comments(
+    id serial primary key,
+    task_id int references tasks(id) on delete cascade,
+    user_id int references users(id),
+    body text,
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists task_comments_task_idx on task_comments(task_id);
+
+drop trigger if exists task_comments_changes on task_comments;
+create trigger task_comments_changes after insert or update or delete on task_comments
+    for each row execute procedure record_change();
+
+create table if not exists task_watchers(
+    task_id int references tasks(id) on delete cascade,
+    user_id int references users(id) on delete cascade,
+    primary key (task_id, user_id)
+);
+
+create table if not exists notifications(
+    id serial primary key,
+    user_id int references users(id) on delete cascade,
+    type varchar(32) not null,
+    message text,
+    task_id int references tasks(id) on delete set null,
+    project_id int references projects(id) on delete set null,
+    is_read boolean not null default false,
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists notifications_user_idx on notifications(user_id, is_read);
+
+create table if not exists notification_preferences(
+    user_id int primary key references users(id) on delete cascade,
+    assignment boolean not null default true,
+    status_change boolean not null default true,
+    comment boolean not null default true,
+    mention boolean not null default true
+);
@@ -0,0 +1,7 @@
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS daily_digest;
+
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS email;
+
+DROP INDEX IF EXISTS tasks_due_date_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_date;
@@ -0,0 +1,7 @@
+alter table tasks add column if not exists due_date date;
+
+create index if not exists tasks_due_date_idx on tasks(due_date);
+
+alter table notification_preferences add column if not exists email boolean not null default true;
+
+alter table notification_preferences add column if not exists daily_digest boolean not null default false;
@@ -0,0 +1,10 @@
+create table if not exists job_runs(
+    id serial primary key,
+    job_name varchar(64) not null,
+    scheduled_at timestamp not null,
+    started_at timestamp not null default current_timestamp,
+    finished_at timestamp,
+    status varchar(16) not null,
+    error text,
+    unique (job_name, scheduled_at)
+);
@@ -0,0 +1,24 @@
+create table if not exists automation_rules(
+    id serial primary key,
+    name varchar(255) not null,
+    project_id int references projects(id) on delete cascade,
+    trigger varchar(32) not null,
+    conditions jsonb not null default '[]',
+    actions jsonb not null,
+    enabled boolean not null default true,
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists automation_rules_trigger_idx on automation_rules(trigger, project_id) where enabled;
+
+create table if not exists automation_rule_runs(
+    id serial primary key,
+    rule_id int not null references automation_rules(id) on delete cascade,
+    task_id int references tasks(id) on delete set null,
+    project_id int references projects(id) on delete set null,
+    status varchar(16) not null,
+    message text not null default '',
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists automation_rule_runs_rule_idx on automation_rule_runs(rule_id, id);
@@ -0,0 +1,14 @@
+create table if not exists task_recurrences(
+    id serial primary key,
+    rrule varchar(255) not null,
+    start_date date not null,
+    next_date date,
+    occurrences int not null default 1,
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists task_recurrences_next_date_idx on task_recurrences(next_date);
+
+alter table tasks add column if not exists recurrence_id int references task_recurrences(id) on delete set null;
+
+create index if not exists tasks_recurrence_idx on tasks(recurrence_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS template_tasks;
+
+DROP TABLE IF EXISTS project_templates;
+
+DROP INDEX IF EXISTS tasks_parent_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS labels;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,27 @@
+alter table tasks add column if not exists parent_id int references tasks(id) on delete set null;
+alter table tasks add column if not exists labels text[] not null default '{}';
+
+create index if not exists tasks_parent_idx on tasks(parent_id);
+
+create table if not exists project_templates(
+    id serial primary key,
+    name varchar(255) not null,
+    description text not null default '',
+    project_title varchar(255) not null,
+    project_description text not null default '',
+    creation_date timestamp default current_timestamp
+);
+
+create table if not exists template_tasks(
+    id serial primary key,
+    template_id int not null references project_templates(id) on delete cascade,
+    parent_id int references template_tasks(id) on delete cascade,
+    position int not null,
+    title varchar(255) not null,
+    description text not null default '',
+    priority task_priority not null default 'medium',
+    labels text[] not null default '{}',
+    due_offset_days int
+);
+
+create index if not exists template_tasks_template_idx on template_tasks(template_id, position);
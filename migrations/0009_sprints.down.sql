@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS tasks_status_history ON tasks;
+
+DROP FUNCTION IF EXISTS record_task_status();
+
+DROP TABLE IF EXISTS task_status_history;
+
+DROP TABLE IF EXISTS sprint_carryover;
+
+DROP INDEX IF EXISTS tasks_sprint_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS sprint_id;
+
+DROP TABLE IF EXISTS sprints;
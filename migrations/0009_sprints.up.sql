@@ -0,0 +1,58 @@
+create table if not exists sprints(
+    id serial primary key,
+    project_id int not null references projects(id) on delete cascade,
+    name varchar(255) not null,
+    goal text not null default '',
+    start_date date not null,
+    end_date date not null,
+    status varchar(16) not null default 'planned',
+    creation_date timestamp default current_timestamp,
+    completion_date date
+);
+
+create index if not exists sprints_project_idx on sprints(project_id, start_date);
+
+-- at most one running sprint per project
+create unique index if not exists sprints_active_idx on sprints(project_id) where status = 'active';
+
+alter table tasks add column if not exists sprint_id int references sprints(id) on delete set null;
+
+create index if not exists tasks_sprint_idx on tasks(sprint_id);
+
+-- unfinished tasks that were moved on when a sprint was completed, so that
+-- its burndown still shows them
+create table if not exists sprint_carryover(
+    sprint_id int not null references sprints(id) on delete cascade,
+    task_id int not null references tasks(id) on delete cascade,
+    primary key (sprint_id, task_id)
+);
+
+create table if not exists task_status_history(
+    id bigserial primary key,
+    task_id int not null references tasks(id) on delete cascade,
+    status task_status not null,
+    changed_at timestamp default current_timestamp
+);
+
+create index if not exists task_status_history_task_idx on task_status_history(task_id, changed_at);
+
+create or replace function record_task_status() returns trigger as $$
+    BEGIN
+        IF (TG_OP = 'INSERT' OR NEW.status IS DISTINCT FROM OLD.status) AND NEW.status IS NOT NULL THEN
+            INSERT INTO task_status_history (task_id, status) VALUES (NEW.id, NEW.status);
+        END IF;
+        RETURN NEW;
+    END;
+$$ language plpgsql;
+
+drop trigger if exists tasks_status_history on tasks;
+create trigger tasks_status_history after insert or update of status on tasks
+    for each row execute procedure record_task_status();
+
+-- seed the history from what is known about existing tasks
+insert into task_status_history (task_id, status, changed_at)
+select id, case when status = 'done' and completion_date is not null then 'new' else status end, creation_date
+from tasks where status is not null order by id;
+
+insert into task_status_history (task_id, status, changed_at)
+select id, status, completion_date from tasks where status = 'done' and completion_date is not null order by id;
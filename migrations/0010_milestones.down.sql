@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS tasks_check_groups ON tasks;
+
+DROP FUNCTION IF EXISTS check_task_groups();
+
+DROP INDEX IF EXISTS tasks_epic_idx;
+
+DROP INDEX IF EXISTS tasks_milestone_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS epic_id;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS milestone_id;
+
+DROP TABLE IF EXISTS epics;
+
+DROP TABLE IF EXISTS milestones;
@@ -0,0 +1,43 @@
+create table if not exists milestones(
+    id serial primary key,
+    project_id int not null references projects(id) on delete cascade,
+    title varchar(255) not null,
+    description text not null default '',
+    target_date date not null,
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists milestones_project_idx on milestones(project_id, target_date);
+
+create table if not exists epics(
+    id serial primary key,
+    project_id int not null references projects(id) on delete cascade,
+    title varchar(255) not null,
+    description text not null default '',
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists epics_project_idx on epics(project_id);
+
+alter table tasks add column if not exists milestone_id int references milestones(id) on delete set null;
+alter table tasks add column if not exists epic_id int references epics(id) on delete set null;
+
+create index if not exists tasks_milestone_idx on tasks(milestone_id);
+create index if not exists tasks_epic_idx on tasks(epic_id);
+
+-- a task can only be grouped within its own project
+create or replace function check_task_groups() returns trigger as $$
+    BEGIN
+        IF NEW.milestone_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM milestones WHERE id = NEW.milestone_id AND project_id = NEW.project_id) THEN
+            RAISE EXCEPTION 'milestone % does not belong to project %', NEW.milestone_id, NEW.project_id;
+        END IF;
+        IF NEW.epic_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM epics WHERE id = NEW.epic_id AND project_id = NEW.project_id) THEN
+            RAISE EXCEPTION 'epic % does not belong to project %', NEW.epic_id, NEW.project_id;
+        END IF;
+        RETURN NEW;
+    END;
+$$ language plpgsql;
+
+drop trigger if exists tasks_check_groups on tasks;
+create trigger tasks_check_groups before insert or update of milestone_id, epic_id, project_id on tasks
+    for each row execute procedure check_task_groups();
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS worklogs;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS remaining_estimate_minutes;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS original_estimate_minutes;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS story_points;
@@ -0,0 +1,16 @@
+alter table tasks add column if not exists story_points numeric(6, 1) check (story_points >= 0);
+alter table tasks add column if not exists original_estimate_minutes int check (original_estimate_minutes >= 0);
+alter table tasks add column if not exists remaining_estimate_minutes int check (remaining_estimate_minutes >= 0);
+
+create table if not exists worklogs(
+    id serial primary key,
+    task_id int not null references tasks(id) on delete cascade,
+    user_id int not null references users(id) on delete cascade,
+    date date not null,
+    minutes int not null check (minutes > 0),
+    note text not null default '',
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists worklogs_task_idx on worklogs(task_id);
+create index if not exists worklogs_user_idx on worklogs(user_id, date);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS worklogs_check_period ON worklogs;
+
+DROP FUNCTION IF EXISTS check_worklog_period();
+
+DROP TABLE IF EXISTS timesheet_transitions;
+
+DROP TABLE IF EXISTS timesheets;
@@ -0,0 +1,38 @@
+create table if not exists timesheets(
+    id serial primary key,
+    user_id int not null references users(id) on delete cascade,
+    -- monday of the week the timesheet covers
+    week_start date not null,
+    status varchar(16) not null default 'open',
+    creation_date timestamp default current_timestamp,
+    unique (user_id, week_start)
+);
+
+create table if not exists timesheet_transitions(
+    id serial primary key,
+    timesheet_id int not null references timesheets(id) on delete cascade,
+    from_status varchar(16) not null,
+    to_status varchar(16) not null,
+    user_id int references users(id) on delete set null,
+    comment text not null default '',
+    creation_date timestamp default current_timestamp
+);
+
+create index if not exists timesheet_transitions_timesheet_idx on timesheet_transitions(timesheet_id, id);
+
+-- time cannot be logged or changed in a week that was submitted or approved
+create or replace function check_worklog_period() returns trigger as $$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM timesheets WHERE user_id = NEW.user_id AND week_start = date_trunc('week', NEW.date)::date AND status IN ('submitted', 'approved')) THEN
+            RAISE EXCEPTION 'the timesheet of user % for the week of % is locked', NEW.user_id, NEW.date;
+        END IF;
+        IF TG_OP = 'UPDATE' AND EXISTS (SELECT 1 FROM timesheets WHERE user_id = OLD.user_id AND week_start = date_trunc('week', OLD.date)::date AND status IN ('submitted', 'approved')) THEN
+            RAISE EXCEPTION 'the timesheet of user % for the week of % is locked', OLD.user_id, OLD.date;
+        END IF;
+        RETURN NEW;
+    END;
+$$ language plpgsql;
+
+drop trigger if exists worklogs_check_period on worklogs;
+create trigger worklogs_check_period before insert or update on worklogs
+    for each row execute procedure check_worklog_period();
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS board_wip_limits;
+
+DROP INDEX IF EXISTS tasks_board_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS board_rank;
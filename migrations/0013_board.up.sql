@@ -0,0 +1,13 @@
+-- position of the task in its board column; the C collation keeps the order
+-- of ranks independent of the database locale. Tasks that were never moved
+-- have no rank and come last.
+alter table tasks add column if not exists board_rank varchar(64) collate "C";
+
+create index if not exists tasks_board_idx on tasks(project_id, status, board_rank);
+
+create table if not exists board_wip_limits(
+    project_id int not null references projects(id) on delete cascade,
+    status task_status not null,
+    wip_limit int not null check (wip_limit > 0),
+    primary key (project_id, status)
+);
@@ -0,0 +1,14 @@
+alter table tasks add column if not exists start_date date;
+
+-- finish-to-start links: the successor starts lag_days after the predecessor's
+-- due date at the earliest
+create table if not exists task_dependencies(
+    predecessor_id int not null references tasks(id) on delete cascade,
+    successor_id int not null references tasks(id) on delete cascade,
+    lag_days int not null default 0 check (lag_days >= 0),
+    creation_date timestamp default current_timestamp,
+    primary key (predecessor_id, successor_id),
+    check (predecessor_id <> successor_id)
+);
+
+create index if not exists task_dependencies_successor_idx on task_dependencies(successor_id);
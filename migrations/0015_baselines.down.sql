@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS baseline_tasks;
+
+DROP TABLE IF EXISTS baselines;
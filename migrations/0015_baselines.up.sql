@@ -0,0 +1,22 @@
+create table if not exists baselines(
+    id serial primary key,
+    project_id int not null references projects(id) on delete cascade,
+    name varchar(255) not null,
+    creation_date timestamp default current_timestamp,
+    unique (project_id, name)
+);
+
+-- copies of the tasks as they were when the baseline was taken; task_id is
+-- not a foreign key so that deleted tasks still show up as removed
+create table if not exists baseline_tasks(
+    baseline_id int not null references baselines(id) on delete cascade,
+    task_id int not null,
+    title varchar(255) not null,
+    status task_status,
+    start_date date,
+    due_date date,
+    story_points numeric(6, 1),
+    original_estimate_minutes int,
+    remaining_estimate_minutes int,
+    primary key (baseline_id, task_id)
+);
@@ -0,0 +1,20 @@
+-- users without a row work 40 hours a week and belong to no team
+create table if not exists user_capacity(
+    user_id int primary key references users(id) on delete cascade,
+    weekly_hours numeric(5, 1) not null default 40 check (weekly_hours >= 0),
+    team varchar(255) not null default ''
+);
+
+create index if not exists user_capacity_team_idx on user_capacity(team);
+
+create table if not exists time_off(
+    id serial primary key,
+    user_id int not null references users(id) on delete cascade,
+    start_date date not null,
+    end_date date not null,
+    reason text not null default '',
+    creation_date timestamp default current_timestamp,
+    check (start_date <= end_date)
+);
+
+create index if not exists time_off_user_idx on time_off(user_id, start_date);
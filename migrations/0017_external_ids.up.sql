@@ -0,0 +1,10 @@
+-- ties the users, projects, tasks and comments of an import to their ids in
+-- the tool they came from, so that importing again updates them
+create table if not exists external_ids(
+    source varchar(32) not null,
+    kind varchar(32) not null,
+    external_id varchar(255) not null,
+    entity_id int not null,
+    creation_date timestamp default current_timestamp,
+    primary key (source, kind, external_id)
+);
@@ -0,0 +1,10 @@
+-- secret links to the iCalendar feed of a user or of a project; a new token
+-- replaces the old one, which stops working
+create table if not exists calendar_feeds(
+    id serial primary key,
+    token varchar(64) not null unique,
+    user_id int unique references users(id) on delete cascade,
+    project_id int unique references projects(id) on delete cascade,
+    creation_date timestamp default current_timestamp,
+    check ((user_id is null) <> (project_id is null))
+);
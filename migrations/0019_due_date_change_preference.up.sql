@@ -0,0 +1 @@
+alter table notification_preferences add column if not exists due_date_change boolean not null default true;
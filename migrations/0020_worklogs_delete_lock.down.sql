@@ -0,0 +1,16 @@
+CREATE OR REPLACE FUNCTION check_worklog_period() RETURNS trigger AS $$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM timesheets WHERE user_id = NEW.user_id AND week_start = date_trunc('week', NEW.date)::date AND status IN ('submitted', 'approved')) THEN
+            RAISE EXCEPTION 'the timesheet of user % for the week of % is locked', NEW.user_id, NEW.date;
+        END IF;
+        IF TG_OP = 'UPDATE' AND EXISTS (SELECT 1 FROM timesheets WHERE user_id = OLD.user_id AND week_start = date_trunc('week', OLD.date)::date AND status IN ('submitted', 'approved')) THEN
+            RAISE EXCEPTION 'the timesheet of user % for the week of % is locked', OLD.user_id, OLD.date;
+        END IF;
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS worklogs_check_period ON worklogs;
+
+CREATE TRIGGER worklogs_check_period BEFORE INSERT OR UPDATE ON worklogs
+    FOR EACH ROW EXECUTE PROCEDURE check_worklog_period();
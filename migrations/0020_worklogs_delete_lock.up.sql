@@ -0,0 +1,23 @@
+-- time cannot be logged, changed or deleted in a week that was submitted or
+-- approved, neither directly nor by deleting its task or user. The error names
+-- the timesheets_locked constraint so that it can be told from other failures.
+create or replace function check_worklog_period() returns trigger as $$
+    BEGIN
+        IF TG_OP <> 'DELETE' AND EXISTS (SELECT 1 FROM timesheets WHERE user_id = NEW.user_id AND week_start = date_trunc('week', NEW.date)::date AND status IN ('submitted', 'approved')) THEN
+            RAISE EXCEPTION 'the timesheet of user % for the week of % is locked', NEW.user_id, NEW.date
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'timesheets_locked';
+        END IF;
+        IF TG_OP <> 'INSERT' AND EXISTS (SELECT 1 FROM timesheets WHERE user_id = OLD.user_id AND week_start = date_trunc('week', OLD.date)::date AND status IN ('submitted', 'approved')) THEN
+            RAISE EXCEPTION 'the timesheet of user % for the week of % is locked', OLD.user_id, OLD.date
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'timesheets_locked';
+        END IF;
+        IF TG_OP = 'DELETE' THEN
+            RETURN OLD;
+        END IF;
+        RETURN NEW;
+    END;
+$$ language plpgsql;
+
+drop trigger if exists worklogs_check_period on worklogs;
+create trigger worklogs_check_period before insert or update or delete on worklogs
+    for each row execute procedure check_worklog_period();
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS tasks_check_wip_limit ON tasks;
+
+DROP FUNCTION IF EXISTS check_wip_limit();
@@ -0,0 +1,28 @@
+-- a task cannot enter a board column that already holds as many tasks as its
+-- WIP limit, whether it is moved on the board, updated, created, imported or
+-- changed by an automation rule. The limit row is locked while the column is
+-- counted, so that concurrent changes cannot both take the last free place.
+create or replace function check_wip_limit() returns trigger as $$
+    DECLARE
+        column_limit int;
+        column_count int;
+    BEGIN
+        IF TG_OP = 'UPDATE' AND NEW.status = OLD.status AND NEW.project_id IS NOT DISTINCT FROM OLD.project_id THEN
+            RETURN NEW;
+        END IF;
+        SELECT wip_limit INTO column_limit FROM board_wip_limits WHERE project_id = NEW.project_id AND status = NEW.status FOR UPDATE;
+        IF column_limit IS NULL THEN
+            RETURN NEW;
+        END IF;
+        SELECT COUNT(*) INTO column_count FROM tasks WHERE project_id = NEW.project_id AND status = NEW.status AND id <> NEW.id;
+        IF column_count >= column_limit THEN
+            RAISE EXCEPTION 'the % column of project % is at its WIP limit of %', NEW.status, NEW.project_id, column_limit
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'board_wip_limits';
+        END IF;
+        RETURN NEW;
+    END;
+$$ language plpgsql;
+
+drop trigger if exists tasks_check_wip_limit on tasks;
+create trigger tasks_check_wip_limit before insert or update of status, project_id on tasks
+    for each row execute procedure check_wip_limit();
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS milestones_revision ON milestones;
+
+DROP FUNCTION IF EXISTS count_milestone_revision();
+
+ALTER TABLE milestones DROP COLUMN IF EXISTS modified_at;
+ALTER TABLE milestones DROP COLUMN IF EXISTS sequence;
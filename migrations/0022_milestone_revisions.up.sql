@@ -0,0 +1,20 @@
+-- milestones keep their own revision for their calendar entries: sequence
+-- counts the changes of a milestone and modified_at is the time of the last
+alter table milestones add column if not exists sequence int not null default 0;
+alter table milestones add column if not exists modified_at timestamp not null default current_timestamp;
+
+update milestones set modified_at = creation_date where creation_date is not null;
+
+create or replace function count_milestone_revision() returns trigger as $$
+    BEGIN
+        IF (NEW.project_id, NEW.title, NEW.description, NEW.target_date) IS DISTINCT FROM (OLD.project_id, OLD.title, OLD.description, OLD.target_date) THEN
+            NEW.sequence := OLD.sequence + 1;
+            NEW.modified_at := current_timestamp;
+        END IF;
+        RETURN NEW;
+    END;
+$$ language plpgsql;
+
+drop trigger if exists milestones_revision on milestones;
+create trigger milestones_revision before update on milestones
+    for each row execute procedure count_milestone_revision();
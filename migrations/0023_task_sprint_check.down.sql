@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION check_task_groups() RETURNS TRIGGER AS $$
+    BEGIN
+        IF NEW.milestone_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM milestones WHERE id = NEW.milestone_id AND project_id = NEW.project_id) THEN
+            RAISE EXCEPTION 'milestone % does not belong to project %', NEW.milestone_id, NEW.project_id;
+        END IF;
+        IF NEW.epic_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM epics WHERE id = NEW.epic_id AND project_id = NEW.project_id) THEN
+            RAISE EXCEPTION 'epic % does not belong to project %', NEW.epic_id, NEW.project_id;
+        END IF;
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_check_groups ON tasks;
+CREATE TRIGGER tasks_check_groups BEFORE INSERT OR UPDATE OF milestone_id, epic_id, project_id ON tasks
+    FOR EACH ROW EXECUTE PROCEDURE check_task_groups();
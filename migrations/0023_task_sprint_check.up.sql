@@ -0,0 +1,23 @@
+-- a task can only be in a sprint of its own project, like its milestone and epic
+create or replace function check_task_groups() returns trigger as $$
+    BEGIN
+        IF NEW.milestone_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM milestones WHERE id = NEW.milestone_id AND project_id = NEW.project_id) THEN
+            RAISE EXCEPTION 'milestone % does not belong to project %', NEW.milestone_id, NEW.project_id;
+        END IF;
+        IF NEW.epic_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM epics WHERE id = NEW.epic_id AND project_id = NEW.project_id) THEN
+            RAISE EXCEPTION 'epic % does not belong to project %', NEW.epic_id, NEW.project_id;
+        END IF;
+        IF NEW.sprint_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM sprints WHERE id = NEW.sprint_id AND project_id = NEW.project_id) THEN
+            RAISE EXCEPTION 'sprint % does not belong to project %', NEW.sprint_id, NEW.project_id;
+        END IF;
+        RETURN NEW;
+    END;
+$$ language plpgsql;
+
+drop trigger if exists tasks_check_groups on tasks;
+create trigger tasks_check_groups before insert or update of milestone_id, epic_id, sprint_id, project_id on tasks
+    for each row execute procedure check_task_groups();
+
+-- tasks moved to another project before kept the sprint of the old one
+update tasks set sprint_id = null
+    where sprint_id is not null and not exists (select 1 from sprints where id = tasks.sprint_id and project_id = tasks.project_id);